@@ -1,7 +1,6 @@
 package main
 
 import (
-	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
 	"github.com/quarkcloudio/quark-go/v2/pkg/adapter/ginadapter"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install"
@@ -15,9 +14,6 @@ import (
 func main() {
 	r := gin.Default()
 
-	// WEB根目录
-	r.Use(static.Serve("/", static.LocalFile("./web/app", false)))
-
 	// 数据库配置信息
 	dsn := "root:fK7xPGJi1gJfIief@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local"
 
@@ -43,5 +39,8 @@ func main() {
 	// 适配gin
 	ginadapter.Adapter(b, r)
 
+	// WEB根目录
+	r.NoRoute(gin.WrapH(b.StaticHandler("./web/app")))
+
 	r.Run(":3000")
 }
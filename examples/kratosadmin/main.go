@@ -1,8 +1,6 @@
 package main
 
 import (
-	stdhttp "net/http"
-
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/quarkcloudio/quark-go/v2/pkg/adapter/kratosadapter"
@@ -45,7 +43,7 @@ func main() {
 	kratosadapter.Adapter(b, hs)
 
 	// WEB根目录，只能放在后面，否则与其他路由有冲突
-	hs.HandlePrefix("/", stdhttp.FileServer(stdhttp.Dir("./web/app")))
+	hs.HandlePrefix("/", b.StaticHandler("./web/app"))
 
 	// 创建服务
 	app := kratos.New(
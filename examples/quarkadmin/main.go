@@ -3,14 +3,15 @@ package main
 import (
 	admininstall "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install"
 	adminmiddleware "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/middleware"
+	adminopenapi "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/openapi"
 	adminservice "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service"
 	miniappinstall "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/install"
 	miniappmiddleware "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/middleware"
 	miniappservice "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/service"
 	toolservice "github.com/quarkcloudio/quark-go/v2/pkg/app/tool/service"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
+	"github.com/quarkcloudio/quark-go/v2/pkg/config"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
 )
 
 func main() {
@@ -18,8 +19,13 @@ func main() {
 	// 定义服务
 	var providers []interface{}
 
-	// 数据库配置信息
-	dsn := "root:fK7xPGJi1gJfIief@tcp(127.0.0.1:3306)/quarkgo?charset=utf8&parseTime=True&loc=Local"
+	// 加载配置文件，可以使用环境变量覆盖，例如：QUARK_PROFILE=prod QUARK_APP_KEY=xxx
+	// 未创建config.yaml时使用配置示例，密钥通过环境变量QUARK_APP_KEY、QUARK_DATABASE_PASSWORD设置
+	configPath := "config.yaml"
+	if !file.IsExist(configPath) {
+		configPath = "config.example.yaml"
+	}
+	cfg := config.MustLoad(configPath)
 
 	// 加载后台服务
 	providers = append(providers, adminservice.Providers...)
@@ -31,23 +37,13 @@ func main() {
 	providers = append(providers, toolservice.Providers...)
 
 	// 配置资源
-	config := &builder.Config{
-		AppKey:    "123456",
-		Providers: providers,
-		DBConfig: &builder.DBConfig{
-			Dialector: mysql.Open(dsn),
-			Opts:      &gorm.Config{},
-		},
-		RedisConfig: &builder.RedisConfig{
-			Host:     "127.0.0.1",
-			Port:     "6379",
-			Password: "",
-			Database: 0,
-		},
+	builderConfig, err := cfg.BuilderConfig(providers)
+	if err != nil {
+		panic(err)
 	}
 
 	// 实例化对象
-	b := builder.New(config)
+	b := builder.New(builderConfig)
 
 	// WEB根目录
 	b.Static("/", "./web/app")
@@ -58,6 +54,9 @@ func main() {
 	// 管理后台中间件
 	b.Use(adminmiddleware.Handle)
 
+	// OpenAPI文档，挂载在后台路由下以复用登录认证与权限校验
+	b.GET("/api/admin/openapi.json", adminopenapi.Handle)
+
 	// 构建MiniApp数据库
 	miniappinstall.Handle()
 
@@ -70,5 +69,5 @@ func main() {
 	})
 
 	// 启动服务
-	b.Run(":3000")
+	b.Run(cfg.Addr)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 )
 
@@ -25,6 +26,9 @@ func RouteAdapter(b *builder.Engine, ctx *fiber.Ctx) error {
 		ctx.Response().BodyWriter(),
 	)
 
+	// 传递fiber请求的上下文，用于关联链路
+	context.SetContext(ctx.UserContext())
+
 	return b.Render(context)
 }
 
@@ -71,4 +75,13 @@ func Adapter(b *builder.Engine, app *fiber.App) {
 			})
 		}
 	}
+
+	// Prometheus指标、存活检查、就绪检查及私有文件接口，fiber的GET路由同时匹配HEAD请求
+	for _, v := range b.GetHandlerMappings() {
+		if v.Prefix {
+			app.Get(v.Path+"*", adaptor.HTTPHandler(v.Handler))
+			continue
+		}
+		app.Get(v.Path, adaptor.HTTPHandler(v.Handler))
+	}
 }
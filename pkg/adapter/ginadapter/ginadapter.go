@@ -34,6 +34,9 @@ func RouteAdapter(b *builder.Engine, ctx *gin.Context) {
 		ctx.Writer,
 	)
 
+	// 传递gin请求的上下文，用于关联链路
+	context.SetContext(ctx.Request.Context())
+
 	b.Render(context)
 }
 
@@ -80,4 +83,14 @@ func Adapter(b *builder.Engine, app *gin.Engine) {
 			})
 		}
 	}
+
+	// Prometheus指标、存活检查、就绪检查及私有文件接口
+	for _, v := range b.GetHandlerMappings() {
+		if v.Prefix {
+			app.GET(v.Path+"*filepath", gin.WrapH(v.Handler))
+			app.HEAD(v.Path+"*filepath", gin.WrapH(v.Handler))
+			continue
+		}
+		app.GET(v.Path, gin.WrapH(v.Handler))
+	}
 }
@@ -3,14 +3,21 @@ package hertzadapter
 import (
 	"bytes"
 	"context"
+	"net/http"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 )
 
 // 适配hertz框架路由
 func RouteAdapter(b *builder.Engine, ctx *app.RequestContext) {
+	RouteAdapterWithContext(b, context.Background(), ctx)
+}
+
+// 适配hertz框架路由，c为hertz请求的上下文，用于关联链路
+func RouteAdapterWithContext(b *builder.Engine, c context.Context, ctx *app.RequestContext) {
 	body, err := ctx.Body()
 	if err != nil {
 		ctx.JSON(200, builder.Error(err.Error()))
@@ -31,10 +38,27 @@ func RouteAdapter(b *builder.Engine, ctx *app.RequestContext) {
 		bytes.NewReader(body),
 		ctx.Response.BodyWriter(),
 	)
+	context.SetContext(c)
 
 	b.Render(context)
 }
 
+// 适配Prometheus指标接口
+func MetricsAdapter(b *builder.Engine, ctx *app.RequestContext) {
+	HandlerAdapter(b.MetricsHandler(), ctx)
+}
+
+// 适配标准库的http.Handler，用于内置的指标、存活检查及就绪检查接口
+func HandlerAdapter(handler http.Handler, ctx *app.RequestContext) {
+	request, err := adaptor.GetCompatRequest(&ctx.Request)
+	if err != nil {
+		ctx.String(500, err.Error())
+		return
+	}
+
+	handler.ServeHTTP(adaptor.GetCompatResponseWriter(&ctx.Response), request)
+}
+
 // 适配hertz框架
 func Adapter(b *builder.Engine, r *server.Hertz) {
 
@@ -46,36 +70,50 @@ func Adapter(b *builder.Engine, r *server.Hertz) {
 		switch v.Method {
 		case "GET":
 			r.GET(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "HEAD":
 			r.HEAD(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "OPTIONS":
 			r.OPTIONS(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "POST":
 			r.POST(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "PUT":
 			r.PUT(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "PATCH":
 			r.PATCH(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "DELETE":
 			r.DELETE(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		case "Any":
 			r.Any(v.Path, func(c context.Context, ctx *app.RequestContext) {
-				RouteAdapter(b, ctx)
+				RouteAdapterWithContext(b, c, ctx)
 			})
 		}
 	}
+
+	// Prometheus指标、存活检查、就绪检查及私有文件接口
+	for _, v := range b.GetHandlerMappings() {
+		handler := v.Handler
+		handle := func(c context.Context, ctx *app.RequestContext) {
+			HandlerAdapter(handler, ctx)
+		}
+		if v.Prefix {
+			r.GET(v.Path+"*filepath", handle)
+			r.HEAD(v.Path+"*filepath", handle)
+			continue
+		}
+		r.GET(v.Path, handle)
+	}
 }
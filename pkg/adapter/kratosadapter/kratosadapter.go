@@ -56,4 +56,13 @@ func Adapter(b *builder.Engine, s *http.Server) {
 			r.Handle(v.Method, path, RouteAdapter(b, v.Path))
 		}
 	}
+
+	// Prometheus指标、存活检查、就绪检查及私有文件接口
+	for _, v := range b.GetHandlerMappings() {
+		if v.Prefix {
+			s.HandlePrefix(v.Path, v.Handler)
+			continue
+		}
+		s.Handle(v.Path, v.Handler)
+	}
 }
@@ -2,6 +2,8 @@ package zeroadapter
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/zeromicro/go-zero/rest"
@@ -22,6 +24,21 @@ func RouteAdapter(b *builder.Engine, routePath string) http.HandlerFunc {
 	}
 }
 
+// 前缀路由匹配的最大层级，gozero不支持通配路由，按层级注册带参数的路由
+const prefixRouteDepth = 8
+
+// 获取前缀下各层级的路由，例如：/private/:p1、/private/:p1/:p2
+func prefixPaths(prefix string) []string {
+	paths := []string{}
+	path := strings.TrimSuffix(prefix, "/")
+	for i := 1; i <= prefixRouteDepth; i++ {
+		path = path + "/:p" + strconv.Itoa(i)
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
 // 适配gozero框架
 func Adapter(b *builder.Engine, server *rest.Server) {
 
@@ -63,5 +80,30 @@ func Adapter(b *builder.Engine, server *rest.Server) {
 		}
 	}
 
+	// Prometheus指标、存活检查、就绪检查及私有文件接口
+	for _, v := range b.GetHandlerMappings() {
+		if !v.Prefix {
+			routes = append(routes, rest.Route{
+				Method:  http.MethodGet,
+				Path:    v.Path,
+				Handler: v.Handler.ServeHTTP,
+			})
+			continue
+		}
+
+		for _, path := range prefixPaths(v.Path) {
+			routes = append(routes, rest.Route{
+				Method:  http.MethodGet,
+				Path:    path,
+				Handler: v.Handler.ServeHTTP,
+			})
+			routes = append(routes, rest.Route{
+				Method:  http.MethodHead,
+				Path:    path,
+				Handler: v.Handler.ServeHTTP,
+			})
+		}
+	}
+
 	server.AddRoutes(routes)
 }
@@ -19,6 +19,8 @@ type Component struct {
 	Collapsible      bool        `json:"collapsible"`
 	DefaultCollapsed bool        `json:"defaultCollapsed"`
 	Body             interface{} `json:"body"`
+	Api              string      `json:"api,omitempty"`
+	RefreshInterval  int         `json:"refreshInterval,omitempty"`
 }
 
 // 初始化组件
@@ -155,3 +157,17 @@ func (p *Component) JsonSerialize() *Component {
 
 	return p
 }
+
+// 获取内容的接口，设置后卡片通过接口加载内容
+func (p *Component) SetApi(api string) *Component {
+	p.Api = api
+
+	return p
+}
+
+// 通过接口自动刷新内容的间隔，单位秒，为0时不刷新
+func (p *Component) SetRefreshInterval(refreshInterval int) *Component {
+	p.RefreshInterval = refreshInterval
+
+	return p
+}
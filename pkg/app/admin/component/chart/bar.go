@@ -0,0 +1,167 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Bar struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	XField        string      `json:"xField"`
+	YField        string      `json:"yField"`
+	SeriesField   string      `json:"seriesField,omitempty"`
+	IsGroup       bool        `json:"isGroup,omitempty"`
+	IsStack       bool        `json:"isStack,omitempty"`
+	IsPercent     bool        `json:"isPercent,omitempty"`
+	BarWidthRatio float64     `json:"barWidthRatio,omitempty"`
+	Meta          interface{} `json:"meta"`
+	Label         interface{} `json:"label,omitempty"`
+	Legend        interface{} `json:"legend,omitempty"`
+	Color         interface{} `json:"color,omitempty"`
+}
+
+// 条形图
+func NewBar(data interface{}) *Bar {
+	return (&Bar{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Bar) Init() *Bar {
+	p.Component = "bar"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Bar) SetApi(api string) *Bar {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Bar) SetWidth(width int) *Bar {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Bar) SetHeight(height int) *Bar {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Bar) SetAutoFit(autoFit bool) *Bar {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Bar) SetPadding(padding interface{}) *Bar {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Bar) SetAppendPadding(appendPadding interface{}) *Bar {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Bar) SetRenderer(renderer string) *Bar {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Bar) SetLimitInPlot(limitInPlot bool) *Bar {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Bar) SetLocale(locale string) *Bar {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Bar) SetData(data interface{}) *Bar {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *Bar) SetXField(xField string) *Bar {
+	p.XField = xField
+	return p
+}
+
+// y轴字段
+func (p *Bar) SetYField(yField string) *Bar {
+	p.YField = yField
+	return p
+}
+
+// 分组字段，用于多系列数据，可以使用 ToSeries 将宽表数据转换为多系列数据
+func (p *Bar) SetSeriesField(seriesField string) *Bar {
+	p.SeriesField = seriesField
+	return p
+}
+
+// 是否分组条形图
+func (p *Bar) SetIsGroup(isGroup bool) *Bar {
+	p.IsGroup = isGroup
+	return p
+}
+
+// 是否堆积条形图
+func (p *Bar) SetIsStack(isStack bool) *Bar {
+	p.IsStack = isStack
+	return p
+}
+
+// 是否百分比条形图，需要与 isStack 一起使用
+func (p *Bar) SetIsPercent(isPercent bool) *Bar {
+	p.IsPercent = isPercent
+	return p
+}
+
+// 条形图宽度占比，值域为 [0,1]
+func (p *Bar) SetBarWidthRatio(barWidthRatio float64) *Bar {
+	p.BarWidthRatio = barWidthRatio
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Bar) SetMeta(meta interface{}) *Bar {
+	p.Meta = meta
+	return p
+}
+
+// 图形标签，设置为 false 时不显示
+func (p *Bar) SetLabel(label interface{}) *Bar {
+	p.Label = label
+	return p
+}
+
+// 图例，设置为 false 时不显示
+func (p *Bar) SetLegend(legend interface{}) *Bar {
+	p.Legend = legend
+	return p
+}
+
+// 图形颜色，可以为颜色值、颜色数组或回调
+func (p *Bar) SetColor(color interface{}) *Bar {
+	p.Color = color
+	return p
+}
@@ -0,0 +1,144 @@
+package chart
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/convert"
+)
+
+// 转换后数据的默认字段名
+const (
+	TypeField  = "type"  // 分组或分类字段
+	ValueField = "value" // 数值字段
+)
+
+// 转换为数字，数据库查询结果中的数值可能为[]byte或字符串，无法转换时返回0
+func ToNumber(value interface{}) float64 {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case float64:
+		return value
+	case int64:
+		return float64(value)
+	case int:
+		return float64(value)
+	}
+
+	number, _ := strconv.ParseFloat(convert.AnyToString(value), 64)
+
+	return number
+}
+
+// 转换为分类名称，[]byte转换为字符串
+func toLabel(value interface{}) interface{} {
+	if value, ok := value.([]byte); ok {
+		return string(value)
+	}
+
+	return value
+}
+
+// 宽表数据转换为多系列数据，用于折线图、柱状图、条形图、面积图的 seriesField
+//
+// 例如：[{"date":"01-01","pv":10,"uv":5}] 转换为 [{"date":"01-01","type":"pv","value":10},{"date":"01-01","type":"uv","value":5}]，
+// names 为数值字段对应的系列名称，未设置时使用字段名
+func ToSeries(rows []map[string]interface{}, xField string, valueFields []string, names map[string]string) []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, row := range rows {
+		for _, field := range valueFields {
+			name, ok := names[field]
+			if !ok {
+				name = field
+			}
+			data = append(data, map[string]interface{}{
+				xField:     toLabel(row[xField]),
+				TypeField:  name,
+				ValueField: ToNumber(row[field]),
+			})
+		}
+	}
+
+	return data
+}
+
+// 分类数据，用于饼图、漏斗图，转换为 [{"type":分类,"value":数值}] 并按数值倒序排列
+func ToCategory(rows []map[string]interface{}, typeField string, valueField string) []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, row := range rows {
+		data = append(data, map[string]interface{}{
+			TypeField:  toLabel(row[typeField]),
+			ValueField: ToNumber(row[valueField]),
+		})
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i][ValueField].(float64) > data[j][ValueField].(float64)
+	})
+
+	return data
+}
+
+// 双轴图数据，转换为左右两个轴的数据 [[{xField, leftField}], [{xField, rightField}]]
+func ToDualAxes(rows []map[string]interface{}, xField string, leftField string, rightField string) []interface{} {
+	left := []map[string]interface{}{}
+	right := []map[string]interface{}{}
+	for _, row := range rows {
+		x := toLabel(row[xField])
+		left = append(left, map[string]interface{}{xField: x, leftField: ToNumber(row[leftField])})
+		right = append(right, map[string]interface{}{xField: x, rightField: ToNumber(row[rightField])})
+	}
+
+	return []interface{}{left, right}
+}
+
+// 散点图数据，保留x、y轴字段及其他字段，x、y轴字段转换为数字
+func ToScatter(rows []map[string]interface{}, xField string, yField string, fields ...string) []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, row := range rows {
+		item := map[string]interface{}{
+			xField: ToNumber(row[xField]),
+			yField: ToNumber(row[yField]),
+		}
+		for _, field := range fields {
+			item[field] = toLabel(row[field])
+		}
+		data = append(data, item)
+	}
+
+	return data
+}
+
+// 热力图数据，转换为 [{xField, yField, "value":数值}]，缺少的坐标补0，坐标按出现的顺序排列
+func ToHeatmap(rows []map[string]interface{}, xField string, yField string, valueField string) []map[string]interface{} {
+	xs := []string{}
+	ys := []string{}
+	labels := map[string]interface{}{}
+	values := map[string]float64{}
+	for _, row := range rows {
+		x := convert.AnyToString(toLabel(row[xField]))
+		y := convert.AnyToString(toLabel(row[yField]))
+		if _, ok := labels["x:"+x]; !ok {
+			labels["x:"+x] = toLabel(row[xField])
+			xs = append(xs, x)
+		}
+		if _, ok := labels["y:"+y]; !ok {
+			labels["y:"+y] = toLabel(row[yField])
+			ys = append(ys, y)
+		}
+		values[x+"\n"+y] += ToNumber(row[valueField])
+	}
+
+	data := []map[string]interface{}{}
+	for _, x := range xs {
+		for _, y := range ys {
+			data = append(data, map[string]interface{}{
+				xField:     labels["x:"+x],
+				yField:     labels["y:"+y],
+				ValueField: values[x+"\n"+y],
+			})
+		}
+	}
+
+	return data
+}
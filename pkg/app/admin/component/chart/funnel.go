@@ -0,0 +1,167 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Funnel struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	XField        string      `json:"xField"`
+	YField        string      `json:"yField"`
+	SeriesField   string      `json:"seriesField,omitempty"`
+	CompareField  string      `json:"compareField,omitempty"`
+	IsTransposed  bool        `json:"isTransposed,omitempty"`
+	DynamicHeight bool        `json:"dynamicHeight,omitempty"`
+	ConversionTag interface{} `json:"conversionTag,omitempty"`
+	Meta          interface{} `json:"meta"`
+	Label         interface{} `json:"label,omitempty"`
+	Legend        interface{} `json:"legend,omitempty"`
+	Color         interface{} `json:"color,omitempty"`
+}
+
+// 漏斗图
+func NewFunnel(data interface{}) *Funnel {
+	return (&Funnel{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Funnel) Init() *Funnel {
+	p.Component = "funnel"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Funnel) SetApi(api string) *Funnel {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Funnel) SetWidth(width int) *Funnel {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Funnel) SetHeight(height int) *Funnel {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Funnel) SetAutoFit(autoFit bool) *Funnel {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Funnel) SetPadding(padding interface{}) *Funnel {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Funnel) SetAppendPadding(appendPadding interface{}) *Funnel {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Funnel) SetRenderer(renderer string) *Funnel {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Funnel) SetLimitInPlot(limitInPlot bool) *Funnel {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Funnel) SetLocale(locale string) *Funnel {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Funnel) SetData(data interface{}) *Funnel {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *Funnel) SetXField(xField string) *Funnel {
+	p.XField = xField
+	return p
+}
+
+// y轴字段
+func (p *Funnel) SetYField(yField string) *Funnel {
+	p.YField = yField
+	return p
+}
+
+// 分组字段，用于分组漏斗图
+func (p *Funnel) SetSeriesField(seriesField string) *Funnel {
+	p.SeriesField = seriesField
+	return p
+}
+
+// 对比字段，用于对比漏斗图
+func (p *Funnel) SetCompareField(compareField string) *Funnel {
+	p.CompareField = compareField
+	return p
+}
+
+// 是否转置
+func (p *Funnel) SetIsTransposed(isTransposed bool) *Funnel {
+	p.IsTransposed = isTransposed
+	return p
+}
+
+// 是否按数值动态计算每层的高度
+func (p *Funnel) SetDynamicHeight(dynamicHeight bool) *Funnel {
+	p.DynamicHeight = dynamicHeight
+	return p
+}
+
+// 转化率标签，设置为 false 时不显示
+func (p *Funnel) SetConversionTag(conversionTag interface{}) *Funnel {
+	p.ConversionTag = conversionTag
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Funnel) SetMeta(meta interface{}) *Funnel {
+	p.Meta = meta
+	return p
+}
+
+// 图形标签，设置为 false 时不显示
+func (p *Funnel) SetLabel(label interface{}) *Funnel {
+	p.Label = label
+	return p
+}
+
+// 图例，设置为 false 时不显示
+func (p *Funnel) SetLegend(legend interface{}) *Funnel {
+	p.Legend = legend
+	return p
+}
+
+// 图形颜色，可以为颜色值、颜色数组或回调
+func (p *Funnel) SetColor(color interface{}) *Funnel {
+	p.Color = color
+	return p
+}
@@ -0,0 +1,154 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Gauge struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Percent       float64     `json:"percent"`
+	Radius        float64     `json:"radius,omitempty"`
+	InnerRadius   float64     `json:"innerRadius,omitempty"`
+	StartAngle    float64     `json:"startAngle,omitempty"`
+	EndAngle      float64     `json:"endAngle,omitempty"`
+	Range         interface{} `json:"range,omitempty"`
+	Indicator     interface{} `json:"indicator,omitempty"`
+	Statistic     interface{} `json:"statistic,omitempty"`
+	Type          string      `json:"type,omitempty"`
+}
+
+// 仪表盘
+func NewGauge(percent float64) *Gauge {
+	return (&Gauge{}).Init().SetPercent(percent)
+}
+
+// 初始化
+func (p *Gauge) Init() *Gauge {
+	p.Component = "gauge"
+	p.Radius = 0.75
+	p.InnerRadius = 0.9
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Gauge) SetApi(api string) *Gauge {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Gauge) SetWidth(width int) *Gauge {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Gauge) SetHeight(height int) *Gauge {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Gauge) SetAutoFit(autoFit bool) *Gauge {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Gauge) SetPadding(padding interface{}) *Gauge {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Gauge) SetAppendPadding(appendPadding interface{}) *Gauge {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Gauge) SetRenderer(renderer string) *Gauge {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Gauge) SetLimitInPlot(limitInPlot bool) *Gauge {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Gauge) SetLocale(locale string) *Gauge {
+	p.Locale = locale
+	return p
+}
+
+// 指标比例数据，值域为 [0,1]，超出时按边界值显示
+func (p *Gauge) SetPercent(percent float64) *Gauge {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 1 {
+		percent = 1
+	}
+	p.Percent = percent
+	return p
+}
+
+// 外环的半径，值域为 (0,1]
+func (p *Gauge) SetRadius(radius float64) *Gauge {
+	p.Radius = radius
+	return p
+}
+
+// 内环的半径，值域为 (0,1]
+func (p *Gauge) SetInnerRadius(innerRadius float64) *Gauge {
+	p.InnerRadius = innerRadius
+	return p
+}
+
+// 圆盘的起始角度
+func (p *Gauge) SetStartAngle(startAngle float64) *Gauge {
+	p.StartAngle = startAngle
+	return p
+}
+
+// 圆盘的终止角度
+func (p *Gauge) SetEndAngle(endAngle float64) *Gauge {
+	p.EndAngle = endAngle
+	return p
+}
+
+// 辅助圆弧的样式，例如：{"ticks":[0, 0.6, 1],"color":["#30BF78","#F4664A"]}
+func (p *Gauge) SetRange(rangeOption interface{}) *Gauge {
+	p.Range = rangeOption
+	return p
+}
+
+// 指针的样式，设置为 false 时不显示
+func (p *Gauge) SetIndicator(indicator interface{}) *Gauge {
+	p.Indicator = indicator
+	return p
+}
+
+// 中心文本的配置
+func (p *Gauge) SetStatistic(statistic interface{}) *Gauge {
+	p.Statistic = statistic
+	return p
+}
+
+// 仪表盘的展示类型，可选 meter
+func (p *Gauge) SetType(chartType string) *Gauge {
+	p.Type = chartType
+	return p
+}
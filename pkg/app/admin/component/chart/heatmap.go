@@ -0,0 +1,160 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Heatmap struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	XField        string      `json:"xField"`
+	YField        string      `json:"yField"`
+	ColorField    string      `json:"colorField,omitempty"`
+	SizeField     string      `json:"sizeField,omitempty"`
+	Shape         string      `json:"shape,omitempty"`
+	Reflect       string      `json:"reflect,omitempty"`
+	Meta          interface{} `json:"meta"`
+	Label         interface{} `json:"label,omitempty"`
+	Legend        interface{} `json:"legend,omitempty"`
+	Color         interface{} `json:"color,omitempty"`
+}
+
+// 热力图
+func NewHeatmap(data interface{}) *Heatmap {
+	return (&Heatmap{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Heatmap) Init() *Heatmap {
+	p.Component = "heatmap"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Heatmap) SetApi(api string) *Heatmap {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Heatmap) SetWidth(width int) *Heatmap {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Heatmap) SetHeight(height int) *Heatmap {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Heatmap) SetAutoFit(autoFit bool) *Heatmap {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Heatmap) SetPadding(padding interface{}) *Heatmap {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Heatmap) SetAppendPadding(appendPadding interface{}) *Heatmap {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Heatmap) SetRenderer(renderer string) *Heatmap {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Heatmap) SetLimitInPlot(limitInPlot bool) *Heatmap {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Heatmap) SetLocale(locale string) *Heatmap {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Heatmap) SetData(data interface{}) *Heatmap {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *Heatmap) SetXField(xField string) *Heatmap {
+	p.XField = xField
+	return p
+}
+
+// y轴字段
+func (p *Heatmap) SetYField(yField string) *Heatmap {
+	p.YField = yField
+	return p
+}
+
+// 颜色映射对应的数据字段名
+func (p *Heatmap) SetColorField(colorField string) *Heatmap {
+	p.ColorField = colorField
+	return p
+}
+
+// 大小映射对应的数据字段名
+func (p *Heatmap) SetSizeField(sizeField string) *Heatmap {
+	p.SizeField = sizeField
+	return p
+}
+
+// 图形形状，例如：square、circle
+func (p *Heatmap) SetShape(shape string) *Heatmap {
+	p.Shape = shape
+	return p
+}
+
+// 坐标轴映射，可选 x、y
+func (p *Heatmap) SetReflect(reflect string) *Heatmap {
+	p.Reflect = reflect
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Heatmap) SetMeta(meta interface{}) *Heatmap {
+	p.Meta = meta
+	return p
+}
+
+// 图形标签，设置为 false 时不显示
+func (p *Heatmap) SetLabel(label interface{}) *Heatmap {
+	p.Label = label
+	return p
+}
+
+// 图例，设置为 false 时不显示
+func (p *Heatmap) SetLegend(legend interface{}) *Heatmap {
+	p.Legend = legend
+	return p
+}
+
+// 图形颜色，可以为颜色值、颜色数组或回调
+func (p *Heatmap) SetColor(color interface{}) *Heatmap {
+	p.Color = color
+	return p
+}
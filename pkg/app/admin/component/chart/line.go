@@ -16,6 +16,7 @@ type Line struct {
 	Data          interface{} `json:"data"`
 	XField        string      `json:"xField"`
 	YField        string      `json:"yField"`
+	SeriesField   string      `json:"seriesField,omitempty"`
 	Meta          interface{} `json:"meta"`
 	Smooth        bool        `json:"smooth"`
 }
@@ -105,6 +106,12 @@ func (p *Line) SetYField(yField string) *Line {
 	return p
 }
 
+// 分组字段，用于多系列数据，可以使用 ToSeries 将宽表数据转换为多系列数据
+func (p *Line) SetSeriesField(seriesField string) *Line {
+	p.SeriesField = seriesField
+	return p
+}
+
 // 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
 func (p *Line) SetMeta(meta interface{}) *Line {
 	p.Meta = meta
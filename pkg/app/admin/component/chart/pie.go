@@ -0,0 +1,140 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Pie struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	AngleField    string      `json:"angleField"`
+	ColorField    string      `json:"colorField"`
+	Meta          interface{} `json:"meta"`
+	Radius        float64     `json:"radius"`
+	InnerRadius   float64     `json:"innerRadius,omitempty"`
+	Label         interface{} `json:"label,omitempty"`
+	Legend        interface{} `json:"legend,omitempty"`
+}
+
+// 饼图
+func NewPie(data interface{}) *Pie {
+	return (&Pie{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Pie) Init() *Pie {
+	p.Component = "pie"
+	p.Radius = 1
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Pie) SetApi(api string) *Pie {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Pie) SetWidth(width int) *Pie {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Pie) SetHeight(height int) *Pie {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Pie) SetAutoFit(autoFit bool) *Pie {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Pie) SetPadding(padding interface{}) *Pie {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Pie) SetAppendPadding(appendPadding interface{}) *Pie {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Pie) SetRenderer(renderer string) *Pie {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Pie) SetLimitInPlot(limitInPlot bool) *Pie {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Pie) SetLocale(locale string) *Pie {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Pie) SetData(data interface{}) *Pie {
+	p.Data = data
+	return p
+}
+
+// 扇形切片大小（弧度）所对应的数据字段名
+func (p *Pie) SetAngleField(angleField string) *Pie {
+	p.AngleField = angleField
+	return p
+}
+
+// 扇形颜色映射对应的数据字段名
+func (p *Pie) SetColorField(colorField string) *Pie {
+	p.ColorField = colorField
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Pie) SetMeta(meta interface{}) *Pie {
+	p.Meta = meta
+	return p
+}
+
+// 饼图的半径，原点为画布中心。配置值域为 (0,1]，1 代表饼图撑满绘图区域。
+func (p *Pie) SetRadius(radius float64) *Pie {
+	p.Radius = radius
+	return p
+}
+
+// 饼图的内半径，原点为画布中心。配置值域为 (0,1]，设置后为环图。
+func (p *Pie) SetInnerRadius(innerRadius float64) *Pie {
+	p.InnerRadius = innerRadius
+	return p
+}
+
+// 图形标签，设置为 false 时不显示
+func (p *Pie) SetLabel(label interface{}) *Pie {
+	p.Label = label
+	return p
+}
+
+// 图例，设置为 false 时不显示
+func (p *Pie) SetLegend(legend interface{}) *Pie {
+	p.Legend = legend
+	return p
+}
@@ -0,0 +1,106 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Progress struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	Locale        string      `json:"locale"`
+	Percent       float64     `json:"percent"`
+	BarWidthRatio float64     `json:"barWidthRatio"`
+	Color         interface{} `json:"color,omitempty"`
+}
+
+// 进度条图表
+func NewProgress(percent float64) *Progress {
+	return (&Progress{}).Init().SetPercent(percent)
+}
+
+// 初始化
+func (p *Progress) Init() *Progress {
+	p.Component = "progress"
+	p.Height = 100
+	p.AutoFit = true
+	p.BarWidthRatio = 0.3
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Progress) SetApi(api string) *Progress {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Progress) SetWidth(width int) *Progress {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Progress) SetHeight(height int) *Progress {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Progress) SetAutoFit(autoFit bool) *Progress {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Progress) SetPadding(padding interface{}) *Progress {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Progress) SetAppendPadding(appendPadding interface{}) *Progress {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Progress) SetRenderer(renderer string) *Progress {
+	p.Renderer = renderer
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Progress) SetLocale(locale string) *Progress {
+	p.Locale = locale
+	return p
+}
+
+// 进度百分比，值域为 [0,1]，超出时按边界值显示
+func (p *Progress) SetPercent(percent float64) *Progress {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 1 {
+		percent = 1
+	}
+	p.Percent = percent
+	return p
+}
+
+// 进度条的宽度占比，值域为 [0,1]
+func (p *Progress) SetBarWidthRatio(barWidthRatio float64) *Progress {
+	p.BarWidthRatio = barWidthRatio
+	return p
+}
+
+// 进度条颜色，可以为数组 [进度颜色, 背景颜色]
+func (p *Progress) SetColor(color interface{}) *Progress {
+	p.Color = color
+	return p
+}
@@ -0,0 +1,160 @@
+package chart
+
+import "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/component"
+
+type Scatter struct {
+	component.Element
+	Api           string      `json:"api"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	AutoFit       bool        `json:"autoFit"`
+	Padding       interface{} `json:"padding"`
+	AppendPadding interface{} `json:"appendPadding"`
+	Renderer      string      `json:"renderer"`
+	LimitInPlot   bool        `json:"limitInPlot"`
+	Locale        string      `json:"locale"`
+	Data          interface{} `json:"data"`
+	XField        string      `json:"xField"`
+	YField        string      `json:"yField"`
+	ColorField    string      `json:"colorField,omitempty"`
+	SizeField     string      `json:"sizeField,omitempty"`
+	Size          interface{} `json:"size,omitempty"`
+	Shape         interface{} `json:"shape,omitempty"`
+	Meta          interface{} `json:"meta"`
+	Label         interface{} `json:"label,omitempty"`
+	Legend        interface{} `json:"legend,omitempty"`
+	Color         interface{} `json:"color,omitempty"`
+}
+
+// 散点图
+func NewScatter(data interface{}) *Scatter {
+	return (&Scatter{}).Init().SetData(data)
+}
+
+// 初始化
+func (p *Scatter) Init() *Scatter {
+	p.Component = "scatter"
+	p.SetKey(component.DEFAULT_KEY, component.DEFAULT_CRYPT)
+
+	return p
+}
+
+// 数据接口
+func (p *Scatter) SetApi(api string) *Scatter {
+	p.Api = api
+	return p
+}
+
+// 设置图表宽度
+func (p *Scatter) SetWidth(width int) *Scatter {
+	p.Width = width
+	return p
+}
+
+// 设置图表高度
+func (p *Scatter) SetHeight(height int) *Scatter {
+	p.Height = height
+	return p
+}
+
+// 图表是否自适应容器宽高。当 autoFit 设置为 true 时，width 和 height 的设置将失效。
+func (p *Scatter) SetAutoFit(autoFit bool) *Scatter {
+	p.AutoFit = autoFit
+	return p
+}
+
+// 画布的 padding 值，代表图表在上右下左的间距，可以为单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向，或者开启 auto，由底层自动计算间距。
+func (p *Scatter) SetPadding(padding interface{}) *Scatter {
+	p.Padding = padding
+	return p
+}
+
+// 额外增加的 appendPadding 值，在 padding 的基础上，设置额外的 padding 数值，可以是单个数字 16，或者数组 [16, 8, 16, 8] 代表四个方向。
+func (p *Scatter) SetAppendPadding(appendPadding interface{}) *Scatter {
+	p.AppendPadding = appendPadding
+	return p
+}
+
+// 设置图表渲染方式为 canvas 或 svg。
+func (p *Scatter) SetRenderer(renderer string) *Scatter {
+	p.Renderer = renderer
+	return p
+}
+
+// 是否对超出坐标系范围的 Geometry 进行剪切。
+func (p *Scatter) SetLimitInPlot(limitInPlot bool) *Scatter {
+	p.LimitInPlot = limitInPlot
+	return p
+}
+
+// 指定具体语言，目前内置 'zh-CN' and 'en-US' 两个语言，你也可以使用 G2Plot.registerLocale 方法注册新的语言。语言包格式参考：src/locales/en_US.ts
+func (p *Scatter) SetLocale(locale string) *Scatter {
+	p.Locale = locale
+	return p
+}
+
+// 数据
+func (p *Scatter) SetData(data interface{}) *Scatter {
+	p.Data = data
+	return p
+}
+
+// X轴字段
+func (p *Scatter) SetXField(xField string) *Scatter {
+	p.XField = xField
+	return p
+}
+
+// y轴字段
+func (p *Scatter) SetYField(yField string) *Scatter {
+	p.YField = yField
+	return p
+}
+
+// 点颜色映射对应的数据字段名
+func (p *Scatter) SetColorField(colorField string) *Scatter {
+	p.ColorField = colorField
+	return p
+}
+
+// 点大小映射对应的数据字段名
+func (p *Scatter) SetSizeField(sizeField string) *Scatter {
+	p.SizeField = sizeField
+	return p
+}
+
+// 点的大小，可以为数字或 [最小值, 最大值]
+func (p *Scatter) SetSize(size interface{}) *Scatter {
+	p.Size = size
+	return p
+}
+
+// 点的形状，例如：circle、square
+func (p *Scatter) SetShape(shape interface{}) *Scatter {
+	p.Shape = shape
+	return p
+}
+
+// 通过 meta 可以全局化配置图表数据元信息，以字段为单位进行配置。在 meta 上的配置将同时影响所有组件的文本信息。传入以字段名为 key，MetaOption 为 value 的配置，同时设置多个字段的元信息。
+func (p *Scatter) SetMeta(meta interface{}) *Scatter {
+	p.Meta = meta
+	return p
+}
+
+// 图形标签，设置为 false 时不显示
+func (p *Scatter) SetLabel(label interface{}) *Scatter {
+	p.Label = label
+	return p
+}
+
+// 图例，设置为 false 时不显示
+func (p *Scatter) SetLegend(legend interface{}) *Scatter {
+	p.Legend = legend
+	return p
+}
+
+// 图形颜色，可以为颜色值、颜色数组或回调
+func (p *Scatter) SetColor(color interface{}) *Scatter {
+	p.Color = color
+	return p
+}
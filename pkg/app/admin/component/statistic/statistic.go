@@ -10,7 +10,7 @@ type Component struct {
 	Prefix           string            `json:"prefix"`
 	Suffix           string            `json:"suffix"`
 	Title            string            `json:"title"`
-	Value            int64             `json:"value"`
+	Value            interface{}       `json:"value"`
 	ValueStyle       map[string]string `json:"valueStyle"`
 }
 
@@ -73,8 +73,8 @@ func (p *Component) SetTitle(title string) *Component {
 	return p
 }
 
-// 数值内容
-func (p *Component) SetValue(value int64) *Component {
+// 数值内容，支持整数、浮点数及字符串
+func (p *Component) SetValue(value interface{}) *Component {
 	p.Value = value
 	return p
 }
@@ -0,0 +1,86 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migrate"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 存储就绪检查写入探测文件的目录，与上传模板默认的保存目录一致
+const storageProbePath = "./web/app/storage/"
+
+// 注册管理后台的就绪检查
+func registerHealthChecks() {
+	builder.RegisterHealthCheck("storage", checkStorage)
+	builder.RegisterHealthCheck("migration", checkMigration)
+}
+
+// 检查默认存储驱动是否可以读写：通过驱动写入探测文件，读取文件信息后删除
+func checkStorage(ctx context.Context) error {
+	config := builder.GetConfig()
+	if config == nil {
+		return nil
+	}
+
+	driverName := storage.LocalDriver
+	storageConfig := &storage.Config{}
+	if config.Storage != nil {
+		storageConfig = config.Storage
+		if config.Storage.Driver != "" {
+			driverName = config.Storage.Driver
+		}
+	}
+
+	driver, err := storage.GetDriver(driverName, storageConfig)
+	if err != nil {
+		return errors.New(driverName + ": " + err.Error())
+	}
+
+	// 每次检查使用不同的探测文件，避免并发检查互相删除
+	key := storageProbePath + ".readyz-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	content := "ok"
+	err = driver.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain")
+	if err != nil {
+		return errors.New(driverName + ": " + err.Error())
+	}
+
+	info, err := driver.Stat(ctx, key)
+	if err == nil && info.Size != int64(len(content)) {
+		err = errors.New("probe object size mismatch")
+	}
+
+	// 读取失败时同样删除探测文件
+	deleteErr := driver.Delete(ctx, key)
+	if err == nil {
+		err = deleteErr
+	}
+	if err != nil {
+		return errors.New(driverName + ": " + err.Error())
+	}
+
+	return nil
+}
+
+// 检查数据库迁移是否完成：所有已注册的迁移均已执行
+func checkMigration(ctx context.Context) error {
+	if db.Client == nil {
+		return errors.New("database is not initialized")
+	}
+
+	pending, err := migrate.Pending(db.Client.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.New("pending migrations: " + strings.Join(pending, ", "))
+	}
+
+	return nil
+}
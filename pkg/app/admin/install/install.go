@@ -1,50 +1,50 @@
 package install
 
 import (
-	"os"
-
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	v20261019000001 "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install/v20261019000001"
+	v20261019000002 "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install/v20261019000002"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migrate"
 	"gorm.io/gorm"
 )
 
-// 执行安装操作
-func Handle() {
+// 管理后台的数据库迁移，新增迁移时追加到末尾，已发布的迁移不能修改
+//
+// 迁移使用发布时的数据表结构及填充数据（例如：v20261019000001、v20261019000002包），不能直接使用数据模型，否则迁移的结果会随数据模型变化；
+// 数据模型增加字段时需要新增迁移，例如：tx.Migrator().AddColumn(&v20261101000001.Role{}, "Remark")
+var Migrations = []*migrate.Migration{
+	{
+		Version:     "20261019000001",
+		Description: "create admin tables",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(v20261019000001.Models()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(v20261019000001.Models()...)
+		},
+	},
+	{
+		Version:     "20261019000002",
+		Description: "seed admin data",
+		Up: func(tx *gorm.DB) error {
+			return v20261019000002.Seed(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	},
+}
 
-	// 如果锁定文件存在则不执行安装步骤
-	if file.IsExist("install.lock") {
-		return
-	}
+// 执行安装操作，执行未完成的数据库迁移，可以重复执行
+func Handle() {
 
-	// 迁移数据
-	db.Client.AutoMigrate(
-		&model.ActionLog{},
-		&model.Admin{},
-		&model.Config{},
-		&model.Menu{},
-		&model.File{},
-		&model.FileCategory{},
-		&model.Picture{},
-		&model.PictureCategory{},
-		&model.Permission{},
-		&model.Role{},
-		&model.CasbinRule{},
-	)
+	// 注册存储及数据库迁移的就绪检查
+	registerHealthChecks()
 
-	// 如果超级管理员不存在，初始化数据库数据
-	adminInfo, err := (&model.Admin{}).GetInfoById(1)
-	if err != nil && err != gorm.ErrRecordNotFound {
+	// 执行迁移
+	migrate.Register(Migrations...)
+	err := migrate.Up(db.Client)
+	if err != nil {
 		panic(err)
 	}
-	if adminInfo.Id == 0 {
-		// 数据填充
-		(&model.Admin{}).Seeder()
-		(&model.Config{}).Seeder()
-		(&model.Menu{}).Seeder()
-	}
-
-	// 创建锁定文件
-	file, _ := os.Create("install.lock")
-	file.Close()
 }
@@ -0,0 +1,205 @@
+// 迁移20261019000001创建的数据表结构，迁移发布后不能修改
+//
+// 结构体名称与数据模型一致，以使用相同的表名；之后的结构变更在新的迁移中完成，不能修改本包或使用数据模型代替
+package v20261019000001
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+	"gorm.io/gorm"
+)
+
+type ActionLog struct {
+	Id        int    `gorm:"autoIncrement"`
+	ObjectId  int    `gorm:"size:11;not null"`
+	Username  string `gorm:"<-:false"`
+	Url       string `gorm:"size:500;not null"`
+	Remark    string `gorm:"size:255;not null"`
+	Ip        string `gorm:"size:100;not null"`
+	Type      string `gorm:"size:100;not null"`
+	Status    int    `gorm:"size:1;not null;default:1"`
+	CreatedAt datetime.Datetime
+	UpdatedAt datetime.Datetime
+}
+
+type Admin struct {
+	Id            int    `gorm:"autoIncrement"`
+	Username      string `gorm:"size:20;index:admins_username_unique,unique;not null"`
+	Nickname      string `gorm:"size:200;not null"`
+	Sex           int    `gorm:"size:4;not null;default:1"`
+	Email         string `gorm:"size:50;index:admins_email_unique,unique;not null"`
+	Phone         string `gorm:"size:11;index:admins_phone_unique,unique;not null"`
+	Password      string `gorm:"size:255;not null"`
+	Avatar        string `gorm:"size:1000"`
+	LastLoginIp   string `gorm:"size:255"`
+	LastLoginTime datetime.Datetime
+	Status        int    `gorm:"size:1;not null;default:1"`
+	Locale        string `gorm:"size:20"`
+	CreatedAt     datetime.Datetime
+	UpdatedAt     datetime.Datetime
+	DeletedAt     gorm.DeletedAt
+}
+
+type Config struct {
+	Id        int    `gorm:"autoIncrement"`
+	Title     string `gorm:"size:255;not null"`
+	Type      string `gorm:"size:20;not null"`
+	Name      string `gorm:"size:255;not null"`
+	Sort      int    `gorm:"size:11;default:0"`
+	GroupName string `gorm:"size:255;not null"`
+	Value     string `gorm:"size:2000"`
+	Remark    string `gorm:"size:100;not null"`
+	Status    int    `gorm:"size:1;not null;default:1"`
+	CreatedAt datetime.Datetime
+	UpdatedAt datetime.Datetime
+}
+
+type Menu struct {
+	Id         int    `gorm:"autoIncrement"`
+	Name       string `gorm:"size:100;not null"`
+	GuardName  string `gorm:"size:100;not null"`
+	Icon       string `gorm:"size:100;"`
+	Type       int    `gorm:"size:100;not null"`
+	Pid        int    `gorm:"size:11;default:0"`
+	Sort       int    `gorm:"size:11;default:0"`
+	Path       string `gorm:"size:255"`
+	Show       int    `gorm:"size:1;not null;default:1"`
+	IsEngine   int    `gorm:"size:1;not null;default:0"`
+	IsLink     int    `gorm:"size:1;not null;default:0"`
+	Status     int    `gorm:"size:1;not null;default:1"`
+	Key        string `gorm:"<-:false"`
+	Locale     string `gorm:"<-:false"`
+	HideInMenu bool   `gorm:"<-:false"`
+	CreatedAt  datetime.Datetime
+	UpdatedAt  datetime.Datetime
+}
+
+type File struct {
+	Id             int    `gorm:"autoIncrement"`
+	ObjType        string `gorm:"size:255"`
+	ObjId          int    `gorm:"size:11;default:0"`
+	FileCategoryId int    `gorm:"size:11;default:0"`
+	Sort           int    `gorm:"size:11;default:0"`
+	Name           string `gorm:"size:255;not null"`
+	Size           int64  `gorm:"size:20;default:0"`
+	Ext            string `gorm:"size:255"`
+	Path           string `gorm:"size:255;not null"`
+	Url            string `gorm:"size:255;not null"`
+	Hash           string `gorm:"size:255;not null"`
+	Visibility     string `gorm:"size:20;not null;default:public"`
+	ScanStatus     string `gorm:"size:20"`
+	ScanResult     string `gorm:"size:255"`
+	Status         int    `gorm:"size:1;not null;default:1"`
+	CreatedAt      datetime.Datetime
+	UpdatedAt      datetime.Datetime
+}
+
+type FileCategory struct {
+	Id          int    `gorm:"autoIncrement"`
+	Pid         int    `gorm:"size:11;default:0"`
+	ObjType     string `gorm:"size:100"`
+	ObjId       int    `gorm:"size:11;default:0"`
+	Title       string `gorm:"size:255;not null"`
+	Sort        int    `gorm:"size:11;default:0"`
+	Description string `gorm:"size:255"`
+}
+
+type Picture struct {
+	Id                int    `gorm:"autoIncrement"`
+	ObjType           string `gorm:"size:255"`
+	ObjId             int    `gorm:"size:11;default:0"`
+	PictureCategoryId int    `gorm:"size:11;default:0"`
+	Sort              int    `gorm:"size:11;default:0"`
+	Name              string `gorm:"size:255;not null"`
+	Size              int64  `gorm:"size:20;default:0"`
+	Width             int    `gorm:"size:11;default:0"`
+	Height            int    `gorm:"size:11;default:0"`
+	Ext               string `gorm:"size:255"`
+	Path              string `gorm:"size:255;not null"`
+	Url               string `gorm:"size:255;not null"`
+	Hash              string `gorm:"size:255;not null"`
+	Status            int    `gorm:"size:1;not null;default:1"`
+	CreatedAt         datetime.Datetime
+	UpdatedAt         datetime.Datetime
+}
+
+type PictureCategory struct {
+	Id          int    `gorm:"autoIncrement"`
+	Pid         int    `gorm:"size:11;default:0"`
+	ObjType     string `gorm:"size:100"`
+	ObjId       int    `gorm:"size:11;default:0"`
+	Title       string `gorm:"size:255;not null"`
+	Sort        int    `gorm:"size:11;default:0"`
+	Description string `gorm:"size:255"`
+}
+
+type FileReference struct {
+	Id        int    `gorm:"autoIncrement"`
+	FileType  string `gorm:"size:50;not null;index:idx_file_references_file"`
+	FileId    int    `gorm:"size:11;not null;index:idx_file_references_file"`
+	Path      string `gorm:"size:255;not null;index"`
+	RefType   string `gorm:"size:255;not null;index:idx_file_references_ref"`
+	RefId     int    `gorm:"size:11;not null;index:idx_file_references_ref"`
+	Field     string `gorm:"size:255;not null"`
+	CreatedAt datetime.Datetime
+	UpdatedAt datetime.Datetime
+}
+
+type Permission struct {
+	Id        int    `gorm:"autoIncrement"`
+	Name      string `gorm:"size:500;not null"`
+	GuardName string `gorm:"size:100;not null"`
+	Path      string `gorm:"size:500;not null"`
+	Method    string `gorm:"size:500;not null"`
+	Remark    string `gorm:"size:100"`
+	CreatedAt datetime.Datetime
+	UpdatedAt datetime.Datetime
+}
+
+type Role struct {
+	Id           int    `gorm:"autoIncrement"`
+	Name         string `gorm:"size:255;not null"`
+	GuardName    string `gorm:"size:100;not null"`
+	StorageQuota int64  `gorm:"size:20;default:0"`
+	CreatedAt    datetime.Datetime
+	UpdatedAt    datetime.Datetime
+}
+
+type CasbinRule struct {
+	ID    uint   `gorm:"primaryKey;autoIncrement"`
+	Ptype string `gorm:"size:100;uniqueIndex:unique_index"`
+	V0    string `gorm:"size:100;uniqueIndex:unique_index"`
+	V1    string `gorm:"size:100;uniqueIndex:unique_index"`
+	V2    string `gorm:"size:100;uniqueIndex:unique_index"`
+	V3    string `gorm:"size:100;uniqueIndex:unique_index"`
+	V4    string `gorm:"size:100;uniqueIndex:unique_index"`
+	V5    string `gorm:"size:100;uniqueIndex:unique_index"`
+}
+
+type DashboardLayout struct {
+	Id        int    `gorm:"autoIncrement"`
+	Dashboard string `gorm:"size:100;not null;index:idx_dashboard_layouts_obj"`
+	ObjType   string `gorm:"size:100;not null;index:idx_dashboard_layouts_obj"`
+	ObjId     int    `gorm:"size:11;not null;index:idx_dashboard_layouts_obj"`
+	Cards     string `gorm:"type:text"`
+	CreatedAt datetime.Datetime
+	UpdatedAt datetime.Datetime
+}
+
+// 迁移创建的全部数据表
+func Models() []interface{} {
+	return []interface{}{
+		&ActionLog{},
+		&Admin{},
+		&Config{},
+		&Menu{},
+		&File{},
+		&FileCategory{},
+		&Picture{},
+		&PictureCategory{},
+		&FileReference{},
+		&Permission{},
+		&Role{},
+		&CasbinRule{},
+		&DashboardLayout{},
+	}
+}
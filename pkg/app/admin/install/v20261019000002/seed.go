@@ -0,0 +1,138 @@
+// 迁移20261019000002填充的初始数据，迁移发布后不能修改
+//
+// 使用迁移20261019000001的数据表结构，不能使用数据模型代替，否则填充的结果会随数据模型变化
+package v20261019000002
+
+import (
+	v20261019000001 "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install/v20261019000001"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
+)
+
+// 填充管理员、配置及菜单，可以重复执行
+func Seed(tx *gorm.DB) error {
+	err := seedAdmins(tx)
+	if err != nil {
+		return err
+	}
+	err = seedConfigs(tx)
+	if err != nil {
+		return err
+	}
+
+	return SeedMenus(tx, adminMenus)
+}
+
+// 填充默认管理员，已存在管理员（包括已删除的）时跳过
+func seedAdmins(tx *gorm.DB) error {
+	var count int64
+	err := tx.Unscoped().Model(&v20261019000001.Admin{}).Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+
+	seeders := []v20261019000001.Admin{
+		{Username: "administrator", Nickname: "超级管理员", Email: "admin@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: datetime.Now()},
+	}
+
+	return tx.Create(&seeders).Error
+}
+
+// 填充配置，名称已存在的配置跳过
+func seedConfigs(tx *gorm.DB) error {
+	seeders := []v20261019000001.Config{
+		{Title: "网站名称", Type: "text", Name: "WEB_SITE_NAME", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
+		{Title: "关键字", Type: "text", Name: "WEB_SITE_KEYWORDS", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
+		{Title: "描述", Type: "textarea", Name: "WEB_SITE_DESCRIPTION", Sort: 0, GroupName: "基本", Value: "QuarkCloud", Remark: "", Status: 1},
+		{Title: "Logo", Type: "picture", Name: "WEB_SITE_LOGO", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
+		{Title: "统计代码", Type: "textarea", Name: "WEB_SITE_SCRIPT", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
+		{Title: "网站域名", Type: "text", Name: "WEB_SITE_DOMAIN", Sort: 0, GroupName: "基本", Value: "", Remark: "", Status: 1},
+		{Title: "网站版权", Type: "text", Name: "WEB_SITE_COPYRIGHT", Sort: 0, GroupName: "基本", Value: "© Company 2018", Remark: "", Status: 1},
+		{Title: "开启SSL", Type: "switch", Name: "SSL_OPEN", Sort: 0, GroupName: "基本", Value: "0", Remark: "", Status: 1},
+		{Title: "开启网站", Type: "switch", Name: "WEB_SITE_OPEN", Sort: 0, GroupName: "基本", Value: "1", Remark: "", Status: 1},
+		{Title: "KeyID", Type: "text", Name: "OSS_ACCESS_KEY_ID", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeyID", Status: 1},
+		{Title: "KeySecret", Type: "text", Name: "OSS_ACCESS_KEY_SECRET", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "你的AccessKeySecret", Status: 1},
+		{Title: "EndPoint", Type: "text", Name: "OSS_ENDPOINT", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "地域节点", Status: 1},
+		{Title: "Bucket域名", Type: "text", Name: "OSS_BUCKET", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "", Status: 1},
+		{Title: "自定义域名", Type: "text", Name: "OSS_MYDOMAIN", Sort: 0, GroupName: "阿里云存储", Value: "", Remark: "例如：oss.web.com", Status: 1},
+		{Title: "开启云存储", Type: "switch", Name: "OSS_OPEN", Sort: 0, GroupName: "阿里云存储", Value: "0", Remark: "", Status: 1},
+		{Title: "总存储配额", Type: "text", Name: "STORAGE_QUOTA_TOTAL", Sort: 0, GroupName: "存储配额", Value: "0", Remark: "单位MB，0为不限制", Status: 1},
+		{Title: "管理员存储配额", Type: "text", Name: "STORAGE_QUOTA_ADMINID", Sort: 0, GroupName: "存储配额", Value: "0", Remark: "每个管理员可用的空间，单位MB，0为不限制，角色设置了配额时优先使用角色配额", Status: 1},
+		{Title: "用户存储配额", Type: "text", Name: "STORAGE_QUOTA_UID", Sort: 0, GroupName: "存储配额", Value: "0", Remark: "每个用户可用的空间，单位MB，0为不限制", Status: 1},
+	}
+
+	for _, seeder := range seeders {
+		err := tx.Where(v20261019000001.Config{Name: seeder.Name}).FirstOrCreate(&seeder).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 后台菜单，Id 18、19在早期版本中由MiniApp的用户菜单使用，后台菜单不再使用
+var adminMenus = []v20261019000001.Menu{
+	{Id: 1, Name: "控制台", GuardName: "admin", Icon: "icon-home", Type: 1, Pid: 0, Sort: 0, Path: "/dashboard", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 2, Name: "主页", GuardName: "admin", Icon: "", Type: 2, Pid: 1, Sort: 0, Path: "/api/admin/dashboard/index/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 3, Name: "管理员", GuardName: "admin", Icon: "icon-admin", Type: 1, Pid: 0, Sort: 100, Path: "/admin", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 4, Name: "管理员列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/admin/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 5, Name: "权限列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/permission/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 6, Name: "角色列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/role/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 7, Name: "系统配置", GuardName: "admin", Icon: "icon-setting", Type: 1, Pid: 0, Sort: 100, Path: "/system", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 8, Name: "设置管理", GuardName: "admin", Icon: "", Type: 1, Pid: 7, Sort: 0, Path: "/system/config", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 9, Name: "网站设置", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/webConfig/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 10, Name: "配置管理", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/config/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 11, Name: "菜单管理", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 0, Path: "/api/admin/menu/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 12, Name: "操作日志", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 100, Path: "/api/admin/actionLog/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 13, Name: "附件空间", GuardName: "admin", Icon: "icon-attachment", Type: 1, Pid: 0, Sort: 100, Path: "/attachment", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 14, Name: "文件管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/file/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 15, Name: "图片管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/picture/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 16, Name: "我的账号", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 100, Path: "/account", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+	{Id: 17, Name: "个人设置", GuardName: "admin", Icon: "", Type: 2, Pid: 16, Sort: 0, Path: "/api/admin/account/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 20, Name: "系统监控", GuardName: "admin", Icon: "", Type: 2, Pid: 1, Sort: 100, Path: "/api/admin/dashboard/monitor/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 21, Name: "文件夹", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/fileCategory/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	{Id: 22, Name: "图片文件夹", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/pictureCategory/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+}
+
+// 填充菜单，路径已存在的菜单跳过，可以重复执行；菜单Id被占用时使用自增Id，子菜单的Pid随之更新，
+// 菜单需要按照先父级后子级的顺序排列
+func SeedMenus(tx *gorm.DB, menus []v20261019000001.Menu) error {
+	ids := map[int]int{}
+	for _, menu := range menus {
+		seedId := menu.Id
+		if menu.Pid != 0 {
+			if pid, ok := ids[menu.Pid]; ok {
+				menu.Pid = pid
+			}
+		}
+
+		exist := v20261019000001.Menu{}
+		err := tx.Where("guard_name = ? AND path = ?", menu.GuardName, menu.Path).First(&exist).Error
+		if err == nil {
+			ids[seedId] = exist.Id
+			continue
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&v20261019000001.Menu{}).Where("id = ?", menu.Id).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			menu.Id = 0
+		}
+
+		err = tx.Create(&menu).Error
+		if err != nil {
+			return err
+		}
+		ids[seedId] = menu.Id
+	}
+
+	return nil
+}
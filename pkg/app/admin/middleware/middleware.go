@@ -6,6 +6,7 @@ import (
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/logins"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 )
 
@@ -36,8 +37,8 @@ func Handle(ctx *builder.Context) error {
 		return ctx.Next()
 	}
 
-	// 排除非后台路由
-	if !strings.Contains(ctx.Path(), "api/admin") {
+	// 排除非后台路由，REST接口与后台接口使用相同的认证与权限
+	if !strings.Contains(ctx.Path(), "api/admin") && !strings.HasPrefix(ctx.Path(), resource.RestPathPrefix) {
 		return ctx.Next()
 	}
 
@@ -47,12 +48,17 @@ func Handle(ctx *builder.Context) error {
 	// 获取登录管理员信息
 	err := ctx.JwtAuthUser(adminInfo)
 	if err != nil {
-		return ctx.JSON(401, builder.Error(err.Error()))
+		return ctx.JSON(401, builder.Error(ctx.TError(err)))
 	}
 
 	guardName := adminInfo.GuardName
 	if guardName != "admin" {
-		return ctx.JSON(401, builder.Error("401 Unauthozied"))
+		return ctx.JSON(401, builder.Error(ctx.T("message.unauthorized")))
+	}
+
+	// 使用管理员偏好的语言
+	if adminInfo.Locale != "" {
+		ctx.SetLocale(adminInfo.Locale)
 	}
 
 	// 管理员id
@@ -77,8 +83,14 @@ func Handle(ctx *builder.Context) error {
 			return ctx.JSON(500, builder.Error(err.Error()))
 		}
 
-		if !(result1 || result2 || result3 || result4) {
-			return ctx.JSON(403, builder.Error("403 Forbidden"))
+		// 带有路径参数的路由，例如：/api/v1/admin/:id
+		result5, err := (&model.CasbinRule{}).Enforce("admin|"+strconv.Itoa(adminInfo.Id), ctx.RouterPathToUrl(ctx.FullPath()), ctx.Method())
+		if err != nil {
+			return ctx.JSON(500, builder.Error(err.Error()))
+		}
+
+		if !(result1 || result2 || result3 || result4 || result5) {
+			return ctx.JSON(403, builder.Error(ctx.T("message.forbidden")))
 		}
 	}
 
@@ -1,13 +1,13 @@
 package model
 
 import (
-	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
 	"gorm.io/gorm"
 )
 
@@ -24,6 +24,7 @@ type Admin struct {
 	LastLoginIp   string            `json:"last_login_ip" gorm:"size:255"`
 	LastLoginTime datetime.Datetime `json:"last_login_time"`
 	Status        int               `json:"status" gorm:"size:1;not null;default:1"`
+	Locale        string            `json:"locale" gorm:"size:20"`
 	CreatedAt     datetime.Datetime `json:"created_at"`
 	UpdatedAt     datetime.Datetime `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt    `json:"deleted_at"`
@@ -39,18 +40,10 @@ type AdminClaims struct {
 	Phone     string `json:"phone"`
 	Avatar    string `json:"avatar"`
 	GuardName string `json:"guard_name"`
+	Locale    string `json:"locale"`
 	jwt.RegisteredClaims
 }
 
-// 管理员Seeder
-func (model *Admin) Seeder() {
-	seeders := []Admin{
-		{Username: "administrator", Nickname: "超级管理员", Email: "admin@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: datetime.Now()},
-	}
-
-	db.Client.Create(&seeders)
-}
-
 // 获取管理员JWT信息
 func (model *Admin) GetClaims(adminInfo *Admin) (adminClaims *AdminClaims) {
 	adminClaims = &AdminClaims{
@@ -62,12 +55,13 @@ func (model *Admin) GetClaims(adminInfo *Admin) (adminClaims *AdminClaims) {
 		adminInfo.Phone,
 		adminInfo.Avatar,
 		"admin",
+		adminInfo.Locale,
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 过期时间，默认24小时
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // 颁发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                     // 不早于时间
-			Issuer:    "QuarkGo",                                          // 颁发人
-			Subject:   "Admin Token",                                      // 主题信息
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(builder.GetJwtExpire())), // 过期时间，默认24小时
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                             // 颁发时间
+			NotBefore: jwt.NewNumericDate(time.Now()),                             // 不早于时间
+			Issuer:    "QuarkGo",                                                  // 颁发人
+			Subject:   "Admin Token",                                              // 主题信息
 		},
 	}
 
@@ -82,11 +76,11 @@ func (model *Admin) GetAuthUser(appKey string, tokenString string) (adminClaims
 	if err != nil {
 		if ve, ok := err.(*jwt.ValidationError); ok {
 			if ve.Errors&jwt.ValidationErrorMalformed != 0 {
-				return nil, errors.New("token格式错误")
+				return nil, i18n.NewError("token.malformed")
 			} else if ve.Errors&jwt.ValidationErrorExpired != 0 {
-				return nil, errors.New("token已过期")
+				return nil, i18n.NewError("token.expired")
 			} else if ve.Errors&jwt.ValidationErrorNotValidYet != 0 {
-				return nil, errors.New("token未生效")
+				return nil, i18n.NewError("token.not_valid_yet")
 			} else {
 				return nil, err
 			}
@@ -97,7 +91,7 @@ func (model *Admin) GetAuthUser(appKey string, tokenString string) (adminClaims
 		return claims, nil
 	}
 
-	return nil, errors.New("token不可用")
+	return nil, i18n.NewError("token.invalid")
 }
 
 // 通过ID获取管理员信息
@@ -117,10 +111,10 @@ func (model *Admin) GetInfoByUsername(username string) (admin *Admin, Error erro
 	return admin, err
 }
 
-// 通过ID获取管理员拥有的菜单列表
-func (model *Admin) GetMenuListById(id interface{}) (menuList interface{}, Error error) {
+// 通过ID获取管理员拥有的菜单列表，可传入语言用于翻译菜单名称
+func (model *Admin) GetMenuListById(id interface{}, locale ...string) (menuList interface{}, Error error) {
 
-	return (&Menu{}).GetListByAdminId(id.(int))
+	return (&Menu{}).GetListByAdminId(id.(int), locale...)
 }
 
 // 更新最后一次登录数据
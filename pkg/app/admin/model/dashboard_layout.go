@@ -0,0 +1,121 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+	"gorm.io/gorm"
+)
+
+// 仪表盘布局的所属类型
+const (
+	DashboardLayoutAdmin = "ADMINID" // 管理员
+	DashboardLayoutRole  = "ROLE"    // 角色，作为该角色下管理员的默认布局
+)
+
+// 仪表盘布局，管理员未设置时使用角色的布局，角色也未设置时使用仪表盘定义的卡片
+type DashboardLayout struct {
+	Id        int               `json:"id" gorm:"autoIncrement"`
+	Dashboard string            `json:"dashboard" gorm:"size:100;not null;index:idx_dashboard_layouts_obj"`
+	ObjType   string            `json:"obj_type" gorm:"size:100;not null;index:idx_dashboard_layouts_obj"`
+	ObjId     int               `json:"obj_id" gorm:"size:11;not null;index:idx_dashboard_layouts_obj"`
+	Cards     string            `json:"cards" gorm:"type:text"`
+	CreatedAt datetime.Datetime `json:"created_at"`
+	UpdatedAt datetime.Datetime `json:"updated_at"`
+}
+
+// 布局中的卡片
+type DashboardCard struct {
+	Key string `json:"key"` // 卡片key
+	Col int    `json:"col"` // 卡片宽度，1-24
+}
+
+// 获取布局中的卡片，未设置时返回nil
+func (model *DashboardLayout) GetCards(dashboard string, objType string, objId int) (cards []*DashboardCard, Error error) {
+	layout := &DashboardLayout{}
+	err := db.Client.
+		Where("dashboard = ?", dashboard).
+		Where("obj_type = ?", objType).
+		Where("obj_id = ?", objId).
+		First(layout).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cards = []*DashboardCard{}
+	err = json.Unmarshal([]byte(layout.Cards), &cards)
+	if cards == nil {
+		cards = []*DashboardCard{}
+	}
+
+	return cards, err
+}
+
+// 获取管理员的布局，未设置时使用角色的布局，多个角色时使用id最小的角色，都未设置时返回nil
+func (model *DashboardLayout) GetAdminCards(dashboard string, adminId int) (cards []*DashboardCard, Error error) {
+	cards, err := model.GetCards(dashboard, DashboardLayoutAdmin, adminId)
+	if err != nil || cards != nil {
+		return cards, err
+	}
+
+	roles, err := (&CasbinRule{}).GetUserRoles(adminId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Id < roles[j].Id
+	})
+	for _, role := range roles {
+		cards, err = model.GetCards(dashboard, DashboardLayoutRole, role.Id)
+		if err != nil || cards != nil {
+			return cards, err
+		}
+	}
+
+	return nil, nil
+}
+
+// 保存布局
+func (model *DashboardLayout) SaveCards(dashboard string, objType string, objId int, cards []*DashboardCard) error {
+	if cards == nil {
+		cards = []*DashboardCard{}
+	}
+	content, err := json.Marshal(cards)
+	if err != nil {
+		return err
+	}
+
+	layout := &DashboardLayout{}
+	err = db.Client.
+		Where("dashboard = ?", dashboard).
+		Where("obj_type = ?", objType).
+		Where("obj_id = ?", objId).
+		First(layout).Error
+	if err == gorm.ErrRecordNotFound {
+		return db.Client.Create(&DashboardLayout{
+			Dashboard: dashboard,
+			ObjType:   objType,
+			ObjId:     objId,
+			Cards:     string(content),
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	return db.Client.Model(layout).Update("cards", string(content)).Error
+}
+
+// 删除布局，恢复为默认布局
+func (model *DashboardLayout) DeleteCards(dashboard string, objType string, objId int) error {
+	return db.Client.
+		Where("dashboard = ?", dashboard).
+		Where("obj_type = ?", objType).
+		Where("obj_id = ?", objId).
+		Delete(&DashboardLayout{}).Error
+}
@@ -2,11 +2,16 @@ package model
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
 	"github.com/xuri/excelize/v2"
 )
@@ -24,6 +29,9 @@ type File struct {
 	Path           string            `json:"path" gorm:"size:255;not null"`
 	Url            string            `json:"url" gorm:"size:255;not null"`
 	Hash           string            `json:"hash" gorm:"size:255;not null"`
+	Visibility     string            `json:"visibility" gorm:"size:20;not null;default:public"`
+	ScanStatus     string            `json:"scan_status" gorm:"size:20"`
+	ScanResult     string            `json:"scan_result" gorm:"size:255"`
 	Status         int               `json:"status" gorm:"size:1;not null;default:1"`
 	CreatedAt      datetime.Datetime `json:"created_at"`
 	UpdatedAt      datetime.Datetime `json:"updated_at"`
@@ -36,6 +44,110 @@ func (model *File) InsertGetId(data *File) (id int, Error error) {
 	return data.Id, err
 }
 
+// 根据搜索条件获取当前管理员的文件列表
+func (model *File) SearchList(appKey string, tokenString string, search *FileSearch) (list []*File, total int64, Error error) {
+	files := []*File{}
+
+	adminInfo, err := (&Admin{}).GetAuthUser(appKey, tokenString)
+	if err != nil {
+		return files, 0, err
+	}
+
+	query := db.Client.Model(&File{}).
+		Where("status =?", 1).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id", adminInfo.Id)
+
+	categoryIds := []int{}
+	if search.CategoryId != 0 {
+		categoryIds = (&FileCategory{}).GetDescendantIds(search.CategoryId)
+	}
+	query = search.apply(query, "file_category_id", categoryIds)
+
+	query.Count(&total)
+	search.paginate(query.Order("id desc")).Find(&files)
+
+	for _, v := range files {
+		if v.IsPrivate() {
+			v.Url = model.GetSignedPath(v.Id, builder.DefaultSignExpire)
+		} else {
+			v.Url = model.GetPath(v.Url)
+		}
+	}
+
+	return files, total, nil
+}
+
+// 将管理员的文件移动到文件夹，categoryId为0时移动到根目录
+func (model *File) MoveToCategory(objId int, ids []int, categoryId int) (int64, error) {
+	result := db.Client.Model(&File{}).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id = ?", objId).
+		Where("id IN ?", ids).
+		Update("file_category_id", categoryId)
+
+	return result.RowsAffected, result.Error
+}
+
+// 重命名管理员的文件
+func (model *File) Rename(objId int, id int, name string) (count int64, Error error) {
+	// 名称未变化时部分数据库的影响行数为0，需要先查询记录是否存在
+	err := db.Client.Model(&File{}).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id = ?", objId).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	err = db.Client.Model(&File{}).Where("id = ?", id).Update("name", name).Error
+
+	return count, err
+}
+
+// 获取已上传文件的扩展名选项
+func (model *File) GetExtOptions() (options []*selectfield.Option) {
+	exts := []string{}
+	db.Client.Model(&File{}).Where("ext <> ?", "").Distinct("ext").Order("ext asc").Pluck("ext", &exts)
+	for _, v := range exts {
+		options = append(options, &selectfield.Option{Label: v, Value: v})
+	}
+
+	return options
+}
+
+// 插入未通过扫描的文件记录，状态为禁用，不会出现在文件选择列表中
+func (model *File) InsertQuarantine(data *File) (id int, Error error) {
+	err := db.Client.Create(&data).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// 状态字段有默认值，零值需要单独更新
+	err = db.Client.Model(&File{}).Where("id = ?", data.Id).Update("status", 0).Error
+
+	return data.Id, err
+}
+
+// 根据id查询文件信息
+func (model *File) GetInfoById(id interface{}) (file *File, Error error) {
+	err := db.Client.Where("status = ?", 1).Where("id = ?", id).First(&file).Error
+
+	return file, err
+}
+
+// 根据hash及可见性查询文件信息，公开文件与私有文件不共用存储
+func (model *File) GetInfoByHashAndVisibility(hash string, visibility string) (file *File, Error error) {
+	err := db.Client.
+		Where("status = ?", 1).
+		Where("hash = ?", hash).
+		Where("visibility = ?", visibility).
+		First(&file).Error
+
+	return file, err
+}
+
 // 根据hash查询文件信息
 func (model *File) GetInfoByHash(hash string) (file *File, Error error) {
 	err := db.Client.Where("status = ?", 1).Where("hash = ?", hash).First(&file).Error
@@ -43,7 +155,80 @@ func (model *File) GetInfoByHash(hash string) (file *File, Error error) {
 	return file, err
 }
 
-// 获取文件路径
+// 根据hash查询所属对象的文件信息
+func (model *File) GetInfoByHashAndObj(hash string, objType string, objId int) (file *File, Error error) {
+	err := db.Client.
+		Where("status = ?", 1).
+		Where("hash = ?", hash).
+		Where("obj_type = ?", objType).
+		Where("obj_id = ?", objId).
+		First(&file).Error
+
+	return file, err
+}
+
+// 获取使用同一保存路径的记录数量
+func (model *File) CountByPath(path string) (count int64) {
+	db.Client.Model(&File{}).Where("path = ?", path).Count(&count)
+
+	return count
+}
+
+// 获取所有记录的保存路径
+func (model *File) GetSavePaths() (paths []string, Error error) {
+	err := db.Client.Model(&File{}).Distinct("path").Pluck("path", &paths).Error
+
+	return paths, err
+}
+
+// 判断是否为私有文件
+func (model *File) IsPrivate() bool {
+	return model.Visibility == storage.VisibilityPrivate
+}
+
+// 获取私有文件带签名的临时下载地址
+func (model *File) GetSignedPath(id int, expire time.Duration) string {
+	http := ""
+	webSiteDomain := (&Config{}).GetValue("WEB_SITE_DOMAIN")
+	WebConfig := (&Config{}).GetValue("SSL_OPEN")
+	if webSiteDomain != "" {
+		if WebConfig == "1" {
+			http = "https://"
+		} else {
+			http = "http://"
+		}
+	}
+
+	return http + webSiteDomain + builder.SignURL("/upload/file/"+strconv.Itoa(id)+"/download", expire)
+}
+
+// 根据ID获取私有文件的签名地址，不是私有文件时返回空
+func (model *File) getPrivatePath(id interface{}) string {
+	fileId := 0
+	switch getId := id.(type) {
+	case int:
+		fileId = getId
+	case float64:
+		fileId = int(getId)
+	case string:
+		fileId, _ = strconv.Atoi(getId)
+	case map[string]interface{}:
+		return model.getPrivatePath(getId["id"])
+	}
+	if fileId == 0 {
+		return ""
+	}
+
+	file := &File{}
+	db.Client.Where("id", fileId).Where("status", 1).First(&file)
+	if file.Id == 0 || !file.IsPrivate() {
+		return ""
+	}
+
+	return model.GetSignedPath(file.Id, builder.DefaultSignExpire)
+}
+
+// 获取文件路径，私有文件返回带签名的临时下载地址
 func (model *File) GetPath(id interface{}) string {
 	http, path := "", ""
 	webSiteDomain := (&Config{}).GetValue("WEB_SITE_DOMAIN")
@@ -56,6 +241,11 @@ func (model *File) GetPath(id interface{}) string {
 		}
 	}
 
+	// 私有文件
+	if privatePath := model.getPrivatePath(id); privatePath != "" {
+		return privatePath
+	}
+
 	if getId, ok := id.(string); ok {
 		if strings.Contains(getId, "//") && !strings.Contains(getId, "{") {
 			return getId
@@ -73,6 +263,9 @@ func (model *File) GetPath(id interface{}) string {
 			json.Unmarshal([]byte(getId), &jsonData)
 			// 如果为map
 			if mapData, ok := jsonData.(map[string]interface{}); ok {
+				if privatePath := model.getPrivatePath(mapData); privatePath != "" {
+					return privatePath
+				}
 				path = mapData["url"].(string)
 			}
 			// 如果为数组，返回第一个key的path
@@ -131,6 +324,12 @@ func (model *File) GetPaths(id interface{}) []string {
 			err := json.Unmarshal([]byte(getId), &jsonData)
 			if err == nil {
 				for _, v := range jsonData {
+					// 私有文件
+					if privatePath := model.getPrivatePath(v); privatePath != "" {
+						paths = append(paths, privatePath)
+						continue
+					}
+
 					path = v["url"].(string)
 					if strings.Contains(path, "//") {
 						paths = append(paths, v["url"].(string))
@@ -159,7 +358,7 @@ func (model *File) GetExcelData(fileId int) (data [][]interface{}, Error error)
 		return data, err
 	}
 	if file.Id == 0 {
-		return data, errors.New("参数错误！")
+		return data, i18n.NewError("message.invalid_params")
 	}
 
 	f, err := excelize.OpenFile(file.Path)
@@ -168,7 +367,7 @@ func (model *File) GetExcelData(fileId int) (data [][]interface{}, Error error)
 	}
 	defer func() {
 		if err := f.Close(); err != nil {
-			fmt.Println(err)
+			telemetry.Logger().Warn("close import file failed", "path", file.Path, "error", err)
 		}
 	}()
 
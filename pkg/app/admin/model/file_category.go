@@ -1,11 +1,148 @@
 package model
 
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"gorm.io/gorm"
+)
+
 // 字段
 type FileCategory struct {
 	Id          int    `json:"id" gorm:"autoIncrement"`
+	Pid         int    `json:"pid" gorm:"size:11;default:0"`
 	ObjType     string `json:"obj_type" gorm:"size:100"`
 	ObjId       int    `json:"obj_id" gorm:"size:11;default:0"`
 	Title       string `json:"title" gorm:"size:255;not null"`
 	Sort        int    `json:"sort" gorm:"size:11;default:0"`
 	Description string `json:"description" gorm:"size:255"`
 }
+
+// 获取列表
+func (model *FileCategory) GetAuthList(appKey string, tokenString string) (list []*FileCategory, Error error) {
+	categorys := []*FileCategory{}
+
+	adminInfo, err := (&Admin{}).GetAuthUser(appKey, tokenString)
+	if err != nil {
+		return categorys, err
+	}
+
+	err = db.Client.
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id", adminInfo.Id).
+		Order("sort asc,id asc").
+		Find(&categorys).Error
+	if err != nil {
+		return categorys, err
+	}
+
+	return categorys, nil
+}
+
+// 根据id查询文件夹信息
+func (model *FileCategory) GetInfoById(id interface{}) (category *FileCategory, Error error) {
+	err := db.Client.Where("id = ?", id).First(&category).Error
+
+	return category, err
+}
+
+// 获取文件夹id与名称的对应关系
+func (model *FileCategory) GetTitleMap() map[int]string {
+	categorys := []*FileCategory{}
+	db.Client.Select("id", "title").Find(&categorys)
+
+	titles := map[int]string{}
+	for _, v := range categorys {
+		titles[v.Id] = v.Title
+	}
+
+	return titles
+}
+
+// 获取TreeSelect组件数据
+func (model *FileCategory) TreeSelect(root bool) (list []*treeselect.TreeData, Error error) {
+
+	// 是否有根节点
+	if root {
+		list = append(list, &treeselect.TreeData{
+			Title: i18n.T("", "file.folder_root"),
+			Value: 0,
+		})
+	}
+
+	list = append(list, model.FindTreeSelectNode(0)...)
+
+	return list, nil
+}
+
+// 递归获取TreeSelect组件数据
+func (model *FileCategory) FindTreeSelectNode(pid int) (list []*treeselect.TreeData) {
+	categorys := []FileCategory{}
+	db.Client.
+		Where("pid = ?", pid).
+		Order("sort asc,id asc").
+		Select("title", "id", "pid").
+		Find(&categorys)
+
+	for _, v := range categorys {
+		item := &treeselect.TreeData{
+			Value: v.Id,
+			Title: v.Title,
+		}
+
+		children := model.FindTreeSelectNode(v.Id)
+		if len(children) > 0 {
+			item.Children = children
+		}
+
+		list = append(list, item)
+	}
+
+	return list
+}
+
+// 获取文件夹及其所有子文件夹的id
+func (model *FileCategory) GetDescendantIds(id int) []int {
+	ids := []int{id}
+	pids := []int{id}
+	for len(pids) > 0 {
+		children := []int{}
+		db.Client.Model(&FileCategory{}).Where("pid IN ?", pids).Pluck("id", &children)
+
+		// 避免数据异常时出现循环
+		pids = []int{}
+		for _, v := range children {
+			if !hasId(ids, v) {
+				ids = append(ids, v)
+				pids = append(pids, v)
+			}
+		}
+	}
+
+	return ids
+}
+
+// 判断id列表中是否包含id
+func hasId(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 删除文件夹及其子文件夹，文件夹中的文件移动到根目录
+func (model *FileCategory) DeleteWithChildren(id int) error {
+	ids := model.GetDescendantIds(id)
+
+	return db.Client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&File{}).Where("file_category_id IN ?", ids).Update("file_category_id", 0).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("id IN ?", ids).Delete(&FileCategory{}).Error
+	})
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+)
+
+// 文件类型
+const (
+	FileReferenceFile    = "FILE"    // 文件，对应File表
+	FileReferencePicture = "PICTURE" // 图片，对应Picture表
+)
+
+// 文件引用，记录资源字段引用了哪些文件或图片
+type FileReference struct {
+	Id        int               `json:"id" gorm:"autoIncrement"`
+	FileType  string            `json:"file_type" gorm:"size:50;not null;index:idx_file_references_file"`
+	FileId    int               `json:"file_id" gorm:"size:11;not null;index:idx_file_references_file"`
+	Path      string            `json:"path" gorm:"size:255;not null;index"`
+	RefType   string            `json:"ref_type" gorm:"size:255;not null;index:idx_file_references_ref"`
+	RefId     int               `json:"ref_id" gorm:"size:11;not null;index:idx_file_references_ref"`
+	Field     string            `json:"field" gorm:"size:255;not null"`
+	CreatedAt datetime.Datetime `json:"created_at"`
+	UpdatedAt datetime.Datetime `json:"updated_at"`
+}
+
+// 同步记录字段引用的文件，覆盖该字段之前的引用
+func (model *FileReference) Sync(refType string, refId int, field string, fileType string, fileIds []int) error {
+	err := db.Client.
+		Where("ref_type = ?", refType).
+		Where("ref_id = ?", refId).
+		Where("field = ?", field).
+		Delete(&FileReference{}).Error
+	if err != nil {
+		return err
+	}
+	if len(fileIds) == 0 {
+		return nil
+	}
+
+	// 记录文件路径，文件记录删除后仍可判断存储中的文件是否被引用
+	paths := map[int]string{}
+	switch fileType {
+	case FileReferencePicture:
+		pictures := []*Picture{}
+		db.Client.Where("id IN ?", fileIds).Find(&pictures)
+		for _, v := range pictures {
+			paths[v.Id] = v.Path
+		}
+	default:
+		files := []*File{}
+		db.Client.Where("id IN ?", fileIds).Find(&files)
+		for _, v := range files {
+			paths[v.Id] = v.Path
+		}
+	}
+
+	references := []*FileReference{}
+	for _, v := range fileIds {
+		path, ok := paths[v]
+		if !ok {
+			continue
+		}
+		references = append(references, &FileReference{
+			FileType: fileType,
+			FileId:   v,
+			Path:     path,
+			RefType:  refType,
+			RefId:    refId,
+			Field:    field,
+		})
+	}
+	if len(references) == 0 {
+		return nil
+	}
+
+	return db.Client.Create(&references).Error
+}
+
+// 删除记录的所有引用
+func (model *FileReference) RemoveByRef(refType string, refId int) error {
+	return db.Client.
+		Where("ref_type = ?", refType).
+		Where("ref_id = ?", refId).
+		Delete(&FileReference{}).Error
+}
+
+// 获取文件被引用的次数
+func (model *FileReference) CountByFile(fileType string, fileId int) (count int64) {
+	db.Client.
+		Model(&FileReference{}).
+		Where("file_type = ?", fileType).
+		Where("file_id = ?", fileId).
+		Count(&count)
+
+	return count
+}
+
+// 获取路径被引用的次数
+func (model *FileReference) CountByPath(path string) (count int64) {
+	db.Client.
+		Model(&FileReference{}).
+		Where("path = ?", path).
+		Count(&count)
+
+	return count
+}
+
+// 获取文件的引用列表
+func (model *FileReference) GetListByFile(fileType string, fileId int) (list []*FileReference, Error error) {
+	references := []*FileReference{}
+	err := db.Client.
+		Where("file_type = ?", fileType).
+		Where("file_id = ?", fileId).
+		Order("id desc").
+		Find(&references).Error
+
+	return references, err
+}
+
+// 清理引用记录已不存在的引用，返回清理的数量
+func (model *FileReference) PruneStale() (int64, error) {
+	var (
+		refTypes []string
+		removed  int64
+	)
+
+	err := db.Client.Model(&FileReference{}).Distinct("ref_type").Pluck("ref_type", &refTypes).Error
+	if err != nil {
+		return removed, err
+	}
+
+	for _, refType := range refTypes {
+		if !db.Client.Migrator().HasTable(refType) {
+			continue
+		}
+
+		result := db.Client.
+			Where("ref_type = ?", refType).
+			Where("ref_id NOT IN (?)", db.Client.Table(refType).Select("id")).
+			Delete(&FileReference{})
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		removed += result.RowsAffected
+	}
+
+	return removed, nil
+}
+
+// 获取所有被引用的路径
+func (model *FileReference) GetPaths() (paths []string, Error error) {
+	err := db.Client.Model(&FileReference{}).Distinct("path").Pluck("path", &paths).Error
+
+	return paths, err
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 文件选择器可选的每页数量
+var FileSearchPageSizes = []int{8, 16, 24, 48, 96}
+
+// 文件、图片搜索条件
+type FileSearch struct {
+	CategoryId int    // 文件夹，包含子文件夹中的文件，为0时不限制
+	Name       string // 名称，模糊匹配
+	Ext        string // 扩展名，多个用逗号分隔
+	MinSize    int64  // 最小文件大小
+	MaxSize    int64  // 最大文件大小，为0时不限制
+	StartDate  string // 上传开始时间
+	EndDate    string // 上传结束时间
+	Page       int    // 当前页码
+	PageSize   int    // 每页数量，只能使用FileSearchPageSizes中的值，默认8
+}
+
+// 获取当前页码
+func (p *FileSearch) GetPage() int {
+	if p.Page < 1 {
+		return 1
+	}
+
+	return p.Page
+}
+
+// 获取每页数量
+func (p *FileSearch) GetPageSize() int {
+	for _, v := range FileSearchPageSizes {
+		if v == p.PageSize {
+			return v
+		}
+	}
+
+	return FileSearchPageSizes[0]
+}
+
+// 应用搜索条件，categoryIds为文件夹及其子文件夹的id
+func (p *FileSearch) apply(query *gorm.DB, categoryColumn string, categoryIds []int) *gorm.DB {
+	if p.CategoryId != 0 {
+		query = query.Where(categoryColumn+" IN ?", categoryIds)
+	}
+	if p.Name != "" {
+		query = query.Where("name LIKE ?", "%"+p.Name+"%")
+	}
+	if p.Ext != "" {
+		query = query.Where("ext IN ?", strings.Split(strings.ToLower(p.Ext), ","))
+	}
+	if p.MinSize > 0 {
+		query = query.Where("size >= ?", p.MinSize)
+	}
+	if p.MaxSize > 0 {
+		query = query.Where("size <= ?", p.MaxSize)
+	}
+	if p.StartDate != "" && p.EndDate != "" {
+		query = query.Where("created_at BETWEEN ? AND ?", p.StartDate, p.EndDate)
+	}
+
+	return query
+}
+
+// 分页
+func (p *FileSearch) paginate(query *gorm.DB) *gorm.DB {
+	return query.
+		Limit(p.GetPageSize()).
+		Offset((p.GetPage() - 1) * p.GetPageSize())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/tree"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/lister"
 	"gorm.io/gorm"
@@ -33,38 +34,13 @@ type Menu struct {
 	UpdatedAt  datetime.Datetime `json:"updated_at"`
 }
 
-// 菜单表
-func (p *Menu) Seeder() {
-	seeders := []Menu{
-		{Id: 1, Name: "控制台", GuardName: "admin", Icon: "icon-home", Type: 1, Pid: 0, Sort: 0, Path: "/dashboard", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 2, Name: "主页", GuardName: "admin", Icon: "", Type: 2, Pid: 1, Sort: 0, Path: "/api/admin/dashboard/index/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 3, Name: "管理员", GuardName: "admin", Icon: "icon-admin", Type: 1, Pid: 0, Sort: 100, Path: "/admin", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 4, Name: "管理员列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/admin/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 5, Name: "权限列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/permission/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 6, Name: "角色列表", GuardName: "admin", Icon: "", Type: 2, Pid: 3, Sort: 0, Path: "/api/admin/role/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 7, Name: "系统配置", GuardName: "admin", Icon: "icon-setting", Type: 1, Pid: 0, Sort: 100, Path: "/system", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 8, Name: "设置管理", GuardName: "admin", Icon: "", Type: 1, Pid: 7, Sort: 0, Path: "/system/config", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 9, Name: "网站设置", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/webConfig/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 10, Name: "配置管理", GuardName: "admin", Icon: "", Type: 2, Pid: 8, Sort: 0, Path: "/api/admin/config/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 11, Name: "菜单管理", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 0, Path: "/api/admin/menu/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 12, Name: "操作日志", GuardName: "admin", Icon: "", Type: 2, Pid: 7, Sort: 100, Path: "/api/admin/actionLog/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 13, Name: "附件空间", GuardName: "admin", Icon: "icon-attachment", Type: 1, Pid: 0, Sort: 100, Path: "/attachment", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 14, Name: "文件管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/file/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 15, Name: "图片管理", GuardName: "admin", Icon: "", Type: 2, Pid: 13, Sort: 0, Path: "/api/admin/picture/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-		{Id: 16, Name: "我的账号", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 100, Path: "/account", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 17, Name: "个人设置", GuardName: "admin", Icon: "", Type: 2, Pid: 16, Sort: 0, Path: "/api/admin/account/setting/form", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-	}
-
-	db.Client.Create(&seeders)
-}
-
 // 获取TreeSelect组件数据
 func (model *Menu) TreeSelect(root bool) (list []*treeselect.TreeData, Error error) {
 
 	// 是否有根节点
 	if root {
 		list = append(list, &treeselect.TreeData{
-			Title: "根节点",
+			Title: i18n.T("", "menu.root"),
 			Value: 0,
 		})
 	}
@@ -172,7 +148,7 @@ func (model *Menu) FindParentTreeNode(chrildPid int) (list []*Menu) {
 }
 
 // 通过管理员ID权限菜单
-func (model *Menu) GetListByAdminId(adminId int) (menuList interface{}, err error) {
+func (model *Menu) GetListByAdminId(adminId int, locale ...string) (menuList interface{}, err error) {
 	menus := []*Menu{}
 
 	if adminId == 1 {
@@ -183,7 +159,7 @@ func (model *Menu) GetListByAdminId(adminId int) (menuList interface{}, err erro
 			Order("sort asc").
 			Find(&menus)
 
-		return model.MenuParser(menus)
+		return model.MenuParser(menus, locale...)
 	}
 
 	var menuIds []int
@@ -223,16 +199,19 @@ func (model *Menu) GetListByAdminId(adminId int) (menuList interface{}, err erro
 		Order("sort asc").
 		Find(&menus)
 
-	return model.MenuParser(menus)
+	return model.MenuParser(menus, locale...)
 }
 
-// 解析菜单
-func (model *Menu) MenuParser(menus []*Menu) (menuList interface{}, Error error) {
+// 解析菜单，传入语言时使用语言包中的菜单名称
+func (model *Menu) MenuParser(menus []*Menu, locale ...string) (menuList interface{}, Error error) {
 	newMenus := []*Menu{}
 
 	for _, v := range menus {
 		v.Key = uuid.New()
 		v.Locale = "menu" + strings.Replace(v.Path, "/", ".", -1)
+		if len(locale) > 0 {
+			v.Name = i18n.TranslateSeed(locale[0], v.Locale, v.Name)
+		}
 
 		if v.Show == 1 {
 			v.HideInMenu = false
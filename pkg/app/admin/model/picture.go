@@ -2,10 +2,12 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
 )
@@ -28,10 +30,22 @@ type Picture struct {
 	Status            int               `json:"status" gorm:"size:1;not null;default:1"`
 	CreatedAt         datetime.Datetime `json:"created_at"`
 	UpdatedAt         datetime.Datetime `json:"updated_at"`
+	Thumb             string            `json:"thumb" gorm:"-"` // 缩略图地址，不存储
 }
 
 // 获取列表
 func (model *Picture) GetListBySearch(appKey string, tokenString string, categoryId interface{}, name interface{}, startDate interface{}, endDate interface{}, page int) (list []*Picture, total int64, Error error) {
+	search := &FileSearch{Page: page}
+	search.CategoryId, _ = strconv.Atoi(fmt.Sprint(categoryId))
+	search.Name, _ = name.(string)
+	search.StartDate, _ = startDate.(string)
+	search.EndDate, _ = endDate.(string)
+
+	return model.SearchList(appKey, tokenString, search)
+}
+
+// 根据搜索条件获取当前管理员的图片列表
+func (model *Picture) SearchList(appKey string, tokenString string, search *FileSearch) (list []*Picture, total int64, Error error) {
 	pictures := []*Picture{}
 
 	adminInfo, err := (&Admin{}).GetAuthUser(appKey, tokenString)
@@ -44,22 +58,14 @@ func (model *Picture) GetListBySearch(appKey string, tokenString string, categor
 		Where("obj_type = ?", "ADMINID").
 		Where("obj_id", adminInfo.Id)
 
-	if categoryId != "" {
-		query.Where("picture_category_id =?", categoryId)
-	}
-	if name != "" {
-		query.Where("name LIKE %?%", name)
-	}
-	if startDate != "" && endDate != "" {
-		query.Where("created_at BETWEEN ? AND ?", startDate, endDate)
+	categoryIds := []int{}
+	if search.CategoryId != 0 {
+		categoryIds = (&PictureCategory{}).GetDescendantIds(search.CategoryId)
 	}
+	query = search.apply(query, "picture_category_id", categoryIds)
 
 	query.Count(&total)
-	query.
-		Order("id desc").
-		Limit(8).
-		Offset((page - 1) * 8).
-		Find(&pictures)
+	search.paginate(query.Order("id desc")).Find(&pictures)
 
 	for k, v := range pictures {
 		v.Url = model.GetPath(v.Url) + "?timestamp=" + strconv.Itoa(int(time.Now().Unix()))
@@ -69,6 +75,45 @@ func (model *Picture) GetListBySearch(appKey string, tokenString string, categor
 	return pictures, total, nil
 }
 
+// 将管理员的图片移动到文件夹，categoryId为0时移动到根目录
+func (model *Picture) MoveToCategory(objId int, ids []int, categoryId int) (int64, error) {
+	result := db.Client.Model(&Picture{}).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id = ?", objId).
+		Where("id IN ?", ids).
+		Update("picture_category_id", categoryId)
+
+	return result.RowsAffected, result.Error
+}
+
+// 重命名管理员的图片
+func (model *Picture) Rename(objId int, id int, name string) (count int64, Error error) {
+	// 名称未变化时部分数据库的影响行数为0，需要先查询记录是否存在
+	err := db.Client.Model(&Picture{}).
+		Where("obj_type = ?", "ADMINID").
+		Where("obj_id = ?", objId).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	err = db.Client.Model(&Picture{}).Where("id = ?", id).Update("name", name).Error
+
+	return count, err
+}
+
+// 获取已上传图片的扩展名选项
+func (model *Picture) GetExtOptions() (options []*selectfield.Option) {
+	exts := []string{}
+	db.Client.Model(&Picture{}).Where("ext <> ?", "").Distinct("ext").Order("ext asc").Pluck("ext", &exts)
+	for _, v := range exts {
+		options = append(options, &selectfield.Option{Label: v, Value: v})
+	}
+
+	return options
+}
+
 // 插入数据并返回ID
 func (model *Picture) InsertGetId(picture *Picture) (id int, Error error) {
 	err := db.Client.Create(&picture).Error
@@ -106,8 +151,34 @@ func (model *Picture) GetInfoByHash(hash string) (picture *Picture, Error error)
 	return picture, err
 }
 
-// 获取图片路径
-func (model *Picture) GetPath(id interface{}) string {
+// 根据hash查询所属对象的文件信息
+func (model *Picture) GetInfoByHashAndObj(hash string, objType string, objId int) (picture *Picture, Error error) {
+	err := db.Client.
+		Where("status = ?", 1).
+		Where("hash = ?", hash).
+		Where("obj_type = ?", objType).
+		Where("obj_id = ?", objId).
+		First(&picture).Error
+
+	return picture, err
+}
+
+// 获取使用同一保存路径的记录数量
+func (model *Picture) CountByPath(path string) (count int64) {
+	db.Client.Model(&Picture{}).Where("path = ?", path).Count(&count)
+
+	return count
+}
+
+// 获取所有记录的保存路径
+func (model *Picture) GetSavePaths() (paths []string, Error error) {
+	err := db.Client.Model(&Picture{}).Distinct("path").Pluck("path", &paths).Error
+
+	return paths, err
+}
+
+// 获取图片路径，传入规格名称时返回对应规格的地址，例如：GetPath(1, "thumb")
+func (model *Picture) GetPath(id interface{}, variant ...string) string {
 	http, path := "", ""
 	webSiteDomain := (&Config{}).GetValue("WEB_SITE_DOMAIN")
 	WebConfig := (&Config{}).GetValue("SSL_OPEN")
@@ -119,6 +190,13 @@ func (model *Picture) GetPath(id interface{}) string {
 		}
 	}
 
+	// 图片规格地址
+	if len(variant) > 0 && variant[0] != "" {
+		if pictureId := model.getId(id); pictureId != 0 {
+			return http + webSiteDomain + "/upload/image/" + strconv.Itoa(pictureId) + "/" + variant[0]
+		}
+	}
+
 	if getId, ok := id.(string); ok {
 		if strings.Contains(getId, "//") && !strings.Contains(getId, "{") {
 			return getId
@@ -174,6 +252,34 @@ func (model *Picture) GetPath(id interface{}) string {
 	return http + webSiteDomain + "/admin/default.png"
 }
 
+// 解析图片ID，支持数字、数字字符串及包含id的json字符串
+func (model *Picture) getId(id interface{}) int {
+	switch getId := id.(type) {
+	case int:
+		return getId
+	case int64:
+		return int(getId)
+	case float64:
+		return int(getId)
+	case string:
+		if pictureId, err := strconv.Atoi(getId); err == nil {
+			return pictureId
+		}
+		if strings.Contains(getId, "{") {
+			var jsonData interface{}
+			json.Unmarshal([]byte(getId), &jsonData)
+			if arrayData, ok := jsonData.([]interface{}); ok && len(arrayData) > 0 {
+				jsonData = arrayData[0]
+			}
+			if mapData, ok := jsonData.(map[string]interface{}); ok {
+				return model.getId(mapData["id"])
+			}
+		}
+	}
+
+	return 0
+}
+
 // 获取多图片路径
 func (model *Picture) GetPaths(id interface{}) []string {
 	var paths []string
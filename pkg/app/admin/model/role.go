@@ -8,11 +8,12 @@ import (
 
 // 角色
 type Role struct {
-	Id        int               `json:"id" gorm:"autoIncrement"`
-	Name      string            `json:"name" gorm:"size:255;not null"`
-	GuardName string            `json:"guard_name" gorm:"size:100;not null"`
-	CreatedAt datetime.Datetime `json:"created_at"`
-	UpdatedAt datetime.Datetime `json:"updated_at"`
+	Id           int               `json:"id" gorm:"autoIncrement"`
+	Name         string            `json:"name" gorm:"size:255;not null"`
+	GuardName    string            `json:"guard_name" gorm:"size:100;not null"`
+	StorageQuota int64             `json:"storage_quota" gorm:"size:20;default:0"` // 存储配额，单位为MB，为0时使用所属类型的配额
+	CreatedAt    datetime.Datetime `json:"created_at"`
+	UpdatedAt    datetime.Datetime `json:"updated_at"`
 }
 
 // 获取角色列表
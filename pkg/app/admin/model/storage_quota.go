@@ -0,0 +1,174 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+// 存储配额的配置项，单位为MB，为0时不限制
+const (
+	StorageQuotaTotal  = "STORAGE_QUOTA_TOTAL" // 全局配额
+	StorageQuotaPrefix = "STORAGE_QUOTA_"      // 按所属类型配置的配额前缀，例如：STORAGE_QUOTA_ADMINID
+)
+
+// 存储用量
+type StorageUsage struct {
+	ObjType string `json:"obj_type"` // 所属类型
+	ObjId   int    `json:"obj_id"`   // 所属id，按类型统计时为0
+	Size    int64  `json:"size"`     // 已使用的字节数
+	Count   int64  `json:"count"`    // 文件数量
+}
+
+// 存储配额，配额来源于全局配置、所属类型配置及管理员角色，用量为文件与图片大小之和
+type StorageQuota struct{}
+
+// 获取配置的配额，单位为字节，为0时不限制
+func (model *StorageQuota) getConfigLimit(name string) int64 {
+	limit, _ := strconv.ParseInt((&Config{}).GetValue(name), 10, 64)
+	if limit < 0 {
+		return 0
+	}
+
+	return limit << 20
+}
+
+// 获取全局配额，单位为字节，为0时不限制
+func (model *StorageQuota) GetTotalLimit() int64 {
+	return model.getConfigLimit(StorageQuotaTotal)
+}
+
+// 获取所属者的配额，单位为字节，为0时不限制；管理员优先使用角色配额，多个角色取最大值，角色未设置时使用所属类型的配额
+func (model *StorageQuota) GetLimit(objType string, objId int) int64 {
+	if objType == "ADMINID" {
+		roles, _ := (&CasbinRule{}).GetUserRoles(objId)
+
+		limit := int64(0)
+		for _, v := range roles {
+			if v.StorageQuota > 0 && v.StorageQuota<<20 > limit {
+				limit = v.StorageQuota << 20
+			}
+		}
+		if limit > 0 {
+			return limit
+		}
+	}
+
+	return model.getConfigLimit(StorageQuotaPrefix + objType)
+}
+
+// 获取所属者已使用的字节数
+func (model *StorageQuota) GetUsage(objType string, objId int) (int64, error) {
+	var fileSize, pictureSize int64
+	err := db.Client.Model(&File{}).
+		Where("obj_type = ? AND obj_id = ? AND status = ?", objType, objId, 1).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&fileSize).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.Client.Model(&Picture{}).
+		Where("obj_type = ? AND obj_id = ? AND status = ?", objType, objId, 1).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&pictureSize).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return fileSize + pictureSize, nil
+}
+
+// 获取全部已使用的字节数
+func (model *StorageQuota) GetTotalUsage() (int64, error) {
+	var fileSize, pictureSize int64
+	err := db.Client.Model(&File{}).Where("status = ?", 1).Select("COALESCE(SUM(size), 0)").Scan(&fileSize).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.Client.Model(&Picture{}).Where("status = ?", 1).Select("COALESCE(SUM(size), 0)").Scan(&pictureSize).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return fileSize + pictureSize, nil
+}
+
+// 判断所属类型是否受配额限制，配置了所属类型或全局配额时受限制
+func (model *StorageQuota) IsLimited(objType string) bool {
+	return model.getConfigLimit(StorageQuotaPrefix+objType) > 0 || model.GetTotalLimit() > 0
+}
+
+// 获取所属者的剩余空间，单位为字节，不限制时返回-1；同时受所属者配额及全局配额限制，查询用量出错时返回错误
+func (model *StorageQuota) GetRemaining(objType string, objId int) (int64, error) {
+	remaining := int64(-1)
+
+	if limit := model.GetLimit(objType, objId); limit > 0 {
+		usage, err := model.GetUsage(objType, objId)
+		if err != nil {
+			return 0, err
+		}
+
+		remaining = limit - usage
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	if limit := model.GetTotalLimit(); limit > 0 {
+		usage, err := model.GetTotalUsage()
+		if err != nil {
+			return 0, err
+		}
+
+		totalRemaining := limit - usage
+		if totalRemaining < 0 {
+			totalRemaining = 0
+		}
+		if remaining < 0 || totalRemaining < remaining {
+			remaining = totalRemaining
+		}
+	}
+
+	return remaining, nil
+}
+
+// 获取用量列表，byObj为true时按所属者统计，否则按所属类型统计，按用量从大到小排序
+func (model *StorageQuota) GetUsageList(byObj bool) (list []*StorageUsage, Error error) {
+	columns := "obj_type"
+	if byObj {
+		columns = "obj_type, obj_id"
+	}
+
+	usages := map[string]*StorageUsage{}
+	for _, v := range []interface{}{&File{}, &Picture{}} {
+		items := []*StorageUsage{}
+		err := db.Client.Model(v).
+			Where("status = ?", 1).
+			Select(columns + ", SUM(size) AS size, COUNT(*) AS count").
+			Group(columns).
+			Scan(&items).Error
+		if err != nil {
+			return list, err
+		}
+
+		for _, item := range items {
+			key := item.ObjType + ":" + strconv.Itoa(item.ObjId)
+			if usage, ok := usages[key]; ok {
+				usage.Size += item.Size
+				usage.Count += item.Count
+				continue
+			}
+			usages[key] = item
+			list = append(list, item)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Size > list[j].Size
+	})
+
+	return list, nil
+}
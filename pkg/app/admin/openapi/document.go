@@ -0,0 +1,215 @@
+package openapi
+
+// OpenAPI文档版本
+const Version = "3.0.3"
+
+// 文档
+type Document struct {
+	OpenAPI    string                `json:"openapi"`              // OpenAPI版本
+	Info       *Info                 `json:"info"`                 // 文档信息
+	Servers    []*Server             `json:"servers,omitempty"`    // 服务列表
+	Paths      map[string]*PathItem  `json:"paths"`                // 接口路径
+	Components *Components           `json:"components,omitempty"` // 公共组件
+	Security   []map[string][]string `json:"security,omitempty"`   // 全局认证方式
+	Tags       []*Tag                `json:"tags,omitempty"`       // 标签
+}
+
+// 文档信息
+type Info struct {
+	Title       string `json:"title"`                 // 标题
+	Description string `json:"description,omitempty"` // 描述
+	Version     string `json:"version"`               // 版本号
+}
+
+// 服务
+type Server struct {
+	Url         string `json:"url"`                   // 服务地址
+	Description string `json:"description,omitempty"` // 描述
+}
+
+// 标签
+type Tag struct {
+	Name        string `json:"name"`                  // 名称
+	Description string `json:"description,omitempty"` // 描述
+}
+
+// 接口路径
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Options *Operation `json:"options,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+}
+
+// 接口操作
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`        // 标签
+	Summary     string               `json:"summary,omitempty"`     // 摘要
+	Description string               `json:"description,omitempty"` // 描述
+	OperationId string               `json:"operationId,omitempty"` // 操作唯一标识
+	Parameters  []*Parameter         `json:"parameters,omitempty"`  // 请求参数
+	RequestBody *RequestBody         `json:"requestBody,omitempty"` // 请求体
+	Responses   map[string]*Response `json:"responses"`             // 响应
+}
+
+// 请求参数
+type Parameter struct {
+	Name        string  `json:"name"`                  // 参数名
+	In          string  `json:"in"`                    // 参数位置，query | path | header | cookie
+	Description string  `json:"description,omitempty"` // 描述
+	Required    bool    `json:"required,omitempty"`    // 是否必须
+	Schema      *Schema `json:"schema,omitempty"`      // 参数结构
+}
+
+// 请求体
+type RequestBody struct {
+	Description string                `json:"description,omitempty"` // 描述
+	Required    bool                  `json:"required,omitempty"`    // 是否必须
+	Content     map[string]*MediaType `json:"content"`               // 内容
+}
+
+// 响应
+type Response struct {
+	Description string                `json:"description"`       // 描述
+	Content     map[string]*MediaType `json:"content,omitempty"` // 内容
+}
+
+// 媒体类型
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"` // 结构
+}
+
+// 数据结构
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+// 公共组件
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`         // 数据结构
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"` // 认证方式
+}
+
+// 认证方式
+type SecurityScheme struct {
+	Type         string `json:"type"`                   // 类型
+	Scheme       string `json:"scheme,omitempty"`       // 方案
+	BearerFormat string `json:"bearerFormat,omitempty"` // Bearer格式
+}
+
+// 初始化文档
+func NewDocument(title string, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info: &Info{
+			Title:   title,
+			Version: version,
+		},
+		Paths: map[string]*PathItem{},
+		Components: &Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+				},
+			},
+		},
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}
+}
+
+// 添加接口操作
+func (p *Document) AddOperation(method string, path string, operation *Operation) *Document {
+	pathItem, ok := p.Paths[path]
+	if !ok {
+		pathItem = &PathItem{}
+		p.Paths[path] = pathItem
+	}
+
+	switch method {
+	case "GET":
+		pathItem.Get = operation
+	case "HEAD":
+		pathItem.Head = operation
+	case "OPTIONS":
+		pathItem.Options = operation
+	case "POST":
+		pathItem.Post = operation
+	case "PUT":
+		pathItem.Put = operation
+	case "PATCH":
+		pathItem.Patch = operation
+	case "DELETE":
+		pathItem.Delete = operation
+	case "Any":
+		pathItem.Get = operation
+		pathItem.Post = operation
+	}
+
+	return p
+}
+
+// 添加数据结构
+func (p *Document) AddSchema(name string, schema *Schema) *Document {
+	p.Components.Schemas[name] = schema
+
+	return p
+}
+
+// 添加标签
+func (p *Document) AddTag(name string, description string) *Document {
+	for _, v := range p.Tags {
+		if v.Name == name {
+			return p
+		}
+	}
+	p.Tags = append(p.Tags, &Tag{Name: name, Description: description})
+
+	return p
+}
+
+// 引用数据结构
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// JSON请求体
+func JSONBody(schema *Schema) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content: map[string]*MediaType{
+			"application/json": {Schema: schema},
+		},
+	}
+}
+
+// JSON响应
+func JSONResponse(description string, schema *Schema) *Response {
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			"application/json": {Schema: schema},
+		},
+	}
+}
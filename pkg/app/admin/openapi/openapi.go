@@ -0,0 +1,437 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+// 输出OpenAPI文档，需挂载在后台中间件保护的路由下，例如：b.GET("/api/admin/openapi.json", openapi.Handle)
+func Handle(ctx *builder.Context) error {
+	return ctx.JSON(200, Generate(ctx))
+}
+
+// 根据注册的服务生成OpenAPI文档
+func Generate(ctx *builder.Context) *Document {
+	doc := NewDocument(builder.AppName, builder.Version)
+
+	for _, provider := range ctx.Engine.GetProviders() {
+		templateCtx := *ctx
+		templateCtx.Template = provider
+
+		// 模版参数初始化
+		provider.(interface {
+			TemplateInit(ctx *builder.Context) interface{}
+		}).TemplateInit(&templateCtx)
+
+		// 实例初始化
+		provider.(interface {
+			Init(ctx *builder.Context) interface{}
+		}).Init(&templateCtx)
+
+		// 初始化路由
+		provider.(interface {
+			RouteInit() interface{}
+		}).RouteInit()
+
+		// 加载自定义路由
+		provider.(interface {
+			Route() interface{}
+		}).Route()
+
+		if template, ok := provider.(types.Resourcer); ok {
+			resourceParser(&templateCtx, doc, template)
+		} else {
+			templateParser(doc, provider)
+		}
+	}
+
+	return doc
+}
+
+// 获取服务名称
+func providerName(provider interface{}) string {
+	names := strings.Split(reflect.TypeOf(provider).String(), ".")
+	structName := names[len(names)-1]
+
+	return strings.ToLower(string(structName[0])) + structName[1:]
+}
+
+// 获取Schema名称
+func schemaName(provider interface{}, suffix string) string {
+	names := strings.Split(reflect.TypeOf(provider).String(), ".")
+	packageName := strings.TrimLeft(names[0], "*")
+
+	return strings.ToUpper(string(packageName[0])) + packageName[1:] + names[len(names)-1] + suffix
+}
+
+// 将路由中的参数转换为OpenAPI格式
+func convertPath(path string, name string) string {
+	path = strings.Replace(path, ":resource", name, -1)
+	items := strings.Split(path, "/")
+	for k, v := range items {
+		if strings.HasPrefix(v, ":") {
+			items[k] = "{" + strings.TrimPrefix(v, ":") + "}"
+		}
+	}
+
+	return strings.Join(items, "/")
+}
+
+// 解析路由中的路径参数
+func pathParameters(path string) []*Parameter {
+	var parameters []*Parameter
+	for _, v := range strings.Split(path, "/") {
+		if strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}") {
+			parameters = append(parameters, &Parameter{
+				Name:     strings.Trim(v, "{}"),
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	return parameters
+}
+
+// 解析普通模板
+func templateParser(doc *Document, provider interface{}) {
+	name := providerName(provider)
+	tag := schemaName(provider, "")
+	doc.AddTag(tag, "")
+
+	routes := provider.(interface {
+		GetRouteMapping() []*builder.RouteMapping
+	}).GetRouteMapping()
+
+	for _, route := range routes {
+		path := convertPath(route.Path, name)
+		doc.AddOperation(route.Method, path, &Operation{
+			Tags:       []string{tag},
+			Summary:    path,
+			Parameters: pathParameters(path),
+			Responses:  defaultResponses(),
+		})
+	}
+}
+
+// 解析资源模板
+func resourceParser(ctx *builder.Context, doc *Document, template types.Resourcer) {
+	name := providerName(template)
+	tag := schemaName(template, "")
+	title := template.GetTitle()
+	doc.AddTag(tag, title)
+
+	// 列表数据结构
+	indexSchema := schemaName(template, "Index")
+	doc.AddSchema(indexSchema, FieldsToSchema(title, template.IndexFields(ctx), nil))
+
+	// 详情数据结构
+	detailSchema := schemaName(template, "Detail")
+	doc.AddSchema(detailSchema, FieldsToSchema(title, template.DetailFields(ctx), nil))
+
+	// 创建数据结构
+	var creationRules, updateRules = rulesForCreation(ctx, template), rulesForUpdate(ctx, template)
+	creationSchema := schemaName(template, "Creation")
+	doc.AddSchema(creationSchema, FieldsToSchema(title, template.CreationFieldsWithoutWhen(ctx), creationRules))
+
+	// 更新数据结构
+	updateSchema := schemaName(template, "Update")
+	updateSchemaValue := FieldsToSchema(title, template.UpdateFieldsWithoutWhen(ctx), updateRules)
+	if _, ok := updateSchemaValue.Properties["id"]; !ok {
+		updateSchemaValue.Properties["id"] = &Schema{Type: "integer"}
+	}
+	if !hasRequired(updateSchemaValue.Required, "id") {
+		updateSchemaValue.Required = append(updateSchemaValue.Required, "id")
+	}
+	doc.AddSchema(updateSchema, updateSchemaValue)
+
+	routes := template.GetRouteMapping()
+	for _, route := range routes {
+		operation := &Operation{
+			Tags:      []string{tag},
+			Responses: defaultResponses(),
+		}
+
+		switch route.Path {
+		case resource.IndexPath:
+			operation.Summary = title + "列表"
+			operation.Parameters = indexParameters()
+			operation.Responses["200"] = JSONResponse("列表页组件", componentSchema(indexSchema))
+		case resource.CreatePath:
+			operation.Summary = "创建" + title + "页面"
+		case resource.StorePath:
+			operation.Summary = "创建" + title
+			operation.RequestBody = JSONBody(Ref(creationSchema))
+		case resource.EditPath:
+			operation.Summary = "编辑" + title + "页面"
+			operation.Parameters = []*Parameter{idParameter()}
+		case resource.EditValuesPath:
+			operation.Summary = "获取" + title + "编辑表单值"
+			operation.Parameters = []*Parameter{idParameter()}
+			operation.Responses["200"] = JSONResponse("表单值", messageSchema(Ref(updateSchema)))
+		case resource.SavePath:
+			operation.Summary = "保存" + title
+			operation.RequestBody = JSONBody(Ref(updateSchema))
+		case resource.DetailPath:
+			operation.Summary = title + "详情"
+			operation.Parameters = []*Parameter{idParameter()}
+			operation.Responses["200"] = JSONResponse("详情页组件", componentSchema(detailSchema))
+		case resource.EditablePath:
+			operation.Summary = "表格行内编辑" + title
+			operation.Parameters = []*Parameter{idParameter()}
+		case resource.ExportPath:
+			operation.Summary = "导出" + title
+			operation.Parameters = indexParameters()
+			operation.Responses["200"] = &Response{
+				Description: "Excel文件",
+				Content: map[string]*MediaType{
+					"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}},
+				},
+			}
+		case resource.ImportPath:
+			operation.Summary = "导入" + title
+			operation.RequestBody = JSONBody(&Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"fileId": {
+						Type: "array",
+						Items: &Schema{
+							Type: "object",
+							Properties: map[string]*Schema{
+								"id":   {Type: "integer"},
+								"name": {Type: "string"},
+								"size": {Type: "integer"},
+							},
+						},
+					},
+				},
+				Required: []string{"fileId"},
+			})
+		case resource.ImportTemplatePath:
+			operation.Summary = title + "导入模板"
+		case resource.ActionPath, resource.ActionValuesPath:
+			actionParser(ctx, doc, template, route, tag, name)
+			continue
+		case resource.RestIndexPath, resource.RestDetailPath, resource.RestActionPath:
+			if !template.GetWithRestApi() {
+				continue
+			}
+			if route.Path == resource.RestActionPath {
+				actionParser(ctx, doc, template, route, tag, name)
+				continue
+			}
+			restParser(operation, route, title, indexSchema, detailSchema, creationSchema, updateSchema)
+		}
+
+		path := convertPath(route.Path, name)
+		operation.Parameters = append(pathParameters(path), operation.Parameters...)
+		if operation.Summary == "" {
+			operation.Summary = path
+		}
+		doc.AddOperation(route.Method, path, operation)
+	}
+}
+
+// 解析REST接口
+func restParser(operation *Operation, route *builder.RouteMapping, title string, indexSchema string, detailSchema string, creationSchema string, updateSchema string) {
+	switch route.Method {
+	case "GET":
+		if route.Path == resource.RestIndexPath {
+			operation.Summary = title + "列表"
+			operation.Parameters = append([]*Parameter{
+				{Name: "page", In: "query", Description: "当前页码", Schema: &Schema{Type: "integer"}},
+				{Name: "pageSize", In: "query", Description: "每页数量", Schema: &Schema{Type: "integer"}},
+			}, indexParameters()...)
+			operation.Responses["200"] = JSONResponse("列表数据", restSchema(&Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"page":     {Type: "integer"},
+					"pageSize": {Type: "integer"},
+					"total":    {Type: "integer"},
+					"items":    {Type: "array", Items: Ref(indexSchema)},
+				},
+			}))
+		} else {
+			operation.Summary = title + "详情"
+			operation.Responses["200"] = JSONResponse("详情数据", restSchema(Ref(detailSchema)))
+			operation.Responses["404"] = &Response{Description: "记录不存在"}
+		}
+	case "POST":
+		operation.Summary = "创建" + title
+		operation.RequestBody = JSONBody(Ref(creationSchema))
+		operation.Responses["201"] = JSONResponse("创建的数据", restSchema(Ref(detailSchema)))
+		operation.Responses["422"] = JSONResponse("验证失败", restSchema(&Schema{}))
+	case "PATCH":
+		operation.Summary = "更新" + title
+		operation.RequestBody = JSONBody(Ref(updateSchema))
+		operation.Responses["200"] = JSONResponse("更新后的数据", restSchema(Ref(detailSchema)))
+		operation.Responses["404"] = &Response{Description: "记录不存在"}
+		operation.Responses["422"] = JSONResponse("验证失败", restSchema(&Schema{}))
+	case "DELETE":
+		operation.Summary = "删除" + title
+		operation.Responses["204"] = &Response{Description: "删除成功"}
+		operation.Responses["404"] = &Response{Description: "记录不存在"}
+	}
+}
+
+// 解析行为
+func actionParser(ctx *builder.Context, doc *Document, template types.Resourcer, route *builder.RouteMapping, tag string, name string) {
+	for _, v := range template.Actions(ctx) {
+		actionInstance, ok := v.(types.Actioner)
+		if !ok {
+			continue
+		}
+
+		// 初始化模版
+		actionInstance.TemplateInit(ctx)
+
+		// 初始化
+		actionInstance.Init(ctx)
+
+		items := []interface{}{v}
+		if actionInstance.GetActionType() == "dropdown" {
+			items = v.(types.Dropdowner).GetActions()
+		}
+
+		for _, item := range items {
+			action, ok := item.(types.Actioner)
+			if !ok {
+				continue
+			}
+
+			// 只有实现Handle方法的行为才具有接口
+			if !hasMethod(item, "Handle") {
+				continue
+			}
+
+			uriKey := action.GetUriKey(item)
+			path := convertPath(strings.Replace(route.Path, ":uriKey", uriKey, -1), name)
+			summary := action.GetName()
+			if summary == "" {
+				summary = uriKey
+			}
+			if route.Path == resource.ActionValuesPath {
+				summary = summary + "表单值"
+			}
+
+			doc.AddOperation(route.Method, path, &Operation{
+				Tags:       []string{tag},
+				Summary:    summary,
+				Parameters: append(pathParameters(path), idParameter()),
+				Responses:  defaultResponses(),
+			})
+		}
+	}
+}
+
+// 判断实例上是否存在方法
+func hasMethod(item interface{}, name string) bool {
+	return reflect.ValueOf(item).MethodByName(name).IsValid()
+}
+
+// 获取创建请求验证规则
+func rulesForCreation(ctx *builder.Context, template interface{}) []*rule.Rule {
+	if v, ok := template.(interface {
+		RulesForCreation(ctx *builder.Context) []*rule.Rule
+	}); ok {
+		return v.RulesForCreation(ctx)
+	}
+
+	return nil
+}
+
+// 获取更新请求验证规则
+func rulesForUpdate(ctx *builder.Context, template interface{}) []*rule.Rule {
+	if v, ok := template.(interface {
+		RulesForUpdate(ctx *builder.Context) []*rule.Rule
+	}); ok {
+		return v.RulesForUpdate(ctx)
+	}
+
+	return nil
+}
+
+// ID参数
+func idParameter() *Parameter {
+	return &Parameter{
+		Name:        "id",
+		In:          "query",
+		Description: "记录ID，多个ID用英文逗号分隔",
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+// 列表查询参数
+func indexParameters() []*Parameter {
+	return []*Parameter{
+		{
+			Name:        "search",
+			In:          "query",
+			Description: "搜索条件JSON字符串，包含current、pageSize以及搜索项",
+			Schema:      &Schema{Type: "string"},
+		},
+		{
+			Name:        "filter",
+			In:          "query",
+			Description: "表格列筛选JSON字符串，例如：{\"status\":[1]}",
+			Schema:      &Schema{Type: "string"},
+		},
+		{
+			Name:        "sorter",
+			In:          "query",
+			Description: "排序JSON字符串，例如：{\"id\":\"descend\"}",
+			Schema:      &Schema{Type: "string"},
+		},
+	}
+}
+
+// 消息组件结构
+func messageSchema(data *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"component": {Type: "string"},
+			"type":      {Type: "string", Enum: []interface{}{"success", "error"}},
+			"content":   {Type: "string"},
+			"url":       {Type: "string"},
+			"data":      data,
+		},
+	}
+}
+
+// REST响应结构
+func restSchema(data *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code": {Type: "integer"},
+			"msg":  {Type: "string"},
+			"data": data,
+		},
+	}
+}
+
+// 页面组件结构
+func componentSchema(name string) *Schema {
+	return &Schema{
+		Type:                 "object",
+		Description:          "页面组件树，数据结构参见：" + Ref(name).Ref,
+		Properties:           map[string]*Schema{"component": {Type: "string"}},
+		AdditionalProperties: true,
+	}
+}
+
+// 默认响应
+func defaultResponses() map[string]*Response {
+	return map[string]*Response{
+		"200": JSONResponse("成功", messageSchema(&Schema{})),
+		"401": {Description: "未登录"},
+		"403": {Description: "无权限"},
+	}
+}
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+)
+
+// 根据字段列表生成数据结构
+func FieldsToSchema(title string, fields interface{}, rules []*rule.Rule) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Title:      title,
+		Properties: map[string]*Schema{},
+	}
+
+	getFields, ok := fields.([]interface{})
+	if !ok {
+		return schema
+	}
+
+	for _, field := range getFields {
+		name, property := FieldToSchema(field)
+		if name == "" {
+			continue
+		}
+		schema.Properties[name] = property
+	}
+
+	// 将验证规则写入数据结构
+	for _, v := range rules {
+		property, ok := schema.Properties[v.Name]
+		if !ok {
+			continue
+		}
+		applyRule(property, v)
+		if v.RuleType == "required" && !hasRequired(schema.Required, v.Name) {
+			schema.Required = append(schema.Required, v.Name)
+		}
+	}
+
+	return schema
+}
+
+// 根据字段生成数据结构
+func FieldToSchema(field interface{}) (string, *Schema) {
+	value := reflect.ValueOf(field)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return "", nil
+	}
+	elem := value.Elem()
+
+	// 字段名
+	name := stringField(elem, "Name")
+
+	// 字段标签
+	label := stringField(elem, "Label")
+
+	// 组件名称
+	component := stringField(elem, "Component")
+
+	// 组件模式
+	mode := stringField(elem, "Mode")
+
+	schema := &Schema{Title: label}
+	switch component {
+	case "idField", "inputNumberField":
+		schema.Type = "number"
+		if component == "idField" {
+			schema.Type = "integer"
+		}
+	case "switchField":
+		schema.Type = "boolean"
+	case "dateField":
+		schema.Type = "string"
+		schema.Format = "date"
+	case "datetimeField":
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case "dateRangeField", "datetimeRangeField", "timeRangeField":
+		schema.Type = "array"
+		schema.Items = &Schema{Type: "string"}
+	case "checkboxField", "treeField", "transferField", "cascaderField", "listField":
+		schema.Type = "array"
+		schema.Items = &Schema{}
+	case "selectField":
+		if mode == "multiple" || mode == "tags" {
+			schema.Type = "array"
+			schema.Items = &Schema{}
+		}
+	case "imageField", "fileField":
+		schema.Type = "object"
+		schema.Properties = map[string]*Schema{
+			"id":   {Type: "integer"},
+			"name": {Type: "string"},
+			"url":  {Type: "string"},
+			"size": {Type: "integer"},
+		}
+		if mode == "multiple" || component == "fileField" {
+			schema.Type = "array"
+			schema.Items = &Schema{Type: "object", Properties: schema.Properties}
+			schema.Properties = nil
+		}
+	case "geofenceField", "mapField", "fieldsetField":
+		schema.Type = "object"
+	default:
+		schema.Type = "string"
+	}
+
+	// 可选项转为枚举
+	options := elem.FieldByName("Options")
+	if options.IsValid() && options.Kind() == reflect.Slice && options.Len() > 0 {
+		enum := []interface{}{}
+		for i := 0; i < options.Len(); i++ {
+			option := options.Index(i)
+			if option.Kind() == reflect.Ptr {
+				option = option.Elem()
+			}
+			if option.Kind() != reflect.Struct {
+				continue
+			}
+			optionValue := option.FieldByName("Value")
+			if optionValue.IsValid() && optionValue.CanInterface() {
+				enum = append(enum, optionValue.Interface())
+			}
+		}
+		if schema.Type == "array" {
+			schema.Items.Enum = enum
+		} else if schema.Type == "string" {
+			schema.Type = ""
+			schema.Enum = enum
+		}
+	}
+
+	// 默认值
+	defaultValue := elem.FieldByName("DefaultValue")
+	if defaultValue.IsValid() && defaultValue.CanInterface() && !defaultValue.IsZero() {
+		schema.Default = defaultValue.Interface()
+	}
+
+	return name, schema
+}
+
+// 将验证规则写入数据结构
+func applyRule(schema *Schema, v *rule.Rule) {
+	switch v.RuleType {
+	case "min":
+		min := v.Min
+		schema.MinLength = &min
+	case "max":
+		max := v.Max
+		schema.MaxLength = &max
+	case "regexp":
+		schema.Pattern = strings.TrimSuffix(strings.TrimPrefix(v.Pattern, "/"), "/")
+	case "email":
+		schema.Format = "email"
+	case "url":
+		schema.Format = "uri"
+	case "integer":
+		schema.Type = "integer"
+	case "number", "float":
+		schema.Type = "number"
+	case "boolean":
+		schema.Type = "boolean"
+	}
+
+	if v.Message != "" && schema.Description == "" {
+		schema.Description = v.Message
+	}
+}
+
+// 判断是否已经为必填
+func hasRequired(required []string, name string) bool {
+	for _, v := range required {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 获取字符串属性
+func stringField(elem reflect.Value, name string) string {
+	field := elem.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
@@ -15,7 +15,7 @@ type BatchDeleteAction struct {
 func BatchDelete(options ...interface{}) *BatchDeleteAction {
 	action := &BatchDeleteAction{}
 
-	action.Name = "批量删除"
+	action.Name = "action.batch_delete"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -36,7 +36,7 @@ func (p *BatchDeleteAction) Init(ctx *builder.Context) interface{} {
 	p.Reload = "table"
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要删除吗？", "删除后数据将无法恢复，请谨慎操作！", "modal")
+	p.WithConfirm("action.confirm.delete.title", "action.confirm.delete.text", "modal")
 
 	// 在表格多选弹出层展示
 	p.SetOnlyOnIndexTableAlert(true)
@@ -55,8 +55,8 @@ func (p *BatchDeleteAction) GetApiParams() []string {
 func (p *BatchDeleteAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
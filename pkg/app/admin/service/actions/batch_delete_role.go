@@ -24,7 +24,7 @@ func BatchDeleteRole() *BatchDeleteRoleAction {
 func (p *BatchDeleteRoleAction) Init(ctx *builder.Context) interface{} {
 
 	// 设置按钮文字
-	p.Name = "批量删除"
+	p.Name = "action.batch_delete"
 
 	// 设置按钮类型,primary | ghost | dashed | link | text | default
 	p.Type = "link"
@@ -36,7 +36,7 @@ func (p *BatchDeleteRoleAction) Init(ctx *builder.Context) interface{} {
 	p.Reload = "table"
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要删除吗？", "删除后数据将无法恢复，请谨慎操作！", "modal")
+	p.WithConfirm("action.confirm.delete.title", "action.confirm.delete.text", "modal")
 
 	// 在表格多选弹出层展示
 	p.SetOnlyOnIndexTableAlert(true)
@@ -55,12 +55,12 @@ func (p *BatchDeleteRoleAction) GetApiParams() []string {
 func (p *BatchDeleteRoleAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	id := ctx.Query("id")
 	if id == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	ids := strings.Split(id.(string), ",")
@@ -68,7 +68,7 @@ func (p *BatchDeleteRoleAction) Handle(ctx *builder.Context, query *gorm.DB) err
 		for _, v := range ids {
 			idInt, err := strconv.Atoi(v)
 			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
+				return ctx.JSON(200, message.Error(ctx.TError(err)))
 			}
 
 			// 清理casbin里的角色
@@ -77,12 +77,12 @@ func (p *BatchDeleteRoleAction) Handle(ctx *builder.Context, query *gorm.DB) err
 	} else {
 		idInt, err := strconv.Atoi(id.(string))
 		if err != nil {
-			return ctx.JSON(200, message.Error(err.Error()))
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
 		}
 
 		// 清理casbin里的角色
 		(&model.CasbinRule{}).RemoveRoleMenuAndPermissions(idInt)
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
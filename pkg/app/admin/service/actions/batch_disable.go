@@ -15,7 +15,7 @@ type BatchDisableAction struct {
 func BatchDisable(options ...interface{}) *BatchDisableAction {
 	action := &BatchDisableAction{}
 
-	action.Name = "批量禁用"
+	action.Name = "action.batch_disable"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -39,7 +39,7 @@ func (p *BatchDisableAction) Init(ctx *builder.Context) interface{} {
 	p.SetOnlyOnIndexTableAlert(true)
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要禁用吗？", "禁用后数据将无法使用，请谨慎操作！", "modal")
+	p.WithConfirm("action.confirm.disable.title", "action.confirm.disable.text", "modal")
 
 	return p
 }
@@ -55,8 +55,8 @@ func (p *BatchDisableAction) GetApiParams() []string {
 func (p *BatchDisableAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Update("status", 0).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
@@ -15,7 +15,7 @@ type BatchEnableAction struct {
 func BatchEnable(options ...interface{}) *BatchEnableAction {
 	action := &BatchEnableAction{}
 
-	action.Name = "批量启用"
+	action.Name = "action.batch_enable"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -39,7 +39,7 @@ func (p *BatchEnableAction) Init(ctx *builder.Context) interface{} {
 	p.SetOnlyOnIndexTableAlert(true)
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要启用吗？", "启用后数据将正常使用！", "modal")
+	p.WithConfirm("action.confirm.enable.title", "action.confirm.enable.text", "modal")
 
 	return p
 }
@@ -55,8 +55,8 @@ func (p *BatchEnableAction) GetApiParams() []string {
 func (p *BatchEnableAction) Handle(ctx *builder.Context, model *gorm.DB) error {
 	err := model.Update("status", 1).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
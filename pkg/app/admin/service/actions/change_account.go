@@ -23,7 +23,11 @@ func ChangeAccount() *ChangeAccountAction {
 // 执行行为句柄
 func (p *ChangeAccountAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	data := map[string]interface{}{}
-	ctx.Bind(&data)
+	err := ctx.Bind(&data)
+	if err != nil {
+		ctx.Logger().Warn("bind account request failed", "error", err)
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
 
 	if data["avatar"] != "" && data["avatar"] != nil {
 		data["avatar"], _ = json.Marshal(data["avatar"])
@@ -37,13 +41,13 @@ func (p *ChangeAccountAction) Handle(ctx *builder.Context, query *gorm.DB) error
 	// 获取登录管理员信息
 	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	err = query.Where("id", adminInfo.Id).Updates(data).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
@@ -16,7 +16,7 @@ func ChangeStatus(options ...interface{}) *ChangeStatusAction {
 	action := &ChangeStatusAction{}
 
 	// 行为名称，当行为在表格行展示时，支持js表达式
-	action.Name = "<%= (status==1 ? '禁用' : '启用') %>"
+	action.Name = "action.change_status"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -40,7 +40,7 @@ func (p *ChangeStatusAction) Init(ctx *builder.Context) interface{} {
 	p.SetOnlyOnIndexTableRow(true)
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要<%= (status==1 ? '禁用' : '启用') %>数据吗？", "", "pop")
+	p.WithConfirm("action.confirm.change_status.title", "", "pop")
 
 	return p
 }
@@ -57,7 +57,7 @@ func (p *ChangeStatusAction) GetApiParams() []string {
 func (p *ChangeStatusAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	status := ctx.Query("status")
 	if status == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	var fieldStatus int
@@ -69,8 +69,8 @@ func (p *ChangeStatusAction) Handle(ctx *builder.Context, query *gorm.DB) error
 
 	err := query.Update("status", fieldStatus).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
@@ -28,7 +28,7 @@ func (p *ChangeWebConfigAction) Handle(ctx *builder.Context, query *gorm.DB) err
 
 	for k, v := range data {
 		config := map[string]interface{}{}
-		db.Client.Model(&model.Config{}).Where("name =?", k).First(&config)
+		db.Client.WithContext(ctx.Context()).Model(&model.Config{}).Where("name =?", k).First(&config)
 		if getValue, ok := v.([]interface{}); ok {
 			v, _ = json.Marshal(getValue)
 		}
@@ -38,19 +38,19 @@ func (p *ChangeWebConfigAction) Handle(ctx *builder.Context, query *gorm.DB) err
 		if getValue, ok := v.(map[string]interface{}); ok {
 			v, _ = json.Marshal(getValue)
 		}
-		updateResult := db.Client.Model(&model.Config{}).Where("name", k).Update("value", v)
+		updateResult := db.Client.WithContext(ctx.Context()).Model(&model.Config{}).Where("name", k).Update("value", v)
 		if updateResult.Error != nil {
 			result = false
 		}
 	}
 
 	if !result {
-		return ctx.JSON(200, message.Error("操作失败，请重试！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.failed_retry")))
 	}
 
 	// 刷新网站配置
 	(&model.Config{}).Refresh()
 
 	// 返回成功
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
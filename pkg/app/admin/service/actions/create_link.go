@@ -22,7 +22,7 @@ func (p *CreateLinkAction) Init(ctx *builder.Context) interface{} {
 	template := ctx.Template.(types.Resourcer)
 
 	// 文字
-	p.Name = "创建" + template.GetTitle()
+	p.Name = ctx.T("action.create", template.GetTitle())
 
 	// 类型
 	p.Type = "primary"
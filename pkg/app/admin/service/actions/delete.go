@@ -15,7 +15,7 @@ type DeleteAction struct {
 func Delete(options ...interface{}) *DeleteAction {
 	action := &DeleteAction{}
 
-	action.Name = "删除"
+	action.Name = "action.delete"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -36,7 +36,7 @@ func (p *DeleteAction) Init(ctx *builder.Context) interface{} {
 	p.Reload = "table"
 
 	// 当行为在表格行展示时，支持js表达式
-	p.WithConfirm("确定要删除吗？", "删除后数据将无法恢复，请谨慎操作！", "modal")
+	p.WithConfirm("action.confirm.delete.title", "action.confirm.delete.text", "modal")
 
 	// 在表格行内展示
 	p.SetOnlyOnIndexTableRow(true)
@@ -53,8 +53,8 @@ func (p *DeleteAction) Init(ctx *builder.Context) interface{} {
 func (p *DeleteAction) Handle(ctx *builder.Context, query *gorm.DB) error {
 	err := query.Delete("").Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
@@ -0,0 +1,142 @@
+package actions
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+)
+
+type DeleteFileAction struct {
+	actions.Action
+	FileType string
+}
+
+type BatchDeleteFileAction struct {
+	actions.Action
+	FileType string
+}
+
+// 删除文件，正在使用的文件不会被删除，fileType为model.FileReferenceFile或model.FileReferencePicture，DeleteFile(model.FileReferenceFile) | DeleteFile(model.FileReferenceFile, "删除")
+func DeleteFile(fileType string, options ...interface{}) *DeleteFileAction {
+	action := &DeleteFileAction{FileType: fileType}
+
+	action.Name = "action.delete"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 批量删除文件，正在使用的文件不会被删除，BatchDeleteFile(model.FileReferenceFile) | BatchDeleteFile(model.FileReferenceFile, "批量删除")
+func BatchDeleteFile(fileType string, options ...interface{}) *BatchDeleteFileAction {
+	action := &BatchDeleteFileAction{FileType: fileType}
+
+	action.Name = "action.batch_delete"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *DeleteFileAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	//  执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 当行为在表格行展示时，支持js表达式
+	p.WithConfirm("action.confirm.delete.title", "action.confirm.delete.text", "modal")
+
+	// 在表格行内展示
+	p.SetOnlyOnIndexTableRow(true)
+
+	// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+	p.SetApiParams([]string{
+		"id",
+	})
+
+	return p
+}
+
+// 执行行为句柄
+func (p *DeleteFileAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	return deleteFiles(ctx, query, p.FileType)
+}
+
+// 初始化
+func (p *BatchDeleteFileAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	//  执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 当行为在表格行展示时，支持js表达式
+	p.WithConfirm("action.confirm.delete.title", "action.confirm.delete.text", "modal")
+
+	// 在表格多选弹出层展示
+	p.SetOnlyOnIndexTableAlert(true)
+
+	return p
+}
+
+// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+func (p *BatchDeleteFileAction) GetApiParams() []string {
+	return []string{
+		"id",
+	}
+}
+
+// 执行行为句柄
+func (p *BatchDeleteFileAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	return deleteFiles(ctx, query, p.FileType)
+}
+
+// 删除没有被引用的文件记录，存储中的文件由垃圾回收清理
+func deleteFiles(ctx *builder.Context, query *gorm.DB, fileType string) error {
+	ids := []int{}
+	err := query.Pluck("id", &ids).Error
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	deleteIds := []int{}
+	for _, id := range ids {
+		if (&model.FileReference{}).CountByFile(fileType, id) == 0 {
+			deleteIds = append(deleteIds, id)
+		}
+	}
+
+	if len(deleteIds) > 0 {
+		var deleteModel interface{} = &model.File{}
+		if fileType == model.FileReferencePicture {
+			deleteModel = &model.Picture{}
+		}
+
+		err = db.Client.Where("id IN ?", deleteIds).Delete(deleteModel).Error
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+	}
+
+	if skipped := len(ids) - len(deleteIds); skipped > 0 {
+		return ctx.JSON(200, message.Error(ctx.T("file.delete_referenced", len(deleteIds), skipped)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
@@ -0,0 +1,75 @@
+package actions
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type DeleteFolderAction struct {
+	actions.Action
+	FileType string
+}
+
+// 删除文件夹，同时删除子文件夹，文件夹中的文件移动到根目录，DeleteFolder(model.FileReferenceFile) | DeleteFolder(model.FileReferenceFile, "删除")
+func DeleteFolder(fileType string, options ...interface{}) *DeleteFolderAction {
+	action := &DeleteFolderAction{FileType: fileType}
+
+	action.Name = "action.delete"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *DeleteFolderAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	//  执行成功后刷新的组件
+	p.Reload = "table"
+
+	// 当行为在表格行展示时，支持js表达式
+	p.WithConfirm("action.confirm.delete.title", "file.folder_delete_confirm", "modal")
+
+	// 在表格行内展示
+	p.SetOnlyOnIndexTableRow(true)
+
+	// 行为接口接收的参数，当行为在表格行展示的时候，可以配置当前行的任意字段
+	p.SetApiParams([]string{
+		"id",
+	})
+
+	return p
+}
+
+// 执行行为句柄
+func (p *DeleteFolderAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	ids := []int{}
+	err := query.Pluck("id", &ids).Error
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	for _, id := range ids {
+		switch p.FileType {
+		case model.FileReferencePicture:
+			err = (&model.PictureCategory{}).DeleteWithChildren(id)
+		default:
+			err = (&model.FileCategory{}).DeleteWithChildren(id)
+		}
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
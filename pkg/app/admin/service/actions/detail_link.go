@@ -16,7 +16,7 @@ func DetailLink(options ...interface{}) *DetailLinkAction {
 	action := &DetailLinkAction{}
 
 	// 文字
-	action.Name = "详情"
+	action.Name = "action.detail"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -17,7 +17,7 @@ func EditDrawer(options ...interface{}) *EditDrawerAction {
 	action := &EditDrawerAction{}
 
 	// 文字
-	action.Name = "编辑"
+	action.Name = "action.edit"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -80,12 +80,12 @@ func (p *EditDrawerAction) GetActions(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		(&action.Component{}).
 			Init().
-			SetLabel("取消").
+			SetLabel(ctx.T("action.cancel")).
 			SetActionType("cancel"),
 
 		(&action.Component{}).
 			Init().
-			SetLabel("提交").
+			SetLabel(ctx.T("action.submit")).
 			SetWithLoading(true).
 			SetReload("table").
 			SetActionType("submit").
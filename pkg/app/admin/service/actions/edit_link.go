@@ -16,7 +16,7 @@ func EditLink(options ...interface{}) *EditLinkAction {
 	action := &EditLinkAction{}
 
 	// 文字
-	action.Name = "编辑"
+	action.Name = "action.edit"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -14,7 +14,7 @@ func FormExtraBack(options ...interface{}) *FormExtraBackAction {
 	action := &FormExtraBackAction{}
 
 	// 文字
-	action.Name = "返回上一页"
+	action.Name = "action.back"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -14,7 +14,7 @@ func FormReset(options ...interface{}) *FormResetAction {
 	action := &FormResetAction{}
 
 	// 文字
-	action.Name = "重置"
+	action.Name = "action.reset"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
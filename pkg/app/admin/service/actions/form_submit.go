@@ -14,7 +14,7 @@ func FormSubmit(options ...interface{}) *FormSubmitAction {
 	action := &FormSubmitAction{}
 
 	// 文字
-	action.Name = "提交"
+	action.Name = "action.submit"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -19,7 +19,7 @@ func Import(options ...interface{}) *ImportAction {
 	action := &ImportAction{}
 
 	// 文字
-	action.Name = "导入数据"
+	action.Name = "action.import"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -44,7 +44,7 @@ func (p *ImportAction) GetBody(ctx *builder.Context) interface{} {
 	api := "/api/admin/" + ctx.Param("resource") + "/import"
 	getTpl := (&tpl.Component{}).
 		Init().
-		SetBody("模板文件: <a href='/api/admin/" + ctx.Param("resource") + "/import/template?token=" + ctx.Token() + "' target='_blank'>下载模板</a>").
+		SetBody(ctx.T("import.template_file") + ": <a href='/api/admin/" + ctx.Param("resource") + "/import/template?token=" + ctx.Token() + "' target='_blank'>" + ctx.T("import.download_template") + "</a>").
 		SetStyle(map[string]interface{}{
 			"marginLeft": "50px",
 		})
@@ -59,13 +59,13 @@ func (p *ImportAction) GetBody(ctx *builder.Context) interface{} {
 				"marginBottom": "20px",
 			}),
 		(&resource.Field{}).
-			File("fileId", "导入文件").
+			File("fileId", ctx.T("import.file")).
 			SetLimitNum(1).
 			SetLimitType([]string{
 				"application/vnd.ms-excel",
 				"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
 			}).
-			SetHelp("请上传xls格式的文件"),
+			SetHelp(ctx.T("import.file_help")),
 	}
 
 	return (&form.Component{}).
@@ -87,12 +87,12 @@ func (p *ImportAction) GetActions(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		(&action.Component{}).
 			Init().
-			SetLabel("取消").
+			SetLabel(ctx.T("action.cancel")).
 			SetActionType("cancel"),
 
 		(&action.Component{}).
 			Init().
-			SetLabel("提交").
+			SetLabel(ctx.T("action.submit")).
 			SetWithLoading(true).
 			SetReload("table").
 			SetActionType("submit").
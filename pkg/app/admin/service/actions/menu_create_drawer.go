@@ -22,7 +22,7 @@ func (p *MenuCreateDrawerAction) Init(ctx *builder.Context) interface{} {
 	template := ctx.Template.(types.Resourcer)
 
 	// 文字
-	p.Name = "创建" + template.GetTitle()
+	p.Name = ctx.T("action.create", template.GetTitle())
 
 	// 类型
 	p.Type = "primary"
@@ -74,12 +74,12 @@ func (p *MenuCreateDrawerAction) GetActions(ctx *builder.Context) []interface{}
 	return []interface{}{
 		(&action.Component{}).
 			Init().
-			SetLabel("取消").
+			SetLabel(ctx.T("action.cancel")).
 			SetActionType("cancel"),
 
 		(&action.Component{}).
 			Init().
-			SetLabel("提交").
+			SetLabel(ctx.T("action.submit")).
 			SetWithLoading(true).
 			SetReload("table").
 			SetActionType("submit").
@@ -13,7 +13,7 @@ type MoreAction struct {
 func More(options ...interface{}) *MoreAction {
 	moreAction := &MoreAction{}
 
-	moreAction.Name = "更多"
+	moreAction.Name = "action.more"
 	if len(options) == 1 {
 		moreAction.Name = options[0].(string)
 	}
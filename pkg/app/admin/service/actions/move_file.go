@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"encoding/json"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type MoveFileAction struct {
+	actions.ModalForm
+	FileType string
+}
+
+// 批量移动文件到文件夹，fileType为model.FileReferenceFile或model.FileReferencePicture，MoveFile(model.FileReferenceFile) | MoveFile(model.FileReferenceFile, "移动")
+func MoveFile(fileType string, options ...interface{}) *MoveFileAction {
+	action := &MoveFileAction{FileType: fileType}
+
+	action.Name = "action.move"
+	if len(options) == 1 {
+		action.Name = options[0].(string)
+	}
+
+	return action
+}
+
+// 初始化
+func (p *MoveFileAction) Init(ctx *builder.Context) interface{} {
+
+	// 设置按钮类型,primary | ghost | dashed | link | text | default
+	p.Type = "link"
+
+	// 设置按钮大小,large | middle | small | default
+	p.Size = "small"
+
+	// 关闭时销毁 Modal 里的子元素
+	p.DestroyOnClose = true
+
+	// 在表格多选弹出层展示
+	p.SetOnlyOnIndexTableAlert(true)
+
+	// 行为接口接收的参数
+	p.SetApiParams([]string{
+		"id",
+	})
+
+	return p
+}
+
+// 分类字段
+func (p *MoveFileAction) categoryColumn() string {
+	if p.FileType == model.FileReferencePicture {
+		return "picture_category_id"
+	}
+
+	return "file_category_id"
+}
+
+// 字段
+func (p *MoveFileAction) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	var folders []*treeselect.TreeData
+	if p.FileType == model.FileReferencePicture {
+		folders, _ = (&model.PictureCategory{}).TreeSelect(true)
+	} else {
+		folders, _ = (&model.FileCategory{}).TreeSelect(true)
+	}
+
+	return []interface{}{
+		field.TreeSelect(p.categoryColumn(), ctx.T("file.folder")).
+			SetData(folders).
+			SetDefault(0),
+	}
+}
+
+// 执行行为句柄
+func (p *MoveFileAction) Handle(ctx *builder.Context, query *gorm.DB) error {
+	data := map[string]interface{}{}
+	json.Unmarshal(ctx.Body(), &data)
+
+	categoryId := 0
+	if value, ok := data[p.categoryColumn()].(float64); ok {
+		categoryId = int(value)
+	}
+
+	// 文件夹需要存在
+	if categoryId != 0 {
+		var err error
+		if p.FileType == model.FileReferencePicture {
+			_, err = (&model.PictureCategory{}).GetInfoById(categoryId)
+		} else {
+			_, err = (&model.FileCategory{}).GetInfoById(categoryId)
+		}
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.T("file.folder_invalid")))
+		}
+	}
+
+	err := query.Update(p.categoryColumn(), categoryId).Error
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
@@ -13,5 +13,5 @@ type SelectOptionsAction struct {
 
 // 执行行为句柄
 func (p *SelectOptionsAction) Handle(ctx *builder.Context, query *gorm.DB) error {
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
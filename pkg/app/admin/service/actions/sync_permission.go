@@ -6,6 +6,7 @@ import (
 	"github.com/gobeam/stringy"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
@@ -21,7 +22,7 @@ func SyncPermission(options ...interface{}) *SyncPermissionAction {
 	action := &SyncPermissionAction{}
 
 	// 文字
-	action.Name = "同步权限"
+	action.Name = "action.sync_permission"
 	if len(options) == 1 {
 		action.Name = options[0].(string)
 	}
@@ -54,12 +55,14 @@ func (p *SyncPermissionAction) Handle(ctx *builder.Context, query *gorm.DB) erro
 
 	var names []string
 	var currentNames []string
-	db.Client.Model(&model.Permission{}).Pluck("name", &names)
+	db.Client.WithContext(ctx.Context()).Model(&model.Permission{}).Pluck("name", &names)
 	for _, v := range permissions {
-		if strings.Contains(v.Url, "/api/admin") {
+		if strings.Contains(v.Url, "/api/admin") || strings.HasPrefix(v.Url, resource.RestPathPrefix) {
 			has := false
 			hasPermission := false
 			url := strings.ReplaceAll(v.Url, "/api/admin/", "")
+			url = strings.ReplaceAll(url, "/api/", "")
+			url = strings.ReplaceAll(url, ":", "")
 			url = strings.ReplaceAll(url, "/", "_") + "_" + strings.ToLower(v.Method)
 			name := stringy.
 				New(url).
@@ -92,13 +95,13 @@ func (p *SyncPermissionAction) Handle(ctx *builder.Context, query *gorm.DB) erro
 		}
 	}
 	if len(data) == 0 {
-		return ctx.JSON(200, message.Error("暂无新增权限！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.no_new_permission")))
 	}
 
 	err := query.Create(data).Error
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
@@ -26,5 +26,9 @@ func (p *Index) Cards(ctx *builder.Context) []interface{} {
 		&metrics.TotalFile{},
 		&metrics.SystemInfo{},
 		&metrics.TeamInfo{},
+		&metrics.StorageUsage{},
+		&metrics.LogTrend{},
+		&metrics.FileExtPartition{},
+		&metrics.StorageProgress{},
 	}
 }
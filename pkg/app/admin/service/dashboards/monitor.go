@@ -0,0 +1,30 @@
+package dashboards
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type Monitor struct {
+	dashboard.Template
+}
+
+// 初始化
+func (p *Monitor) Init(ctx *builder.Context) interface{} {
+	p.Title = "系统监控"
+
+	return p
+}
+
+// 内容
+func (p *Monitor) Cards(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		&metrics.ServerStatus{},
+		&metrics.ProcessStatus{},
+		&metrics.RequestStatus{Stats: ctx.Engine.GetRequestStats()},
+		&metrics.RequestLatency{Stats: ctx.Engine.GetRequestStats()},
+		&metrics.DatabaseStatus{},
+		&metrics.RedisStatus{},
+	}
+}
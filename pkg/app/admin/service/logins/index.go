@@ -9,6 +9,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/login"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
 	"gorm.io/gorm"
@@ -39,7 +40,7 @@ func (p *Index) Init(ctx *builder.Context) interface{} {
 	p.Title = "QuarkGo"
 
 	// 登录页面子标题
-	p.SubTitle = "信息丰富的世界里，唯一稀缺的就是人类的注意力"
+	p.SubTitle = ctx.T("login.subtitle")
 
 	// 登录后跳转地址
 	p.Redirect = "/layout/index?api=/api/admin/dashboard/index/index"
@@ -60,18 +61,18 @@ func (p *Index) Fields(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		field.Text("username").
 			SetRules([]*rule.Rule{
-				rule.Required(true, "请输入用户名"),
+				rule.Required(true, ctx.T("login.username_required")),
 			}).
-			SetPlaceholder("用户名").
+			SetPlaceholder(ctx.T("login.username")).
 			SetWidth("100%").
 			SetSize("large").
 			SetPrefix(icon.New().SetType("icon-user")),
 
 		field.Password("password").
 			SetRules([]*rule.Rule{
-				rule.Required(true, "请输入密码"),
+				rule.Required(true, ctx.T("login.password_required")),
 			}).
-			SetPlaceholder("密码").
+			SetPlaceholder(ctx.T("login.password")).
 			SetWidth("100%").
 			SetSize("large").
 			SetPrefix(icon.New().SetType("icon-lock")),
@@ -80,9 +81,9 @@ func (p *Index) Fields(ctx *builder.Context) []interface{} {
 			SetCaptchaIdUrl(captchaIdUrl).
 			SetCaptchaUrl(captchaUrl).
 			SetRules([]*rule.Rule{
-				rule.Required(true, "请输入验证码"),
+				rule.Required(true, ctx.T("login.captcha_placeholder_required")),
 			}).
-			SetPlaceholder("验证码").
+			SetPlaceholder(ctx.T("login.captcha")).
 			SetWidth("100%").
 			SetSize("large").
 			SetPrefix(icon.New().SetType("icon-safetycertificate")),
@@ -91,35 +92,40 @@ func (p *Index) Fields(ctx *builder.Context) []interface{} {
 
 // 登录方法
 func (p *Index) Handle(ctx *builder.Context) error {
+	result := telemetry.LoginFailure
+	defer func() {
+		telemetry.IncLogin("admin", result)
+	}()
+
 	loginRequest := &LoginRequest{}
 	if err := ctx.Bind(loginRequest); err != nil {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 	if loginRequest.Captcha.Id == "" || loginRequest.Captcha.Value == "" {
-		return ctx.JSON(200, message.Error("验证码不能为空"))
+		return ctx.JSON(200, message.Error(ctx.T("login.captcha_empty")))
 	}
 
 	verifyResult := captcha.VerifyString(loginRequest.Captcha.Id, loginRequest.Captcha.Value)
 	if !verifyResult {
-		return ctx.JSON(200, message.Error("验证码错误"))
+		return ctx.JSON(200, message.Error(ctx.T("login.captcha_error")))
 	}
 	captcha.Reload(loginRequest.Captcha.Id)
 
 	if loginRequest.Username == "" || loginRequest.Password == "" {
-		return ctx.JSON(200, message.Error("用户名或密码不能为空"))
+		return ctx.JSON(200, message.Error(ctx.T("login.credentials_empty")))
 	}
 
 	adminInfo, err := (&model.Admin{}).GetInfoByUsername(loginRequest.Username)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return ctx.JSON(200, message.Error("用户不存在"))
+			return ctx.JSON(200, message.Error(ctx.T("login.user_not_found")))
 		}
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
 	// 检验账号和密码
 	if !hash.Check(adminInfo.Password, loginRequest.Password) {
-		return ctx.JSON(200, message.Error("用户名或密码错误"))
+		return ctx.JSON(200, message.Error(ctx.T("login.credentials_error")))
 	}
 
 	// 更新登录信息
@@ -131,7 +137,9 @@ func (p *Index) Handle(ctx *builder.Context) error {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
-	return ctx.JSON(200, message.Success("登录成功", "", map[string]string{
+	result = telemetry.LoginSuccess
+
+	return ctx.JSON(200, message.Success(ctx.T("login.success"), "", map[string]string{
 		"token": tokenString,
 	}))
 }
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type DatabaseStatus struct {
+	metrics.Descriptions
+}
+
+// 初始化
+func (p *DatabaseStatus) Init() *DatabaseStatus {
+	p.Title = "数据库连接池"
+	p.Col = 12
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 计算数值
+func (p *DatabaseStatus) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	sqlDB, err := db.Client.DB()
+	if err != nil {
+		return p.Result([]interface{}{
+			field.Text("状态").SetValue(err.Error()),
+		})
+	}
+
+	status := "正常"
+	if err := sqlDB.Ping(); err != nil {
+		status = err.Error()
+	}
+
+	maxOpen := "不限制"
+	stats := sqlDB.Stats()
+	if stats.MaxOpenConnections > 0 {
+		maxOpen = strconv.Itoa(stats.MaxOpenConnections)
+	}
+
+	return p.Result([]interface{}{
+		field.Text("数据库").SetValue(db.Client.Dialector.Name()),
+		field.Text("状态").SetValue(status),
+		field.Text("最大连接数").SetValue(maxOpen),
+		field.Text("当前连接数").SetValue(stats.OpenConnections),
+		field.Text("使用中").SetValue(stats.InUse),
+		field.Text("空闲").SetValue(stats.Idle),
+		field.Text("等待次数").SetValue(strconv.FormatInt(stats.WaitCount, 10)),
+		field.Text("等待总时长").SetValue(stats.WaitDuration.String()),
+		field.Text("因空闲关闭").SetValue(strconv.FormatInt(stats.MaxIdleClosed+stats.MaxIdleTimeClosed, 10)),
+		field.Text("因超时关闭").SetValue(strconv.FormatInt(stats.MaxLifetimeClosed, 10)),
+	})
+}
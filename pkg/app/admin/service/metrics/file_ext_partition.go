@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type FileExtPartition struct {
+	metrics.Partition
+}
+
+// 初始化
+func (p *FileExtPartition) Init() *FileExtPartition {
+	p.Title = "上传文件类型"
+	p.Col = 6
+	p.Ranges = metrics.DefaultRanges
+	p.CacheTTL = time.Minute
+	p.DateColumn = "created_at"
+	p.Limit = 5
+
+	return p
+}
+
+// 计算数值
+func (p *FileExtPartition) Calculate() (*space.Component, error) {
+
+	return p.
+		Init().
+		Count(db.Client.Model(&model.File{}).Where("status = ?", 1), "ext")
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type LogTrend struct {
+	metrics.Trend
+}
+
+// 初始化
+func (p *LogTrend) Init() *LogTrend {
+	p.Title = "日志趋势"
+	p.Col = 12
+	p.Ranges = metrics.DefaultRanges
+	p.CacheTTL = time.Minute
+
+	return p
+}
+
+// 计算数值
+func (p *LogTrend) Calculate() (*space.Component, error) {
+
+	return p.
+		Init().
+		ByDays().
+		Count(db.Client.Model(&model.ActionLog{}), "created_at")
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/shirou/gopsutil/process"
+)
+
+type ProcessStatus struct {
+	metrics.Descriptions
+}
+
+// 初始化
+func (p *ProcessStatus) Init() *ProcessStatus {
+	p.Title = "进程状态"
+	p.Col = 12
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 计算数值
+func (p *ProcessStatus) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	items := []interface{}{
+		field.Text("进程ID").SetValue(os.Getpid()),
+	}
+
+	rssText, uptimeText := "-", "-"
+	if proc, err := process.NewProcess(int32(os.Getpid())); err == nil {
+		if memory, err := proc.MemoryInfo(); err == nil {
+			rssText = file.FormatSize(int64(memory.RSS))
+		}
+		if createTime, err := proc.CreateTime(); err == nil {
+			uptimeText = time.Since(time.UnixMilli(createTime)).Round(time.Second).String()
+		}
+	}
+
+	stats := &runtime.MemStats{}
+	runtime.ReadMemStats(stats)
+
+	lastGC := "-"
+	lastPause := time.Duration(0)
+	if stats.NumGC > 0 {
+		lastGC = time.Unix(0, int64(stats.LastGC)).Format("2006-01-02 15:04:05")
+		lastPause = time.Duration(stats.PauseNs[(stats.NumGC+255)%256])
+	}
+
+	return p.Result(append(items,
+		field.Text("运行时间").SetValue(uptimeText),
+		field.Text("常驻内存(RSS)").SetValue(rssText),
+		field.Text("Goroutine数量").SetValue(runtime.NumGoroutine()),
+		field.Text("堆内存").SetValue(file.FormatSize(int64(stats.HeapAlloc))+" / "+file.FormatSize(int64(stats.HeapSys))),
+		field.Text("下次GC阈值").SetValue(file.FormatSize(int64(stats.NextGC))),
+		field.Text("GC次数").SetValue(strconv.FormatUint(uint64(stats.NumGC), 10)),
+		field.Text("最近GC").SetValue(lastGC+"（暂停"+lastPause.String()+"）"),
+		field.Text("GC总暂停").SetValue(time.Duration(stats.PauseTotalNs).String()),
+	))
+}
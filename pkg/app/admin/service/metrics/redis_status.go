@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
+)
+
+type RedisStatus struct {
+	metrics.Descriptions
+}
+
+// 初始化
+func (p *RedisStatus) Init() *RedisStatus {
+	p.Title = "Redis状态"
+	p.Col = 12
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 解析INFO命令的结果
+func (p *RedisStatus) parseInfo(content string) map[string]string {
+	info := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			info[key] = value
+		}
+	}
+
+	return info
+}
+
+// 缓存命中率
+func (p *RedisStatus) hitRate(info map[string]string) string {
+	hits, _ := strconv.ParseFloat(info["keyspace_hits"], 64)
+	misses, _ := strconv.ParseFloat(info["keyspace_misses"], 64)
+	if hits+misses == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f%%", hits/(hits+misses)*100)
+}
+
+// 默认值
+func (p *RedisStatus) value(info map[string]string, key string) string {
+	if value, ok := info[key]; ok && value != "" {
+		return value
+	}
+
+	return "-"
+}
+
+// 计算数值
+func (p *RedisStatus) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	if redisclient.Client == nil {
+		return p.Result([]interface{}{
+			field.Text("状态").SetValue("未启用"),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	content, err := redisclient.Client.Info(ctx, "server", "clients", "memory", "stats").Result()
+	if err != nil {
+		return p.Result([]interface{}{
+			field.Text("状态").SetValue(err.Error()),
+		})
+	}
+	info := p.parseInfo(content)
+	keys, _ := redisclient.Client.DBSize(ctx).Result()
+
+	maxMemory := p.value(info, "maxmemory_human")
+	if info["maxmemory"] == "0" {
+		maxMemory = "不限制"
+	}
+
+	return p.Result([]interface{}{
+		field.Text("版本").SetValue(p.value(info, "redis_version")),
+		field.Text("运行时间").SetValue(p.value(info, "uptime_in_days") + "天"),
+		field.Text("客户端连接数").SetValue(p.value(info, "connected_clients")),
+		field.Text("内存").SetValue(p.value(info, "used_memory_human") + " / " + maxMemory),
+		field.Text("内存峰值").SetValue(p.value(info, "used_memory_peak_human")),
+		field.Text("内存碎片率").SetValue(p.value(info, "mem_fragmentation_ratio")),
+		field.Text("当前Key数量").SetValue(strconv.FormatInt(keys, 10)),
+		field.Text("每秒操作数").SetValue(p.value(info, "instantaneous_ops_per_sec")),
+		field.Text("命中率").SetValue(p.hitRate(info)),
+		field.Text("过期/淘汰Key数").SetValue(p.value(info, "expired_keys") + " / " + p.value(info, "evicted_keys")),
+	})
+}
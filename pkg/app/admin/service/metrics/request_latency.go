@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/statistic"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type RequestLatency struct {
+	metrics.Metrics
+	Stats *builder.RequestStats // 当前应用的请求统计
+}
+
+// 初始化
+func (p *RequestLatency) Init() *RequestLatency {
+	p.Title = "请求耗时分布"
+	p.Col = 12
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 耗时区间名称，例如：≤100ms、>10000ms
+func (p *RequestLatency) bucketLabel(buckets []builder.RequestDurationBucket, index int) string {
+	if buckets[index].Le == 0 {
+		if index == 0 {
+			return "全部"
+		}
+		return ">" + strconv.FormatFloat(buckets[index-1].Le, 'f', -1, 64) + "ms"
+	}
+
+	return "≤" + strconv.FormatFloat(buckets[index].Le, 'f', -1, 64) + "ms"
+}
+
+// 计算数值
+func (p *RequestLatency) Calculate() *space.Component {
+	p.Init()
+
+	data := []map[string]interface{}{}
+	average := time.Duration(0)
+	if p.Stats != nil {
+		snapshot := p.Stats.Snapshot()
+		for k, v := range snapshot.Buckets {
+			data = append(data, map[string]interface{}{
+				"duration": p.bucketLabel(snapshot.Buckets, k),
+				"count":    v.Count,
+			})
+		}
+		average = snapshot.Average()
+	}
+
+	return (&space.Component{}).
+		Init().
+		SetDirection("vertical").
+		SetStyle(map[string]interface{}{"width": "100%"}).
+		SetBody([]interface{}{
+			(&statistic.Component{}).
+				Init().
+				SetTitle(p.Title).
+				SetValue(float64(average.Microseconds()) / 1000).
+				SetPrecision(2).
+				SetSuffix("ms（平均）"),
+			chart.NewColumn(data).
+				SetXField("duration").
+				SetYField("count").
+				SetHeight(200).
+				SetAutoFit(true),
+		})
+}
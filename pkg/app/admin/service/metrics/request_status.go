@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type RequestStatus struct {
+	metrics.Descriptions
+	Stats *builder.RequestStats // 当前应用的请求统计
+}
+
+// 初始化
+func (p *RequestStatus) Init() *RequestStatus {
+	p.Title = "请求统计"
+	p.Col = 12
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 计算数值
+func (p *RequestStatus) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	if p.Stats == nil {
+		return p.Result([]interface{}{})
+	}
+	snapshot := p.Stats.Snapshot()
+
+	items := []interface{}{
+		field.Text("开始统计").SetValue(snapshot.StartedAt.Format("2006-01-02 15:04:05")),
+		field.Text("请求总数").SetValue(strconv.FormatUint(snapshot.Total, 10)),
+		field.Text("吞吐量(最近1分钟)").SetValue(fmt.Sprintf("%.2f 次/秒", snapshot.Rate)),
+		field.Text("处理中").SetValue(strconv.FormatInt(snapshot.InFlight, 10)),
+		field.Text("平均耗时").SetValue(snapshot.Average().Round(time.Microsecond).String()),
+		field.Text("最大耗时").SetValue(snapshot.DurationMax.Round(time.Microsecond).String()),
+	}
+	for _, status := range []string{"2xx", "3xx", "4xx", "5xx"} {
+		items = append(items, field.Text(status).SetValue(strconv.FormatUint(snapshot.Statuses[status], 10)))
+	}
+
+	return p.Result(items)
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+)
+
+type ServerStatus struct {
+	metrics.Descriptions
+}
+
+// 初始化
+func (p *ServerStatus) Init() *ServerStatus {
+	p.Title = "服务器状态"
+	p.Col = 12
+
+	// 获取CPU使用率需要等待1秒，异步加载并定时刷新
+	p.Lazy = true
+	p.CacheTTL = 5 * time.Second
+	p.RefreshInterval = 10
+
+	return p
+}
+
+// 使用量，例如：1.5GB / 10GB (15%)
+func formatUsage(used uint64, total uint64, percent float64) string {
+	return file.FormatSize(int64(used)) + " / " + file.FormatSize(int64(total)) + fmt.Sprintf(" (%.1f%%)", percent)
+}
+
+// 计算数值
+func (p *ServerStatus) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	items := []interface{}{}
+
+	if info, err := host.Info(); err == nil {
+		items = append(items,
+			field.Text("主机名").SetValue(info.Hostname),
+			field.Text("操作系统").SetValue(info.Platform+" "+info.PlatformVersion+" "+runtime.GOARCH),
+			field.Text("运行时间").SetValue((time.Duration(info.Uptime) * time.Second).String()),
+		)
+	}
+
+	cpuText := "-"
+	if cpuPercent, err := cpu.Percent(time.Second, false); err == nil && len(cpuPercent) > 0 {
+		cpuText = fmt.Sprintf("%.1f%%", cpuPercent[0])
+	}
+	items = append(items, field.Text("CPU使用率").SetValue(fmt.Sprintf("%s（%d核）", cpuText, runtime.NumCPU())))
+
+	// Windows不支持平均负载
+	loadText := "-"
+	if avg, err := load.Avg(); err == nil {
+		loadText = fmt.Sprintf("%.2f / %.2f / %.2f", avg.Load1, avg.Load5, avg.Load15)
+	}
+	items = append(items, field.Text("平均负载(1/5/15分钟)").SetValue(loadText))
+
+	memoryText := "-"
+	if memory, err := mem.VirtualMemory(); err == nil {
+		memoryText = formatUsage(memory.Used, memory.Total, memory.UsedPercent)
+	}
+	items = append(items, field.Text("内存").SetValue(memoryText))
+
+	// 应用运行目录所在磁盘
+	diskText := "-"
+	if dir, err := os.Getwd(); err == nil {
+		if usage, err := disk.Usage(dir); err == nil {
+			diskText = formatUsage(usage.Used, usage.Total, usage.UsedPercent)
+		}
+	}
+	items = append(items, field.Text("磁盘").SetValue(diskText))
+
+	return p.Result(items)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+)
+
+type StorageProgress struct {
+	metrics.Progress
+}
+
+// 初始化
+func (p *StorageProgress) Init() *StorageProgress {
+	p.Title = "存储空间(MB)"
+	p.Col = 6
+	p.Precision = 2
+
+	return p
+}
+
+// 计算数值，目标值为全局存储配额，未设置配额时只展示用量
+func (p *StorageProgress) Calculate() (*space.Component, error) {
+	quota := &model.StorageQuota{}
+	usage, err := quota.GetTotalUsage()
+	if err != nil {
+		return nil, err
+	}
+	p.Init().Target = float64(quota.GetTotalLimit()) / (1 << 20)
+
+	return p.Result(float64(usage)/(1<<20), 0), nil
+}
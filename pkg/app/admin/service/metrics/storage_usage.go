@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+)
+
+type StorageUsage struct {
+	metrics.Descriptions
+	Top int // 展示用量最多的所属者数量
+}
+
+// 初始化
+func (p *StorageUsage) Init() *StorageUsage {
+	p.Title = "存储用量"
+	p.Col = 12
+
+	if p.Top == 0 {
+		p.Top = 5
+	}
+
+	return p
+}
+
+// 所属类型名称
+func (p *StorageUsage) typeName(objType string) string {
+	switch objType {
+	case "ADMINID":
+		return "管理员"
+	case "UID":
+		return "用户"
+	}
+
+	return objType
+}
+
+// 所属者名称
+func (p *StorageUsage) objName(objType string, objId int) string {
+	if objType == "ADMINID" {
+		admin, err := (&model.Admin{}).GetInfoById(objId)
+		if err == nil {
+			return admin.Username
+		}
+	}
+
+	return p.typeName(objType) + "#" + strconv.Itoa(objId)
+}
+
+// 用量及配额，例如：1.5GB / 10GB
+func (p *StorageUsage) usageText(usage int64, count int64, limit int64) string {
+	text := file.FormatSize(usage)
+	if limit > 0 {
+		text = text + " / " + file.FormatSize(limit)
+	}
+
+	return text + "（" + strconv.FormatInt(count, 10) + "个文件）"
+}
+
+// 计算数值
+func (p *StorageUsage) Calculate() *descriptions.Component {
+	p.Init()
+
+	field := &descriptions.Field{}
+	quota := &model.StorageQuota{}
+
+	typeUsages, _ := quota.GetUsageList(false)
+	total, count := int64(0), int64(0)
+	for _, v := range typeUsages {
+		total += v.Size
+		count += v.Count
+	}
+
+	items := []interface{}{
+		field.Text("总用量").SetValue(p.usageText(total, count, quota.GetTotalLimit())),
+	}
+	for _, v := range typeUsages {
+		items = append(items, field.Text(p.typeName(v.ObjType)).SetValue(p.usageText(v.Size, v.Count, 0)))
+	}
+
+	objUsages, _ := quota.GetUsageList(true)
+	for key, v := range objUsages {
+		if key >= p.Top {
+			break
+		}
+		items = append(items, field.Text(p.objName(v.ObjType, v.ObjId)).SetValue(p.usageText(v.Size, v.Count, quota.GetLimit(v.ObjType, v.ObjId))))
+	}
+
+	return p.Result(items)
+}
@@ -22,6 +22,11 @@ func (p *SystemInfo) Init() *SystemInfo {
 	p.Title = "系统信息"
 	p.Col = 12
 
+	// 获取CPU使用率需要等待1秒，异步加载并定时刷新
+	p.Lazy = true
+	p.CacheTTL = 10 * time.Second
+	p.RefreshInterval = 30
+
 	return p
 }
 
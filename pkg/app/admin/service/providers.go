@@ -13,6 +13,7 @@ var Providers = []interface{}{
 	&logins.Index{},
 	&layouts.Index{},
 	&dashboards.Index{},
+	&dashboards.Monitor{},
 	&resources.User{},
 	&resources.Admin{},
 	&resources.Role{},
@@ -22,6 +23,8 @@ var Providers = []interface{}{
 	&resources.Config{},
 	&resources.File{},
 	&resources.Picture{},
+	&resources.FileCategory{},
+	&resources.PictureCategory{},
 	&resources.WebConfig{},
 	&resources.Account{},
 	&uploads.File{},
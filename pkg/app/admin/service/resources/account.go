@@ -2,12 +2,14 @@ package resources
 
 import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/radio"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 )
 
 type Account struct {
@@ -45,6 +47,15 @@ func (p *Account) FormApi(ctx *builder.Context) string {
 func (p *Account) Fields(ctx *builder.Context) []interface{} {
 	field := &resource.Field{}
 
+	// 语言选项
+	localeOptions := []*selectfield.Option{}
+	for _, v := range i18n.Locales() {
+		localeOptions = append(localeOptions, &selectfield.Option{
+			Value: v,
+			Label: i18n.T(v, "locale.name"),
+		})
+	}
+
 	return []interface{}{
 
 		field.Image("avatar", "头像").OnlyOnForms(),
@@ -90,6 +101,11 @@ func (p *Account) Fields(ctx *builder.Context) []interface{} {
 			}).
 			SetDefault(1),
 
+		field.Select("locale", ctx.T("account.locale")).
+			SetOptions(localeOptions).
+			SetHelp(ctx.T("account.locale_help")).
+			SetAllowClear(true),
+
 		field.Password("password", "密码").
 			SetCreationRules([]*rule.Rule{
 				rule.New().SetRequired().SetMessage("密码必须填写"),
@@ -113,7 +129,7 @@ func (p *Account) Actions(ctx *builder.Context) []interface{} {
 func (p *Account) BeforeCreating(ctx *builder.Context) map[string]interface{} {
 	data := map[string]interface{}{}
 	adminInfo, _ := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
-	db.Client.
+	db.Client.WithContext(ctx.Context()).
 		Model(p.Model).
 		Where("id = ?", adminInfo.Id).
 		First(&data)
@@ -1,17 +1,48 @@
 package resources
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/convert"
 )
 
 type File struct {
 	resource.Template
 }
 
+// 扫描状态选项
+func scanStatusOptions() []*selectfield.Option {
+	return []*selectfield.Option{
+		{Label: "通过", Value: storage.ScanClean},
+		{Label: "已隔离", Value: storage.ScanInfected},
+		{Label: "扫描出错", Value: storage.ScanError},
+	}
+}
+
+// 文件的使用位置，例如：admins#1.avatar
+func fileUsageText(fileType string, id interface{}) string {
+	fileId, _ := strconv.Atoi(convert.AnyToString(id))
+	references, _ := (&model.FileReference{}).GetListByFile(fileType, fileId)
+	if len(references) == 0 {
+		return "-"
+	}
+
+	usages := []string{}
+	for _, v := range references {
+		usages = append(usages, v.RefType+"#"+strconv.Itoa(v.RefId)+"."+v.Field)
+	}
+
+	return strings.Join(usages, ", ")
+}
+
 // 初始化
 func (p *File) Init(ctx *builder.Context) interface{} {
 
@@ -31,11 +62,27 @@ func (p *File) Init(ctx *builder.Context) interface{} {
 func (p *File) Fields(ctx *builder.Context) []interface{} {
 	field := &resource.Field{}
 
+	// 文件夹名称
+	folders := (&model.FileCategory{}).GetTitleMap()
+
 	return []interface{}{
 		field.ID("id", "ID"),
-		field.Text("name", "名称"),
+		field.Text("name", "名称").SetEditable(true),
+		field.Text("file_category_id", ctx.T("file.folder"), func() interface{} {
+			categoryId, _ := strconv.Atoi(convert.AnyToString(p.Field["file_category_id"]))
+			if title, ok := folders[categoryId]; ok {
+				return title
+			}
+
+			return ctx.T("file.folder_root")
+		}),
 		field.Text("size", "大小").SetSorter(true),
 		field.Text("ext", "扩展名"),
+		field.Text("usage", "使用位置", func() interface{} {
+			return fileUsageText(model.FileReferenceFile, p.Field["id"])
+		}),
+		field.Select("scan_status", "扫描状态").SetOptions(scanStatusOptions()),
+		field.Text("scan_result", "扫描结果"),
 		field.Datetime("created_at", "上传时间"),
 	}
 }
@@ -44,6 +91,10 @@ func (p *File) Fields(ctx *builder.Context) []interface{} {
 func (p *File) Searches(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		searches.Input("name", "名称"),
+		searches.Folder("file_category_id", ctx.T("file.folder"), model.FileReferenceFile),
+		searches.Select("ext", "扩展名", (&model.File{}).GetExtOptions()),
+		searches.FileSize("size", "大小"),
+		searches.Select("scan_status", "扫描状态", scanStatusOptions()),
 		searches.DatetimeRange("created_at", "上传时间"),
 	}
 }
@@ -51,7 +102,8 @@ func (p *File) Searches(ctx *builder.Context) []interface{} {
 // 行为
 func (p *File) Actions(ctx *builder.Context) []interface{} {
 	return []interface{}{
-		actions.BatchDelete(),
-		actions.Delete(),
+		actions.MoveFile(model.FileReferenceFile),
+		actions.BatchDeleteFile(model.FileReferenceFile),
+		actions.DeleteFile(model.FileReferenceFile),
 	}
 }
@@ -0,0 +1,145 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/rule"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/convert"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/lister"
+)
+
+type FileCategory struct {
+	resource.Template
+}
+
+// 初始化
+func (p *FileCategory) Init(ctx *builder.Context) interface{} {
+
+	// 标题
+	p.Title = "文件夹"
+
+	// 模型
+	p.Model = &model.FileCategory{}
+
+	// 分页
+	p.PerPage = false
+
+	// 默认排序
+	p.QueryOrder = "sort asc,id asc"
+
+	return p
+}
+
+// 字段
+func (p *FileCategory) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	// 文件夹列表
+	folders, _ := (&model.FileCategory{}).TreeSelect(true)
+
+	return folderFields(field, folders)
+}
+
+// 搜索
+func (p *FileCategory) Searches(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		searches.Input("title", "名称"),
+	}
+}
+
+// 行为
+func (p *FileCategory) Actions(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		actions.CreateModal(),
+		actions.EditModal(),
+		actions.DeleteFolder(model.FileReferenceFile),
+	}
+}
+
+// 列表页面显示前回调
+func (p *FileCategory) BeforeIndexShowing(ctx *builder.Context, list []map[string]interface{}) []interface{} {
+	return folderTree(ctx, list)
+}
+
+// 保存数据前回调
+func (p *FileCategory) BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error) {
+	return beforeFolderSaving(ctx, submitData, (&model.FileCategory{}).GetDescendantIds)
+}
+
+// 文件夹的表单字段
+func folderFields(field *resource.Field, folders []*treeselect.TreeData) []interface{} {
+	return []interface{}{
+		field.Hidden("id", "ID"), // 列表读取且不展示的字段
+
+		field.Hidden("pid", "PID").OnlyOnIndex(), // 列表读取且不展示的字段
+
+		field.Text("title", "名称").
+			SetRules([]*rule.Rule{
+				rule.Required(true, "名称必须填写"),
+				rule.Max(255, "名称不能超过255个字符"),
+			}),
+
+		field.TreeSelect("pid", "上级文件夹").
+			SetData(folders).
+			SetDefault(0).
+			OnlyOnForms(),
+
+		field.Number("sort", "排序").
+			SetEditable(true).
+			SetDefault(0),
+
+		field.TextArea("description", "描述").
+			SetRules([]*rule.Rule{
+				rule.Max(255, "描述不能超过255个字符"),
+			}),
+	}
+}
+
+// 文件夹转换成树形表格，搜索时直接返回列表
+func folderTree(ctx *builder.Context, list []map[string]interface{}) []interface{} {
+	data := ctx.AllQuerys()
+	if search, ok := data["search"].(map[string]interface{}); ok && search != nil {
+		result := []interface{}{}
+		for _, v := range list {
+			result = append(result, v)
+		}
+
+		return result
+	}
+
+	tree, _ := lister.ListToTree(list, "id", "pid", "children", 0)
+
+	return tree
+}
+
+// 保存文件夹前回调，创建时归属当前管理员，上级文件夹不能是自身或子文件夹
+func beforeFolderSaving(ctx *builder.Context, submitData map[string]interface{}, descendantIds func(id int) []int) (map[string]interface{}, error) {
+	id, _ := strconv.Atoi(convert.AnyToString(submitData["id"]))
+	if id == 0 {
+		adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+		if err != nil {
+			return submitData, err
+		}
+
+		submitData["obj_type"] = "ADMINID"
+		submitData["obj_id"] = adminInfo.Id
+
+		return submitData, nil
+	}
+
+	pid, _ := strconv.Atoi(convert.AnyToString(submitData["pid"]))
+	for _, v := range descendantIds(id) {
+		if v == pid {
+			return submitData, i18n.NewError("file.folder_cycle")
+		}
+	}
+
+	return submitData, nil
+}
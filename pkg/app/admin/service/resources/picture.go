@@ -1,11 +1,14 @@
 package resources
 
 import (
+	"strconv"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/convert"
 )
 
 type Picture struct {
@@ -31,17 +34,31 @@ func (p *Picture) Init(ctx *builder.Context) interface{} {
 func (p *Picture) Fields(ctx *builder.Context) []interface{} {
 	field := &resource.Field{}
 
+	// 文件夹名称
+	folders := (&model.PictureCategory{}).GetTitleMap()
+
 	return []interface{}{
 		field.ID("id", "ID"),
 		field.Text("path", "显示", func() interface{} {
 
 			return "<img src='" + (&model.Picture{}).GetPath(p.Field["id"]) + "' width=50 height=50 />"
 		}),
-		field.Text("name", "名称").SetEllipsis(true),
+		field.Text("name", "名称").SetEllipsis(true).SetEditable(true),
+		field.Text("picture_category_id", ctx.T("file.folder"), func() interface{} {
+			categoryId, _ := strconv.Atoi(convert.AnyToString(p.Field["picture_category_id"]))
+			if title, ok := folders[categoryId]; ok {
+				return title
+			}
+
+			return ctx.T("file.folder_root")
+		}),
 		field.Text("size", "大小").SetSorter(true),
 		field.Text("width", "宽度"),
 		field.Text("height", "高度"),
 		field.Text("ext", "扩展名"),
+		field.Text("usage", "使用位置", func() interface{} {
+			return fileUsageText(model.FileReferencePicture, p.Field["id"])
+		}),
 		field.Datetime("created_at", "上传时间"),
 	}
 }
@@ -50,6 +67,9 @@ func (p *Picture) Fields(ctx *builder.Context) []interface{} {
 func (p *Picture) Searches(ctx *builder.Context) []interface{} {
 	return []interface{}{
 		searches.Input("name", "名称"),
+		searches.Folder("picture_category_id", ctx.T("file.folder"), model.FileReferencePicture),
+		searches.Select("ext", "扩展名", (&model.Picture{}).GetExtOptions()),
+		searches.FileSize("size", "大小"),
 		searches.DatetimeRange("created_at", "上传时间"),
 	}
 }
@@ -57,7 +77,8 @@ func (p *Picture) Searches(ctx *builder.Context) []interface{} {
 // 行为
 func (p *Picture) Actions(ctx *builder.Context) []interface{} {
 	return []interface{}{
-		actions.BatchDelete(),
-		actions.Delete(),
+		actions.MoveFile(model.FileReferencePicture),
+		actions.BatchDeleteFile(model.FileReferencePicture),
+		actions.DeleteFile(model.FileReferencePicture),
 	}
 }
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/actions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/service/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+type PictureCategory struct {
+	resource.Template
+}
+
+// 初始化
+func (p *PictureCategory) Init(ctx *builder.Context) interface{} {
+
+	// 标题
+	p.Title = "图片文件夹"
+
+	// 模型
+	p.Model = &model.PictureCategory{}
+
+	// 分页
+	p.PerPage = false
+
+	// 默认排序
+	p.QueryOrder = "sort asc,id asc"
+
+	return p
+}
+
+// 字段
+func (p *PictureCategory) Fields(ctx *builder.Context) []interface{} {
+	field := &resource.Field{}
+
+	// 文件夹列表
+	folders, _ := (&model.PictureCategory{}).TreeSelect(true)
+
+	return folderFields(field, folders)
+}
+
+// 搜索
+func (p *PictureCategory) Searches(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		searches.Input("title", "名称"),
+	}
+}
+
+// 行为
+func (p *PictureCategory) Actions(ctx *builder.Context) []interface{} {
+	return []interface{}{
+		actions.CreateModal(),
+		actions.EditModal(),
+		actions.DeleteFolder(model.FileReferencePicture),
+	}
+}
+
+// 列表页面显示前回调
+func (p *PictureCategory) BeforeIndexShowing(ctx *builder.Context, list []map[string]interface{}) []interface{} {
+	return folderTree(ctx, list)
+}
+
+// 保存数据前回调
+func (p *PictureCategory) BeforeSaving(ctx *builder.Context, submitData map[string]interface{}) (map[string]interface{}, error) {
+	return beforeFolderSaving(ctx, submitData, (&model.PictureCategory{}).GetDescendantIds)
+}
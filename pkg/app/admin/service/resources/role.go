@@ -49,6 +49,11 @@ func (p *Role) Fields(ctx *builder.Context) []interface{} {
 		field.Text("guard_name", "GuardName").
 			SetDefault("admin"),
 
+		field.Number("storage_quota", ctx.T("role.storage_quota")).
+			SetHelp(ctx.T("role.storage_quota_help")).
+			SetDefault(0).
+			OnlyOnForms(),
+
 		field.Tree("menu_ids", "权限").
 			SetData(treeData).
 			OnlyOnForms(),
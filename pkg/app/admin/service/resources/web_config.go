@@ -10,6 +10,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 )
 
 type WebConfig struct {
@@ -20,7 +21,7 @@ type WebConfig struct {
 func (p *WebConfig) Init(ctx *builder.Context) interface{} {
 
 	// 标题
-	p.Title = "网站配置"
+	p.Title = ctx.T("resource.web_config.title")
 
 	// 模型
 	p.Model = &model.Config{}
@@ -39,7 +40,7 @@ func (p *WebConfig) Fields(ctx *builder.Context) []interface{} {
 	field := &resource.Field{}
 	groupNames := []string{}
 
-	db.Client.
+	db.Client.WithContext(ctx.Context()).
 		Model(p.Model).
 		Where("status = ?", 1).
 		Distinct("group_name").
@@ -48,7 +49,7 @@ func (p *WebConfig) Fields(ctx *builder.Context) []interface{} {
 	tabPanes := []interface{}{}
 	for _, groupName := range groupNames {
 		configs := []map[string]interface{}{}
-		db.Client.
+		db.Client.WithContext(ctx.Context()).
 			Model(p.Model).
 			Where("status = ?", 1).
 			Where("group_name = ?", groupName).
@@ -61,6 +62,12 @@ func (p *WebConfig) Fields(ctx *builder.Context) []interface{} {
 			if !ok {
 				remark = ""
 			}
+
+			// 翻译内置配置的标题及备注
+			name, _ := config["name"].(string)
+			title, _ := config["title"].(string)
+			config["title"] = i18n.TranslateSeed(ctx.Locale(), "config."+name+".title", title)
+			remark = i18n.TranslateSeed(ctx.Locale(), "config."+name+".remark", remark)
 			switch config["type"] {
 			case "text":
 				getField := field.
@@ -75,27 +82,33 @@ func (p *WebConfig) Fields(ctx *builder.Context) []interface{} {
 			case "file":
 				getField := field.
 					File(config["name"], config["title"]).
-					SetButton("上传" + config["title"].(string)).
+					SetButton(ctx.T("field.upload", config["title"].(string))).
 					SetExtra(remark)
 				fields = append(fields, getField)
 			case "picture":
 				getField := field.
 					Image(config["name"], config["title"]).
-					SetButton("上传" + config["title"].(string)).
+					SetButton(ctx.T("field.upload", config["title"].(string))).
 					SetExtra(remark)
 				fields = append(fields, getField)
 			case "switch":
 				getField := field.
 					Switch(config["name"].(string), config["title"].(string)).
-					SetTrueValue("正常").
-					SetFalseValue("禁用").
+					SetTrueValue(ctx.T("field.switch.on")).
+					SetFalseValue(ctx.T("field.switch.off")).
 					SetExtra(remark)
 				fields = append(fields, getField)
 			}
 		}
+		// 翻译内置配置的分组名称
+		groupTitle := groupName
+		if key, ok := i18n.KeyOf(i18n.DefaultLocale, "config.group.", groupName); ok {
+			groupTitle = ctx.T(key)
+		}
+
 		tabPane := (&tabs.TabPane{}).
 			Init().
-			SetTitle(groupName).
+			SetTitle(groupTitle).
 			SetBody(fields)
 		tabPanes = append(tabPanes, tabPane)
 	}
@@ -119,7 +132,7 @@ func (p *WebConfig) BeforeCreating(ctx *builder.Context) map[string]interface{}
 	configs := []map[string]interface{}{}
 	data := map[string]interface{}{}
 
-	db.Client.
+	db.Client.WithContext(ctx.Context()).
 		Model(p.Model).
 		Where("status = ?", 1).
 		Find(&configs)
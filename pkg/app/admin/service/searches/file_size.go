@@ -0,0 +1,51 @@
+package searches
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/selectfield"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type FileSizeField struct {
+	searches.Select
+}
+
+// 文件大小范围
+func FileSize(column string, name string) *FileSizeField {
+	field := &FileSizeField{}
+
+	field.Column = column
+	field.Name = name
+	field.SelectOptions = []*selectfield.Option{
+		{Label: "< 1MB", Value: "lt1m"},
+		{Label: "1MB - 10MB", Value: "1m-10m"},
+		{Label: "10MB - 100MB", Value: "10m-100m"},
+		{Label: "> 100MB", Value: "gt100m"},
+	}
+
+	return field
+}
+
+// 执行查询
+func (p *FileSizeField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	const mb = 1024 * 1024
+
+	switch value {
+	case "lt1m":
+		return query.Where(p.Column+" < ?", mb)
+	case "1m-10m":
+		return query.Where(p.Column+" BETWEEN ? AND ?", mb, 10*mb)
+	case "10m-100m":
+		return query.Where(p.Column+" BETWEEN ? AND ?", 10*mb, 100*mb)
+	case "gt100m":
+		return query.Where(p.Column+" > ?", 100*mb)
+	}
+
+	return query
+}
+
+// 属性
+func (p *FileSizeField) Options(ctx *builder.Context) interface{} {
+	return p.SelectOptions
+}
@@ -0,0 +1,64 @@
+package searches
+
+import (
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/searches"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"gorm.io/gorm"
+)
+
+type FolderField struct {
+	searches.TreeSelect
+	FileType string
+}
+
+// 文件夹，包含子文件夹中的文件，fileType为model.FileReferenceFile或model.FileReferencePicture
+func Folder(column string, name string, fileType string) *FolderField {
+	field := &FolderField{}
+
+	field.Column = column
+	field.Name = name
+	field.FileType = fileType
+
+	return field
+}
+
+// 执行查询
+func (p *FolderField) Apply(ctx *builder.Context, query *gorm.DB, value interface{}) *gorm.DB {
+	id := 0
+	switch getValue := value.(type) {
+	case float64:
+		id = int(getValue)
+	case string:
+		id, _ = strconv.Atoi(getValue)
+	}
+
+	// 根目录不限制
+	if id == 0 {
+		return query
+	}
+
+	ids := []int{}
+	switch p.FileType {
+	case model.FileReferencePicture:
+		ids = (&model.PictureCategory{}).GetDescendantIds(id)
+	default:
+		ids = (&model.FileCategory{}).GetDescendantIds(id)
+	}
+
+	return query.Where(p.Column+" IN ?", ids)
+}
+
+// 属性
+func (p *FolderField) Options(ctx *builder.Context) interface{} {
+	switch p.FileType {
+	case model.FileReferencePicture:
+		options, _ := (&model.PictureCategory{}).TreeSelect(false)
+		return options
+	default:
+		options, _ := (&model.FileCategory{}).TreeSelect(false)
+		return options
+	}
+}
@@ -1,6 +1,8 @@
 package uploads
 
 import (
+	"errors"
+	"mime"
 	"reflect"
 	"time"
 
@@ -9,8 +11,12 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/upload"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"gorm.io/gorm"
 )
 
+// 跳转到对象存储临时地址的有效期
+const downloadPresignExpire = 5 * time.Minute
+
 type File struct {
 	upload.Template
 }
@@ -43,34 +49,155 @@ func (p *File) Init(ctx *builder.Context) interface{} {
 	// 设置文件上传路径
 	p.SavePath = "./web/app/storage/files/" + time.Now().Format("20060102") + "/"
 
+	// 检查压缩包炸弹及多格式混合的文件，需要查杀病毒时可以追加ClamAV扫描器
+	p.Scanners = []storage.Scanner{
+		storage.NewRuleScanner(),
+	}
+
 	return p
 }
 
-// 上传前回调
-func (p *File) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
-	fileHash, err := fileSystem.GetFileHash()
+// 初始化路由映射
+func (p *File) RouteInit() interface{} {
+	p.Template.RouteInit()
+	p.GET("/api/admin/upload/:resource/getList", p.GetList)
+	p.POST("/api/admin/upload/:resource/move", p.Move)
+	p.POST("/api/admin/upload/:resource/rename", p.Rename)
+	p.GET("/api/admin/upload/:resource/usage", p.Usage)
+	p.GET("/api/admin/upload/:resource/download", p.Download)
+	p.GET("/upload/:resource/:id/download", p.Download)
+
+	return p
+}
+
+// 获取文件列表，支持按文件夹（包含子文件夹）、名称、扩展名、大小及上传时间搜索
+func (p *File) GetList(ctx *builder.Context) error {
+	search := parseFileSearch(ctx, "file")
+	files, total, err := (&model.File{}).SearchList(
+		ctx.Engine.GetConfig().AppKey,
+		ctx.Token(),
+		search,
+	)
 	if err != nil {
-		return fileSystem, nil, err
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	getFileInfo, _ := (&model.File{}).GetInfoByHash(fileHash)
+	categorys, err := (&model.FileCategory{}).GetAuthList(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return fileSystem, nil, err
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
-	if getFileInfo.Id != 0 {
-		fileInfo := &storage.FileInfo{
-			Name: getFileInfo.Name,
-			Size: getFileInfo.Size,
-			Ext:  getFileInfo.Ext,
-			Path: getFileInfo.Path,
-			Url:  getFileInfo.Url,
-			Hash: getFileInfo.Hash,
+
+	return ctx.JSON(200, message.Success(
+		ctx.T("message.fetch_success"),
+		"",
+		map[string]interface{}{
+			"pagination": pickerPagination(search, total),
+			"view":       pickerView(ctx),
+			"lists":      files,
+			"categorys":  categorys,
+		},
+	))
+}
+
+// 移动文件到文件夹
+func (p *File) Move(ctx *builder.Context) error {
+	return moveFiles(ctx, model.FileReferenceFile)
+}
+
+// 重命名文件
+func (p *File) Rename(ctx *builder.Context) error {
+	return renameFile(ctx, model.FileReferenceFile)
+}
+
+// 获取文件的使用位置
+func (p *File) Usage(ctx *builder.Context) error {
+	return fileUsage(ctx, model.FileReferenceFile)
+}
+
+// 下载文件，通过后台认证路由或带签名的临时地址访问，对象存储跳转到存储生成的临时地址
+func (p *File) Download(ctx *builder.Context) error {
+	ownerId := 0
+	id := ctx.Param("id")
+	if id == "" {
+		id = ctx.Query("id", "").(string)
+
+		// 后台路由只能下载自己上传的文件
+		adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+		if err != nil {
+			return ctx.String(401, ctx.TError(err))
 		}
+		ownerId = adminInfo.Id
+	} else if err := ctx.VerifySignature(); err != nil {
+		return ctx.String(403, ctx.TError(err))
+	}
+
+	fileInfo, err := (&model.File{}).GetInfoById(id)
+	if err != nil || fileInfo.Id == 0 {
+		return ctx.String(404, ctx.T("message.file_not_found"))
+	}
+	if ownerId != 0 && (fileInfo.ObjType != "ADMINID" || fileInfo.ObjId != ownerId) {
+		return ctx.String(404, ctx.T("message.file_not_found"))
+	}
 
-		return fileSystem, fileInfo, err
+	driver, err := p.GetStorageDriver()
+	if err != nil {
+		return ctx.String(500, ctx.TError(err))
 	}
 
-	return fileSystem, nil, err
+	if presigner, ok := driver.(storage.Presigner); ok {
+		url, err := presigner.PresignURL(ctx.Context(), fileInfo.Path, downloadPresignExpire)
+		if err != nil {
+			return ctx.String(500, ctx.TError(err))
+		}
+
+		return ctx.Redirect(302, url)
+	}
+
+	reader, err := driver.Get(ctx.Context(), fileInfo.Path)
+	if errors.Is(err, storage.ErrNotExist) {
+		return ctx.String(404, ctx.T("message.file_not_found"))
+	}
+	if err != nil {
+		return ctx.String(500, ctx.TError(err))
+	}
+	defer reader.Close()
+
+	contentType := mime.TypeByExtension("." + fileInfo.Ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	ctx.Writer.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileInfo.Name}))
+	ctx.Writer.Header().Set("Cache-Control", "private, no-store")
+
+	return ctx.Stream(200, contentType, reader)
+}
+
+// 上传前回调，已保存过相同内容的文件时不再扫描及写入存储
+func (p *File) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
+	visibility := p.GetVisibility()
+
+	return fileSystem.Dedup(func(hash string) (*storage.FileInfo, error) {
+		getFileInfo, err := (&model.File{}).GetInfoByHashAndVisibility(hash, visibility)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage.FileInfo{
+			Path:       getFileInfo.Path,
+			Url:        getFileInfo.Url,
+			ScanStatus: getFileInfo.ScanStatus,
+			ScanResult: getFileInfo.ScanResult,
+		}, nil
+	}), nil, nil
+}
+
+// 文件未通过扫描回调
+func (p *File) QuarantineHandle(ctx *builder.Context, result *storage.FileInfo, err error) error {
+	return quarantineHandle(ctx, result, err)
 }
 
 // 上传完成后回调
@@ -81,34 +208,64 @@ func (p *File) AfterHandle(ctx *builder.Context, result *storage.FileInfo) error
 		FieldByName("Driver").
 		String()
 
-	// 重写url
-	if driver == storage.LocalDriver {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 相同内容的文件只保存一份，删除本次重复上传的文件
+	visibility := p.GetVisibility()
+	getFileInfo, _ := (&model.File{}).GetInfoByHashAndVisibility(result.Hash, visibility)
+	if getFileInfo.Id != 0 {
+		if getFileInfo.Path != result.Path {
+			if storageDriver, err := p.GetStorageDriver(); err == nil {
+				storageDriver.Delete(ctx.Context(), result.Path)
+			}
+		}
+		result.Path = getFileInfo.Path
+		result.Url = getFileInfo.Url
+	} else if driver == storage.LocalDriver {
+		// 重写url
 		result.Url = (&model.File{}).GetPath(result.Url)
 	}
 
-	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
-	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
-	}
-
-	// 插入数据库
-	id, err := (&model.File{}).InsertGetId(&model.File{
-		ObjType: "ADMINID",
-		ObjId:   adminInfo.Id,
-		Name:    result.Name,
-		Size:    result.Size,
-		Ext:     result.Ext,
-		Path:    result.Path,
-		Url:     result.Url,
-		Hash:    result.Hash,
-		Status:  1,
-	})
-	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+	// 私有文件不保存公开的访问地址，只能通过下载路由或带签名的地址访问
+	if visibility == storage.VisibilityPrivate {
+		result.Url = ""
+	}
+
+	// 当前用户已有相同文件时返回已有记录，否则新增记录
+	id := 0
+	ownFileInfo, _ := (&model.File{}).GetInfoByHashAndObj(result.Hash, "ADMINID", adminInfo.Id)
+	if ownFileInfo.Id != 0 && ownFileInfo.Visibility == visibility {
+		id = ownFileInfo.Id
+	} else {
+		id, err = (&model.File{}).InsertGetId(&model.File{
+			ObjType:    "ADMINID",
+			ObjId:      adminInfo.Id,
+			Name:       result.Name,
+			Size:       result.Size,
+			Ext:        result.Ext,
+			Path:       result.Path,
+			Url:        result.Url,
+			Hash:       result.Hash,
+			Visibility: visibility,
+			ScanStatus: result.ScanStatus,
+			ScanResult: result.ScanResult,
+			Status:     1,
+		})
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+	}
+
+	// 私有文件返回带签名的临时下载地址
+	if visibility == storage.VisibilityPrivate {
+		result.Url = (&model.File{}).GetSignedPath(id, builder.DefaultSignExpire)
 	}
 
 	return ctx.JSON(200, message.Success(
-		"上传成功",
+		ctx.T("message.upload_success"),
 		"",
 		map[string]interface{}{
 			"id":          id,
@@ -0,0 +1,141 @@
+package uploads
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 清理存储中没有被文件、图片记录及引用使用的文件
+type GarbageCollector struct {
+	Driver   storage.Driver // 存储驱动
+	Prefixes []string       // 扫描的路径前缀
+	MinAge   time.Duration  // 最小保留时间，避免删除刚上传还未写入数据库的文件
+	DryRun   bool           // 只统计不删除
+}
+
+// 清理结果
+type GarbageCollectResult struct {
+	Scanned     int      `json:"scanned"`     // 扫描的文件数量
+	Removed     int      `json:"removed"`     // 删除的文件数量
+	RemovedSize int64    `json:"removedSize"` // 删除的文件大小
+	Keys        []string `json:"keys"`        // 删除的文件路径
+}
+
+// 创建垃圾回收，默认扫描文件、图片的上传目录及本地私有文件的保存目录
+func NewGarbageCollector(driver storage.Driver) *GarbageCollector {
+	privatePath := "./storage"
+	if config := builder.GetConfig(); config != nil && config.PrivatePath != "" {
+		privatePath = config.PrivatePath
+	}
+	privatePath = path.Clean(filepath.ToSlash(privatePath))
+	if !path.IsAbs(privatePath) {
+		privatePath = "./" + privatePath
+	}
+
+	return &GarbageCollector{
+		Driver: driver,
+		Prefixes: []string{
+			"./web/app/storage/files/",
+			"./web/app/storage/images/",
+			privatePath + "/files/",
+			privatePath + "/images/",
+		},
+		MinAge: time.Hour,
+	}
+}
+
+// 获取正在使用的文件路径
+func (p *GarbageCollector) usedPaths() (map[string]bool, error) {
+	used := map[string]bool{}
+
+	filePaths, err := (&model.File{}).GetSavePaths()
+	if err != nil {
+		return used, err
+	}
+	picturePaths, err := (&model.Picture{}).GetSavePaths()
+	if err != nil {
+		return used, err
+	}
+	referencePaths, err := (&model.FileReference{}).GetPaths()
+	if err != nil {
+		return used, err
+	}
+
+	for _, paths := range [][]string{filePaths, picturePaths, referencePaths} {
+		for _, v := range paths {
+			used[path.Clean(v)] = true
+		}
+	}
+
+	return used, nil
+}
+
+// 执行清理
+func (p *GarbageCollector) Run(ctx context.Context) (*GarbageCollectResult, error) {
+	result := &GarbageCollectResult{Keys: []string{}}
+
+	// 先清理引用记录已删除的引用
+	_, err := (&model.FileReference{}).PruneStale()
+	if err != nil {
+		return result, err
+	}
+
+	used, err := p.usedPaths()
+	if err != nil {
+		return result, err
+	}
+
+	deadline := time.Now().Add(-p.MinAge)
+	for _, prefix := range p.Prefixes {
+		objects, err := p.Driver.List(ctx, prefix)
+		if err != nil {
+			return result, err
+		}
+
+		for _, v := range objects {
+			result.Scanned++
+
+			// 图片规格跟随原图保留
+			key, _ := storage.ImageVariantOrigin(v.Key)
+			if used[path.Clean(key)] || v.LastModified.After(deadline) {
+				continue
+			}
+
+			if !p.DryRun {
+				err := p.Driver.Delete(ctx, v.Key)
+				if err != nil {
+					return result, err
+				}
+			}
+
+			result.Removed++
+			result.RemovedSize += v.Size
+			result.Keys = append(result.Keys, v.Key)
+		}
+	}
+
+	return result, nil
+}
+
+// 按时间间隔定时执行清理，ctx取消后停止
+func (p *GarbageCollector) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Run(ctx)
+			}
+		}
+	}()
+}
@@ -1,8 +1,12 @@
 package uploads
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"image"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
@@ -12,7 +16,9 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/upload"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"gorm.io/gorm"
 )
 
 type Image struct {
@@ -36,78 +42,183 @@ func (p *Image) Init(ctx *builder.Context) interface{} {
 	// 设置文件上传路径
 	p.SavePath = "./web/app/storage/images/" + time.Now().Format("20060102") + "/"
 
+	// 检查图片中嵌入的脚本及压缩包
+	p.Scanners = []storage.Scanner{
+		storage.NewRuleScanner(),
+	}
+
+	// 图片规格，缩略图在上传时生成
+	p.ImageVariants = []*storage.ImageVariant{
+		{
+			Name:     "thumb",
+			Width:    200,
+			Height:   200,
+			Mode:     storage.ImageFill,
+			OnUpload: true,
+		},
+	}
+
 	return p
 }
 
 // 初始化路由映射
 func (p *Image) RouteInit() interface{} {
+	p.Template.RouteInit()
 	p.GET("/api/admin/upload/:resource/getList", p.GetList)
 	p.Any("/api/admin/upload/:resource/delete", p.Delete)
 	p.POST("/api/admin/upload/:resource/crop", p.Crop)
-	p.POST("/api/admin/upload/:resource/handle", p.Handle)
-	p.POST("/api/admin/upload/:resource/base64Handle", p.HandleFromBase64)
+	p.POST("/api/admin/upload/:resource/move", p.Move)
+	p.POST("/api/admin/upload/:resource/rename", p.Rename)
+	p.GET("/api/admin/upload/:resource/usage", p.Usage)
+	p.GET("/upload/:resource/:id/:variant", p.Variant)
 
 	return p
 }
 
-// 获取文件列表
+// 获取文件列表，支持按文件夹（包含子文件夹）、名称、扩展名、大小及上传时间搜索
 func (p *Image) GetList(ctx *builder.Context) error {
-	page := ctx.Query("page", "1")
-	categoryId := ctx.Query("pictureCategoryId", "")
-	searchName := ctx.Query("pictureSearchName", "")
-	searchDateStart := ctx.Query("pictureSearchDate[0]", "")
-	searchDateEnd := ctx.Query("pictureSearchDate[1]", "")
-	currentPage, _ := strconv.Atoi(page.(string))
-
-	pictures, total, err := (&model.Picture{}).GetListBySearch(
+	search := parseFileSearch(ctx, "picture")
+	pictures, total, err := (&model.Picture{}).SearchList(
 		ctx.Engine.GetConfig().AppKey,
 		ctx.Token(),
-		categoryId, searchName,
-		searchDateStart,
-		searchDateEnd,
-		currentPage,
+		search,
 	)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	pagination := map[string]interface{}{
-		"defaultCurrent": 1,
-		"current":        currentPage,
-		"pageSize":       8,
-		"total":          total,
+	// 列表使用缩略图
+	if p.GetImageVariant("thumb") != nil {
+		for _, v := range pictures {
+			v.Thumb = (&model.Picture{}).GetPath(v.Id, "thumb")
+		}
 	}
 
 	categorys, err := (&model.PictureCategory{}).GetAuthList(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	return ctx.JSON(200, message.Success(
-		"上传成功",
+		ctx.T("message.upload_success"),
 		"",
 		map[string]interface{}{
-			"pagination": pagination,
+			"pagination": pickerPagination(search, total),
+			"view":       pickerView(ctx),
 			"lists":      pictures,
 			"categorys":  categorys,
 		},
 	))
 }
 
+// 移动图片到文件夹
+func (p *Image) Move(ctx *builder.Context) error {
+	return moveFiles(ctx, model.FileReferencePicture)
+}
+
+// 重命名图片
+func (p *Image) Rename(ctx *builder.Context) error {
+	return renameFile(ctx, model.FileReferencePicture)
+}
+
+// 获取图片的使用位置
+func (p *Image) Usage(ctx *builder.Context) error {
+	return fileUsage(ctx, model.FileReferencePicture)
+}
+
 // 图片删除
 func (p *Image) Delete(ctx *builder.Context) error {
 	data := map[string]interface{}{}
 	json.Unmarshal(ctx.Body(), &data)
 	if data["id"] == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
-	err := (&model.Picture{}).DeleteById(data["id"])
+	pictureInfo, err := (&model.Picture{}).GetInfoById(data["id"])
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = (&model.Picture{}).DeleteById(pictureInfo.Id)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 没有其他记录使用该文件时，删除存储中的文件及图片规格
+	if (&model.Picture{}).CountByPath(pictureInfo.Path) == 0 && (&model.FileReference{}).CountByPath(pictureInfo.Path) == 0 {
+		if storageDriver, err := p.GetStorageDriver(); err == nil {
+			storageDriver.Delete(ctx.Context(), pictureInfo.Path)
+		}
+		p.RemoveImageVariants(ctx.Context(), pictureInfo.Path)
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 获取图片规格，规格文件不存在时生成并缓存到存储中
+func (p *Image) Variant(ctx *builder.Context) error {
+	if p.GetImageVariant(ctx.Param("variant")) == nil {
+		return ctx.String(404, ctx.T("storage.image_variant_not_found"))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	pictureInfo, err := (&model.Picture{}).GetInfoById(ctx.Param("id"))
+	if err != nil || pictureInfo.Id == 0 {
+		return ctx.String(404, ctx.T("message.file_not_found"))
+	}
+
+	content, contentType, err := p.ImageVariant(ctx.Context(), pictureInfo.Path, ctx.Param("variant"))
+	if errors.Is(err, storage.ErrNotExist) {
+		return ctx.String(404, ctx.T("message.file_not_found"))
+	}
+	if err != nil {
+		return ctx.String(500, ctx.TError(err))
+	}
+
+	ctx.Writer.Header().Set("Cache-Control", "public, max-age=86400")
+
+	return ctx.Blob(200, contentType, content)
+}
+
+// 根据裁剪区域在服务端裁剪原图，返回裁剪后的图片内容
+func (p *Image) cropPicture(ctx *builder.Context, pictureInfo *model.Picture, data map[string]interface{}) ([]byte, error) {
+	x, _ := data["x"].(float64)
+	y, _ := data["y"].(float64)
+	width, _ := data["width"].(float64)
+	height, _ := data["height"].(float64)
+	if width <= 0 || height <= 0 {
+		return nil, i18n.NewError("message.invalid_params")
+	}
+
+	driver, err := p.GetStorageDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := driver.Get(ctx.Context(), pictureInfo.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	img, format, err := storage.DecodeImage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	err = storage.EncodeImage(
+		buf,
+		storage.CropImage(img, image.Rect(int(x), int(y), int(x+width), int(y+height))),
+		format,
+		0,
+	)
+
+	return buf.Bytes(), err
 }
 
 // 图片裁剪
@@ -119,36 +230,45 @@ func (p *Image) Crop(ctx *builder.Context) error {
 
 	data := map[string]interface{}{}
 	if err := ctx.BodyParser(&data); err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
-	if data["id"] == "" || data["file"] == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+	if data["id"] == nil || data["id"] == "" {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	pictureInfo, err := (&model.Picture{}).GetInfoById(data["id"])
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 	if pictureInfo.Id == 0 {
-		return ctx.JSON(200, message.Error("文件不存在"))
+		return ctx.JSON(200, message.Error(ctx.T("message.file_not_found")))
 	}
 
 	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	limitW := ctx.Query("limitW", "")
 	limitH := ctx.Query("limitH", "")
 
-	files := strings.Split(data["file"].(string), ",")
-	if len(files) != 2 {
-		return ctx.JSON(200, message.Error("格式错误"))
-	}
+	var fileData []byte
+	if file, ok := data["file"].(string); ok && file != "" {
+		files := strings.Split(file, ",")
+		if len(files) != 2 {
+			return ctx.JSON(200, message.Error(ctx.T("message.format_error")))
+		}
 
-	fileData, err := base64.StdEncoding.DecodeString(files[1]) //成图片文件并把文件写入到buffer
-	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		fileData, err = base64.StdEncoding.DecodeString(files[1]) //成图片文件并把文件写入到buffer
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+	} else {
+		// 未传入裁剪后的图片时，根据裁剪区域在服务端裁剪原图
+		fileData, err = p.cropPicture(ctx, pictureInfo, data)
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
 	}
 
 	limitSize := reflect.
@@ -205,6 +325,11 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		Elem().
 		FieldByName("MinioConfig").Interface()
 
+	s3Config := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("S3Config").Interface()
+
 	fileSystem := storage.
 		New(&storage.Config{
 			LimitSize:        limitSize,
@@ -214,6 +339,7 @@ func (p *Image) Crop(ctx *builder.Context) error {
 			Driver:           driver,
 			OSSConfig:        ossConfig.(*storage.OSSConfig),
 			MinioConfig:      minioConfig.(*storage.MinioConfig),
+			S3Config:         s3Config.(*storage.S3Config),
 		}).
 		Reader(&storage.File{
 			Content: fileData,
@@ -224,7 +350,7 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
 	}).BeforeHandle(ctx, fileSystem)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 	if fileInfo != nil {
 		// 更新数据库
@@ -249,7 +375,7 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		Path(savePath).
 		Save()
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	// 重写url
@@ -257,6 +383,10 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		result.Url = (&model.Picture{}).GetPath(result.Url)
 	}
 
+	// 图片内容已改变，重新生成图片规格
+	p.RemoveImageVariants(ctx.Context(), result.Path)
+	p.GenerateImageVariants(ctx.Context(), result.Path)
+
 	// 更新数据库
 	(&model.Picture{}).UpdateById(pictureInfo.Id, &model.Picture{
 		ObjType: "ADMINID",
@@ -272,36 +402,30 @@ func (p *Image) Crop(ctx *builder.Context) error {
 		Status:  1,
 	})
 
-	return ctx.JSON(200, message.Success("操作成功", "", result))
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", result))
 }
 
-// 上传前回调
+// 上传前回调，已保存过相同内容的图片时不再扫描及写入存储
 func (p *Image) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
-	fileHash, err := fileSystem.GetFileHash()
-	if err != nil {
-		return fileSystem, nil, err
-	}
-
-	pictureInfo, _ := (&model.Picture{}).GetInfoByHash(fileHash)
-	if err != nil {
-		return fileSystem, nil, err
-	}
-	if pictureInfo.Id != 0 {
-		fileInfo := &storage.FileInfo{
-			Name:   pictureInfo.Name,
-			Size:   pictureInfo.Size,
-			Width:  pictureInfo.Width,
-			Height: pictureInfo.Height,
-			Ext:    pictureInfo.Ext,
-			Path:   pictureInfo.Path,
-			Url:    pictureInfo.Url,
-			Hash:   pictureInfo.Hash,
+	return fileSystem.Dedup(func(hash string) (*storage.FileInfo, error) {
+		pictureInfo, err := (&model.Picture{}).GetInfoByHash(hash)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		return fileSystem, fileInfo, err
-	}
+		return &storage.FileInfo{
+			Path: pictureInfo.Path,
+			Url:  pictureInfo.Url,
+		}, nil
+	}), nil, nil
+}
 
-	return fileSystem, nil, err
+// 文件未通过扫描回调
+func (p *Image) QuarantineHandle(ctx *builder.Context, result *storage.FileInfo, err error) error {
+	return quarantineHandle(ctx, result, err)
 }
 
 // 上传完成后回调
@@ -311,36 +435,54 @@ func (p *Image) AfterHandle(ctx *builder.Context, result *storage.FileInfo) erro
 		Elem().
 		FieldByName("Driver").String()
 
-	// 重写url
-	if driver == storage.LocalDriver {
-		result.Url = (&model.Picture{}).GetPath(result.Url)
-	}
-
 	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
-	// 插入数据库
-	id, err := (&model.Picture{}).InsertGetId(&model.Picture{
-		ObjType: "ADMINID",
-		ObjId:   adminInfo.Id,
-		Name:    result.Name,
-		Size:    result.Size,
-		Width:   result.Width,
-		Height:  result.Height,
-		Ext:     result.Ext,
-		Path:    result.Path,
-		Url:     result.Url,
-		Hash:    result.Hash,
-		Status:  1,
-	})
+	// 相同内容的图片只保存一份，删除本次重复上传的文件
+	pictureInfo, _ := (&model.Picture{}).GetInfoByHash(result.Hash)
+	if pictureInfo.Id != 0 {
+		if pictureInfo.Path != result.Path {
+			if storageDriver, err := p.GetStorageDriver(); err == nil {
+				storageDriver.Delete(ctx.Context(), result.Path)
+			}
+		}
+		result.Path = pictureInfo.Path
+		result.Url = pictureInfo.Url
+	} else if driver == storage.LocalDriver {
+		// 重写url
+		result.Url = (&model.Picture{}).GetPath(result.Url)
+	}
 
-	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+	// 当前用户已有相同图片时返回已有记录，否则新增记录
+	id := 0
+	ownPictureInfo, _ := (&model.Picture{}).GetInfoByHashAndObj(result.Hash, "ADMINID", adminInfo.Id)
+	if ownPictureInfo.Id != 0 {
+		id = ownPictureInfo.Id
+	} else {
+		id, err = (&model.Picture{}).InsertGetId(&model.Picture{
+			ObjType: "ADMINID",
+			ObjId:   adminInfo.Id,
+			Name:    result.Name,
+			Size:    result.Size,
+			Width:   result.Width,
+			Height:  result.Height,
+			Ext:     result.Ext,
+			Path:    result.Path,
+			Url:     result.Url,
+			Hash:    result.Hash,
+			Status:  1,
+		})
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
 	}
 
-	return ctx.JSON(200, message.Success("上传成功", "", map[string]interface{}{
+	// 生成上传时需要的图片规格，生成失败时在首次访问时重新生成
+	p.GenerateImageVariants(ctx.Context(), result.Path)
+
+	return ctx.JSON(200, message.Success(ctx.T("message.upload_success"), "", map[string]interface{}{
 		"id":          id,
 		"contentType": result.ContentType,
 		"ext":         result.Ext,
@@ -0,0 +1,185 @@
+package uploads
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
+
+// 获取字符串类型的查询参数
+func queryString(ctx *builder.Context, key string) string {
+	value, _ := ctx.Query(key, "").(string)
+
+	return strings.TrimSpace(value)
+}
+
+// 解析文件选择器的搜索条件，prefix为参数前缀，例如：picture、file
+func parseFileSearch(ctx *builder.Context, prefix string) *model.FileSearch {
+	search := &model.FileSearch{
+		Name:      queryString(ctx, prefix+"SearchName"),
+		Ext:       queryString(ctx, prefix+"SearchExt"),
+		StartDate: queryString(ctx, prefix+"SearchDate[0]"),
+		EndDate:   queryString(ctx, prefix+"SearchDate[1]"),
+	}
+	search.Page, _ = strconv.Atoi(queryString(ctx, "page"))
+	search.PageSize, _ = strconv.Atoi(queryString(ctx, "pageSize"))
+	search.CategoryId, _ = strconv.Atoi(queryString(ctx, prefix+"CategoryId"))
+	search.MinSize, _ = strconv.ParseInt(queryString(ctx, prefix+"SearchMinSize"), 10, 64)
+	search.MaxSize, _ = strconv.ParseInt(queryString(ctx, prefix+"SearchMaxSize"), 10, 64)
+
+	return search
+}
+
+// 文件选择器的分页信息
+func pickerPagination(search *model.FileSearch, total int64) map[string]interface{} {
+	return map[string]interface{}{
+		"defaultCurrent":  1,
+		"current":         search.GetPage(),
+		"pageSize":        search.GetPageSize(),
+		"pageSizeOptions": model.FileSearchPageSizes,
+		"total":           total,
+	}
+}
+
+// 文件选择器的展示方式：grid、list，默认grid
+func pickerView(ctx *builder.Context) string {
+	if queryString(ctx, "view") == "list" {
+		return "list"
+	}
+
+	return "grid"
+}
+
+// 解析id列表，支持数组、逗号分隔的字符串及单个数字
+func parseIds(value interface{}) []int {
+	ids := []int{}
+	switch getValue := value.(type) {
+	case float64:
+		ids = append(ids, int(getValue))
+	case string:
+		for _, v := range strings.Split(getValue, ",") {
+			if id, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				ids = append(ids, id)
+			}
+		}
+	case []interface{}:
+		for _, v := range getValue {
+			ids = append(ids, parseIds(v)...)
+		}
+	}
+
+	return ids
+}
+
+// 解析请求数据并获取当前管理员
+func parseManageRequest(ctx *builder.Context) (map[string]interface{}, *model.AdminClaims, error) {
+	data := map[string]interface{}{}
+	json.Unmarshal(ctx.Body(), &data)
+
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+
+	return data, adminInfo, err
+}
+
+// 移动文件或图片到文件夹，请求数据：{"ids":[1,2],"categoryId":3}
+func moveFiles(ctx *builder.Context, fileType string) error {
+	data, adminInfo, err := parseManageRequest(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	ids := parseIds(data["ids"])
+	if len(ids) == 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+	categoryIds := parseIds(data["categoryId"])
+	categoryId := 0
+	if len(categoryIds) > 0 {
+		categoryId = categoryIds[0]
+	}
+
+	// 只能移动到自己的文件夹
+	if categoryId != 0 {
+		objId := 0
+		switch fileType {
+		case model.FileReferencePicture:
+			category, _ := (&model.PictureCategory{}).GetInfoById(categoryId)
+			objId = category.ObjId
+		default:
+			category, _ := (&model.FileCategory{}).GetInfoById(categoryId)
+			objId = category.ObjId
+		}
+		if objId != adminInfo.Id {
+			return ctx.JSON(200, message.Error(ctx.T("file.folder_invalid")))
+		}
+	}
+
+	switch fileType {
+	case model.FileReferencePicture:
+		_, err = (&model.Picture{}).MoveToCategory(adminInfo.Id, ids, categoryId)
+	default:
+		_, err = (&model.File{}).MoveToCategory(adminInfo.Id, ids, categoryId)
+	}
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 重命名文件或图片，请求数据：{"id":1,"name":"demo.png"}
+func renameFile(ctx *builder.Context, fileType string) error {
+	data, adminInfo, err := parseManageRequest(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	ids := parseIds(data["id"])
+	name, _ := data["name"].(string)
+	name = strings.TrimSpace(name)
+	if len(ids) != 1 || name == "" || len(name) > 255 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	var affected int64
+	switch fileType {
+	case model.FileReferencePicture:
+		affected, err = (&model.Picture{}).Rename(adminInfo.Id, ids[0], name)
+	default:
+		affected, err = (&model.File{}).Rename(adminInfo.Id, ids[0], name)
+	}
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	if affected == 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.file_not_found")))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 获取文件或图片的使用位置
+func fileUsage(ctx *builder.Context, fileType string) error {
+	id, err := strconv.Atoi(queryString(ctx, "id"))
+	if err != nil || id == 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	references, err := (&model.FileReference{}).GetListByFile(fileType, id)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(
+		ctx.T("message.fetch_success"),
+		"",
+		map[string]interface{}{
+			"total": len(references),
+			"lists": references,
+		},
+	))
+}
@@ -0,0 +1,29 @@
+package uploads
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 记录未通过扫描的文件，隔离的文件记录为禁用状态，可以在后台文件列表中查看扫描结果
+func quarantineHandle(ctx *builder.Context, result *storage.FileInfo, err error) error {
+	adminInfo, adminErr := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if adminErr == nil {
+		(&model.File{}).InsertQuarantine(&model.File{
+			ObjType:    "ADMINID",
+			ObjId:      adminInfo.Id,
+			Name:       result.Name,
+			Size:       result.Size,
+			Ext:        result.Ext,
+			Path:       result.Path,
+			Hash:       result.Hash,
+			Visibility: storage.VisibilityPrivate,
+			ScanStatus: result.ScanStatus,
+			ScanResult: result.ScanResult,
+		})
+	}
+
+	return ctx.JSON(200, message.Error(ctx.TError(err)))
+}
@@ -0,0 +1,95 @@
+package dashboard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
+)
+
+// 卡片结果缓存的key前缀
+const cachePrefix = "quark:dashboard:"
+
+// 卡片结果缓存，启用Redis时使用Redis，否则使用内存缓存
+type Cache interface {
+
+	// 获取缓存
+	Get(key string) ([]byte, bool)
+
+	// 设置缓存
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// 内存缓存
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string]*memoryCacheItem
+}
+
+// 内存缓存项
+type memoryCacheItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Redis缓存
+type redisCache struct{}
+
+// 默认的内存缓存
+var defaultMemoryCache = &memoryCache{items: map[string]*memoryCacheItem{}}
+
+// 获取卡片结果缓存
+func GetCache() Cache {
+	if redisclient.Client != nil {
+		return &redisCache{}
+	}
+
+	return defaultMemoryCache
+}
+
+// 获取缓存
+func (p *memoryCache) Get(key string) ([]byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	item, ok := p.items[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(item.expiresAt) {
+		delete(p.items, key)
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// 设置缓存，同时清理已过期的缓存
+func (p *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for k, v := range p.items {
+		if now.After(v.expiresAt) {
+			delete(p.items, k)
+		}
+	}
+	p.items[key] = &memoryCacheItem{value: value, expiresAt: now.Add(ttl)}
+}
+
+// 获取缓存
+func (p *redisCache) Get(key string) ([]byte, bool) {
+	value, err := redisclient.Client.Get(context.Background(), cachePrefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// 设置缓存
+func (p *redisCache) Set(key string, value []byte, ttl time.Duration) {
+	redisclient.Client.Set(context.Background(), cachePrefix+key, value, ttl)
+}
@@ -1,14 +1,12 @@
 package dashboard
 
 import (
-	"reflect"
+	"sync"
+	"time"
 
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/card"
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/grid"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/pagecontainer"
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/statistic"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
 )
@@ -16,9 +14,10 @@ import (
 // 后台登录模板
 type Template struct {
 	builder.Template
-	Title    string // 页面标题
-	SubTitle string // 页面子标题
-	BackIcon bool   // 页面是否携带返回Icon
+	Title    string        // 页面标题
+	SubTitle string        // 页面子标题
+	BackIcon bool          // 页面是否携带返回Icon
+	Timeout  time.Duration // 卡片计算的默认超时时间，超时的卡片展示提示信息，可以通过卡片接口重新加载
 }
 
 // 初始化
@@ -35,17 +34,26 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	p.DB = db.Client
 
 	// 标题
-	p.Title = "仪表盘"
+	p.Title = ctx.T("dashboard.title")
 
 	// 页面是否携带返回Icon
 	p.BackIcon = false
 
+	// 卡片计算的默认超时时间
+	p.Timeout = 5 * time.Second
+
 	return p
 }
 
 // 初始化路由映射
 func (p *Template) RouteInit() interface{} {
-	p.GET("/api/admin/dashboard/:resource/index", p.Render) // 后台仪表盘路由
+	p.GET("/api/admin/dashboard/:resource/index", p.Render)                       // 后台仪表盘路由
+	p.GET("/api/admin/dashboard/:resource/metric/:metric", p.MetricRender)        // 后台仪表盘单个卡片路由
+	p.GET("/api/admin/dashboard/:resource/layout", p.Layout)                      // 获取仪表盘布局路由
+	p.POST("/api/admin/dashboard/:resource/layout/save", p.SaveLayout)            // 保存仪表盘布局路由
+	p.POST("/api/admin/dashboard/:resource/layout/reset", p.ResetLayout)          // 恢复默认仪表盘布局路由
+	p.POST("/api/admin/dashboard/:resource/layout/role/save", p.SaveRoleLayout)   // 保存角色默认布局路由
+	p.POST("/api/admin/dashboard/:resource/layout/role/reset", p.ResetRoleLayout) // 恢复角色默认布局路由
 
 	return p
 }
@@ -65,6 +73,11 @@ func (p *Template) GetBackIcon() bool {
 	return p.BackIcon
 }
 
+// 获取卡片计算的默认超时时间
+func (p *Template) GetTimeout() time.Duration {
+	return p.Timeout
+}
+
 // 内容
 func (p *Template) Cards(ctx *builder.Context) []interface{} {
 	return nil
@@ -111,39 +124,56 @@ func (p *Template) PageContainerComponentRender(ctx *builder.Context, body inter
 func (p *Template) Render(ctx *builder.Context) error {
 	template := ctx.Template.(Dashboarder)
 
-	cards := template.Cards(ctx)
-	if cards == nil {
-		return ctx.JSON(200, message.Error("请实现Cards内容"))
+	if template.Cards(ctx) == nil {
+		return ctx.JSON(200, message.Error(ctx.T("dashboard.cards_not_implemented")))
+	}
+
+	// 按管理员的布局获取有权限的卡片
+	adminId, err := p.adminId(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	cards, layoutCols, err := p.layoutMetrics(ctx, adminId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 初始化卡片，非异步加载的卡片并行计算
+	resource := ctx.Param("resource")
+	timeout := template.GetTimeout()
+	bodies := make([][]byte, len(cards))
+	errs := make([]error, len(cards))
+	var wg sync.WaitGroup
+	for key, v := range cards {
+		uriKey := p.GetMetricUriKey(v)
+
+		// 当前选择的时间范围，参数格式：range[指标key]=30d
+		p.initMetric(ctx, v, ctx.QueryParam("range["+uriKey+"]"))
+		if optioner, ok := v.(metricOptioner); ok && optioner.GetLazy() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(key int, v interface{}, uriKey string) {
+			defer wg.Done()
+			bodies[key], errs[key] = p.metricBody(v, resource, uriKey, timeout)
+		}(key, v, uriKey)
 	}
+	wg.Wait()
 
 	var cols []interface{}
 	var body []interface{}
 	var colNum int = 0
 	for key, v := range cards {
+		item := p.metricCard(ctx, v, p.GetMetricUriKey(v), bodies[key], errs[key])
 
-		// 断言statistic组件类型
-		statistic, ok := v.(interface{ Calculate() *statistic.Component })
-		item := (&card.Component{}).Init()
-		if ok {
-			item = item.SetBody(statistic.Calculate())
-		} else {
-			// 断言descriptions组件类型
-			descriptions, ok := v.(interface {
-				Calculate() *descriptions.Component
-			})
-			if ok {
-				item = item.SetBody(descriptions.Calculate())
-			}
+		col := layoutCols[key]
+		if col == 0 {
+			col = p.metricCol(v)
 		}
-
-		col := reflect.
-			ValueOf(v).
-			Elem().
-			FieldByName("Col").
-			Int()
-		colInfo := (&grid.Col{}).Init().SetSpan(int(col)).SetBody(item)
+		colInfo := (&grid.Col{}).Init().SetSpan(col).SetBody(item)
 		cols = append(cols, colInfo)
-		colNum = colNum + int(col)
+		colNum = colNum + col
 		if colNum%24 == 0 {
 			row := (&grid.Row{}).Init().SetGutter(8).SetBody(cols)
 			if key != 1 {
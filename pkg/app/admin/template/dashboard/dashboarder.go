@@ -1,6 +1,10 @@
 package dashboard
 
-import "github.com/quarkcloudio/quark-go/v2/pkg/builder"
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+)
 
 type Dashboarder interface {
 
@@ -16,6 +20,9 @@ type Dashboarder interface {
 	// 页面是否携带返回Icon
 	GetBackIcon() bool
 
+	// 获取卡片计算的默认超时时间
+	GetTimeout() time.Duration
+
 	// 内容
 	Cards(ctx *builder.Context) []interface{}
 
@@ -27,4 +34,25 @@ type Dashboarder interface {
 
 	// 组件渲染
 	Render(ctx *builder.Context) error
+
+	// 单个卡片的渲染
+	MetricRender(ctx *builder.Context) error
+
+	// 卡片key
+	GetMetricUriKey(metric interface{}) string
+
+	// 获取布局
+	Layout(ctx *builder.Context) error
+
+	// 保存当前管理员的布局
+	SaveLayout(ctx *builder.Context) error
+
+	// 恢复当前管理员的布局
+	ResetLayout(ctx *builder.Context) error
+
+	// 保存角色的默认布局
+	SaveRoleLayout(ctx *builder.Context) error
+
+	// 恢复角色的默认布局
+	ResetRoleLayout(ctx *builder.Context) error
 }
@@ -0,0 +1,282 @@
+package dashboard
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 布局接口返回的卡片信息
+type LayoutCard struct {
+	Key   string `json:"key"`   // 卡片key
+	Title string `json:"title"` // 卡片标题
+	Col   int    `json:"col"`   // 卡片宽度
+}
+
+// 保存布局的请求参数，cards的顺序即卡片的展示顺序
+type LayoutRequest struct {
+	RoleId int                    `json:"roleId"` // 角色id，保存或恢复角色的默认布局时使用
+	Cards  []*model.DashboardCard `json:"cards"`
+}
+
+// 获取当前管理员的id
+func (p *Template) adminId(ctx *builder.Context) (int, error) {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return 0, err
+	}
+
+	return adminInfo.Id, nil
+}
+
+// 判断管理员是否有接口的权限，规则与后台权限中间件一致，objs为接口的路由或请求路径
+func (p *Template) canAccess(adminId int, method string, objs ...string) bool {
+	if adminId == 1 {
+		return true
+	}
+
+	sub := "admin|" + strconv.Itoa(adminId)
+	for _, obj := range objs {
+		for _, act := range []string{"Any", method} {
+			if result, _ := (&model.CasbinRule{}).Enforce(sub, obj, act); result {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// 判断管理员是否有卡片的权限，拥有卡片接口的权限即可查看卡片
+func (p *Template) canSeeMetric(ctx *builder.Context, adminId int, uriKey string) bool {
+	return p.canAccess(adminId, "GET",
+		"/api/admin/dashboard/"+ctx.Param("resource")+"/metric/"+uriKey,
+		"/api/admin/dashboard/:resource/metric/:metric",
+		"/api/admin/dashboard/"+ctx.Param("resource")+"/metric/:metric",
+	)
+}
+
+// 判断管理员是否可以管理角色的布局，拥有保存角色布局接口的权限即可查看角色的布局
+func (p *Template) canManageRoleLayout(ctx *builder.Context, adminId int) bool {
+	return p.canAccess(adminId, "POST",
+		"/api/admin/dashboard/"+ctx.Param("resource")+"/layout/role/save",
+		"/api/admin/dashboard/:resource/layout/role/save",
+	)
+}
+
+// 获取卡片的标题
+func (p *Template) metricTitle(metric interface{}) string {
+	title := reflect.ValueOf(metric).Elem().FieldByName("Title")
+	if !title.IsValid() || title.Kind() != reflect.String {
+		return p.GetMetricUriKey(metric)
+	}
+
+	return title.String()
+}
+
+// 获取管理员有权限的卡片，并初始化卡片
+func (p *Template) availableMetrics(ctx *builder.Context, adminId int) []interface{} {
+	template := ctx.Template.(Dashboarder)
+
+	metrics := []interface{}{}
+	for _, v := range template.Cards(ctx) {
+		if p.canSeeMetric(ctx, adminId, p.GetMetricUriKey(v)) {
+			p.initMetric(ctx, v, "")
+			metrics = append(metrics, v)
+		}
+	}
+
+	return metrics
+}
+
+// 按布局排列卡片，返回卡片及布局中设置的宽度，宽度为0时使用卡片的默认宽度
+//
+// 布局为nil时使用全部卡片，布局中不存在或无权限的卡片不展示
+func (p *Template) arrangeMetrics(metrics []interface{}, layout []*model.DashboardCard) ([]interface{}, []int) {
+	if layout == nil {
+		return metrics, make([]int, len(metrics))
+	}
+
+	arranged := []interface{}{}
+	cols := []int{}
+	for _, card := range layout {
+		if metric := p.findMetric(metrics, card.Key); metric != nil {
+			arranged = append(arranged, metric)
+			cols = append(cols, card.Col)
+		}
+	}
+
+	return arranged, cols
+}
+
+// 按管理员的布局排列有权限的卡片，管理员未设置布局时使用角色的布局
+func (p *Template) layoutMetrics(ctx *builder.Context, adminId int) ([]interface{}, []int, error) {
+	layout, err := (&model.DashboardLayout{}).GetAdminCards(ctx.Param("resource"), adminId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics, cols := p.arrangeMetrics(p.availableMetrics(ctx, adminId), layout)
+
+	return metrics, cols, nil
+}
+
+// 布局中的卡片信息
+func (p *Template) layoutCards(metrics []interface{}, cols []int) []*LayoutCard {
+	cards := []*LayoutCard{}
+	for k, v := range metrics {
+		col := cols[k]
+		if col == 0 {
+			col = p.metricCol(v)
+		}
+		cards = append(cards, &LayoutCard{
+			Key:   p.GetMetricUriKey(v),
+			Title: p.metricTitle(v),
+			Col:   col,
+		})
+	}
+
+	return cards
+}
+
+// 检查布局中的卡片，卡片必须在可选的卡片中且不能重复，宽度为1-24，为0时使用卡片的默认宽度
+func (p *Template) checkLayout(cards []*model.DashboardCard, metrics []interface{}) error {
+	keys := map[string]bool{}
+	for _, v := range metrics {
+		keys[p.GetMetricUriKey(v)] = true
+	}
+
+	used := map[string]bool{}
+	for _, card := range cards {
+		if card == nil || !keys[card.Key] {
+			return i18n.NewError("dashboard.layout_card_invalid")
+		}
+		if used[card.Key] {
+			return i18n.NewError("dashboard.layout_card_duplicate", card.Key)
+		}
+		if card.Col < 0 || card.Col > 24 {
+			return i18n.NewError("dashboard.layout_col_invalid")
+		}
+		used[card.Key] = true
+	}
+
+	return nil
+}
+
+// 获取布局，参数：roleId为角色id，设置时获取角色的默认布局，需要有保存角色布局的权限
+//
+// 返回数据中cards为当前布局的卡片，available为可以添加的全部卡片
+func (p *Template) Layout(ctx *builder.Context) error {
+	adminId, err := p.adminId(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 角色的默认布局或当前管理员的布局
+	available := p.availableMetrics(ctx, adminId)
+	layout, err := (&model.DashboardLayout{}).GetAdminCards(ctx.Param("resource"), adminId)
+	if roleId, _ := strconv.Atoi(ctx.QueryParam("roleId")); roleId > 0 {
+		if !p.canManageRoleLayout(ctx, adminId) {
+			return ctx.JSON(200, message.Error(ctx.T("message.forbidden")))
+		}
+		layout, err = (&model.DashboardLayout{}).GetCards(ctx.Param("resource"), model.DashboardLayoutRole, roleId)
+	}
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	metrics, cols := p.arrangeMetrics(available, layout)
+
+	return ctx.JSON(200, message.Success(ctx.T("message.fetch_success"), "", map[string]interface{}{
+		"cards":     p.layoutCards(metrics, cols),
+		"available": p.layoutCards(available, make([]int, len(available))),
+	}))
+}
+
+// 保存当前管理员的布局，请求数据：{"cards":[{"key":"log-trend","col":12}]}
+func (p *Template) SaveLayout(ctx *builder.Context) error {
+	data := &LayoutRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	adminId, err := p.adminId(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = p.checkLayout(data.Cards, p.availableMetrics(ctx, adminId))
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = (&model.DashboardLayout{}).SaveCards(ctx.Param("resource"), model.DashboardLayoutAdmin, adminId, data.Cards)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 恢复当前管理员的布局为角色或仪表盘的默认布局
+func (p *Template) ResetLayout(ctx *builder.Context) error {
+	adminId, err := p.adminId(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = (&model.DashboardLayout{}).DeleteCards(ctx.Param("resource"), model.DashboardLayoutAdmin, adminId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 保存角色的默认布局，请求数据：{"roleId":1,"cards":[{"key":"log-trend","col":12}]}
+func (p *Template) SaveRoleLayout(ctx *builder.Context) error {
+	data := &LayoutRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	roles, err := (&model.Role{}).GetListByIds([]int{data.RoleId})
+	if err != nil || len(roles) == 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	// 角色的布局可以包含仪表盘的全部卡片，展示时仍然检查管理员的权限
+	template := ctx.Template.(Dashboarder)
+	err = p.checkLayout(data.Cards, template.Cards(ctx))
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = (&model.DashboardLayout{}).SaveCards(ctx.Param("resource"), model.DashboardLayoutRole, data.RoleId, data.Cards)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
+
+// 恢复角色的布局为仪表盘的默认布局，请求数据：{"roleId":1}
+func (p *Template) ResetRoleLayout(ctx *builder.Context) error {
+	data := &LayoutRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	if data.RoleId <= 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	err := (&model.DashboardLayout{}).DeleteCards(ctx.Param("resource"), model.DashboardLayoutRole, data.RoleId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
+}
@@ -0,0 +1,312 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobeam/stringy"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/card"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/descriptions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/dropdown"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/menu"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/statistic"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/tpl"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/dashboard/metrics"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 支持选择时间范围的指标
+type metricRanger interface {
+	GetRanges() []string
+	SetRange(key string)
+	GetRange() string
+}
+
+// 包含内置文本，需要按语言翻译的指标
+type metricLocaler interface {
+	SetLocale(locale string)
+	GetLocale() string
+}
+
+// 支持异步加载、缓存及自动刷新的指标，嵌入metrics.Metrics的指标均实现了该接口
+type metricOptioner interface {
+	GetLazy() bool
+	GetCacheTTL() time.Duration
+	GetTimeout() time.Duration
+	GetRefreshInterval() int
+}
+
+// 卡片的计算结果
+type metricResult struct {
+	body []byte
+	err  error
+}
+
+// 正在计算中的卡片，相同的卡片同时只计算一次
+type metricCall struct {
+	wg     sync.WaitGroup
+	result *metricResult
+}
+
+var (
+	metricCallsMu sync.Mutex
+	metricCalls   = map[string]*metricCall{}
+)
+
+// 卡片key
+func (p *Template) GetMetricUriKey(metric interface{}) string {
+	uriKey := reflect.TypeOf(metric).String()
+	uriKeys := strings.Split(uriKey, ".")
+
+	return stringy.New(uriKeys[len(uriKeys)-1]).KebabCase("?", "").ToLower()
+}
+
+// 根据key查找卡片
+func (p *Template) findMetric(cards []interface{}, uriKey string) interface{} {
+	for _, v := range cards {
+		if p.GetMetricUriKey(v) == uriKey {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// 初始化指标，使卡片的宽度、缓存等配置在计算前可用，并设置当前的语言及选择的时间范围
+func (p *Template) initMetric(ctx *builder.Context, metric interface{}, rangeKey string) {
+	init := reflect.ValueOf(metric).MethodByName("Init")
+	if init.IsValid() && init.Type().NumIn() == 0 {
+		init.Call(nil)
+	}
+
+	if localer, ok := metric.(metricLocaler); ok {
+		localer.SetLocale(ctx.Locale())
+	}
+
+	if ranger, ok := metric.(metricRanger); ok {
+		ranger.SetRange(rangeKey)
+	}
+}
+
+// 获取卡片的宽度
+func (p *Template) metricCol(metric interface{}) int {
+	col := reflect.ValueOf(metric).Elem().FieldByName("Col")
+	if !col.IsValid() {
+		return 24
+	}
+
+	return int(col.Int())
+}
+
+// 计算卡片内容
+func (p *Template) calculateMetric(metric interface{}) (body interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = i18n.NewError("dashboard.metric_failed", fmt.Sprint(r))
+		}
+	}()
+
+	// 断言statistic组件类型
+	if statistic, ok := metric.(interface{ Calculate() *statistic.Component }); ok {
+		return statistic.Calculate(), nil
+	}
+
+	// 断言descriptions组件类型
+	if descriptions, ok := metric.(interface {
+		Calculate() *descriptions.Component
+	}); ok {
+		return descriptions.Calculate(), nil
+	}
+
+	// 断言组合组件类型
+	if space, ok := metric.(interface{ Calculate() *space.Component }); ok {
+		return space.Calculate(), nil
+	}
+
+	// 断言趋势、分区、进度等查询数据库的组件类型，查询出错时卡片展示错误
+	if space, ok := metric.(interface {
+		Calculate() (*space.Component, error)
+	}); ok {
+		return space.Calculate()
+	}
+
+	// 其他组件类型，例如：chart.Column、chart.Gauge，第二个返回值为error时作为计算的错误
+	calculate := reflect.ValueOf(metric).MethodByName("Calculate")
+	if calculate.IsValid() && calculate.Type().NumIn() == 0 && calculate.Type().NumOut() == 1 {
+		return calculate.Call(nil)[0].Interface(), nil
+	}
+	if calculate.IsValid() && calculate.Type().NumIn() == 0 && calculate.Type().NumOut() == 2 &&
+		calculate.Type().Out(1) == reflect.TypeOf((*error)(nil)).Elem() {
+		results := calculate.Call(nil)
+		if !results[1].IsNil() {
+			return nil, results[1].Interface().(error)
+		}
+		return results[0].Interface(), nil
+	}
+
+	return nil, nil
+}
+
+// 计算卡片内容并缓存，相同的卡片同时只计算一次
+func (p *Template) computeMetric(metric interface{}, cacheKey string, cacheTTL time.Duration) *metricResult {
+	metricCallsMu.Lock()
+	if call, ok := metricCalls[cacheKey]; ok {
+		metricCallsMu.Unlock()
+		call.wg.Wait()
+		return call.result
+	}
+	call := &metricCall{}
+	call.wg.Add(1)
+	metricCalls[cacheKey] = call
+	metricCallsMu.Unlock()
+
+	result := &metricResult{}
+	body, err := p.calculateMetric(metric)
+	if err == nil {
+		result.body, err = json.Marshal(body)
+	}
+	result.err = err
+	if err == nil && cacheTTL > 0 {
+		GetCache().Set(cacheKey, result.body, cacheTTL)
+	}
+
+	call.result = result
+	call.wg.Done()
+
+	metricCallsMu.Lock()
+	delete(metricCalls, cacheKey)
+	metricCallsMu.Unlock()
+
+	return result
+}
+
+// 获取卡片内容，优先使用缓存，超时后返回错误，未完成的计算会继续执行并写入缓存
+func (p *Template) metricBody(metric interface{}, resource string, uriKey string, timeout time.Duration) ([]byte, error) {
+	cacheKey := resource + ":" + uriKey
+	if ranger, ok := metric.(metricRanger); ok && ranger.GetRange() != "" {
+		cacheKey = cacheKey + ":" + ranger.GetRange()
+	}
+	if localer, ok := metric.(metricLocaler); ok && localer.GetLocale() != "" {
+		cacheKey = cacheKey + ":" + localer.GetLocale()
+	}
+
+	cacheTTL := time.Duration(0)
+	if optioner, ok := metric.(metricOptioner); ok {
+		cacheTTL = optioner.GetCacheTTL()
+		if optioner.GetTimeout() > 0 {
+			timeout = optioner.GetTimeout()
+		}
+	}
+
+	if cacheTTL > 0 {
+		if body, ok := GetCache().Get(cacheKey); ok {
+			return body, nil
+		}
+	}
+
+	done := make(chan *metricResult, 1)
+	go func() {
+		done <- p.computeMetric(metric, cacheKey, cacheTTL)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result.body, result.err
+	case <-timer.C:
+		return nil, i18n.NewError("dashboard.metric_timeout")
+	}
+}
+
+// 卡片的接口
+func (p *Template) metricApi(ctx *builder.Context, metric interface{}, uriKey string) string {
+	api := "/api/admin/dashboard/" + ctx.Param("resource") + "/metric/" + uriKey
+	if ranger, ok := metric.(metricRanger); ok && ranger.GetRange() != "" {
+		api = api + "?range=" + url.QueryEscape(ranger.GetRange())
+	}
+
+	return api
+}
+
+// 时间范围下拉菜单，选择后携带range参数重新加载仪表盘
+func (p *Template) rangeDropdown(ctx *builder.Context, uriKey string, ranger metricRanger) *dropdown.Component {
+	var items []interface{}
+	for _, v := range ranger.GetRanges() {
+		query := url.Values{}
+		for key, values := range ctx.QueryParams() {
+			if key != "range" {
+				query[key] = values
+			}
+		}
+		query.Set("range["+uriKey+"]", v)
+
+		items = append(items, (&menu.Item{}).
+			Init().
+			SetLabel(metrics.GetRangeLabel(ctx.Locale(), v)).
+			SetLink("#/layout/index?api="+url.QueryEscape("/api/admin/dashboard/"+ctx.Param("resource")+"/index?"+query.Encode()), "_self"))
+	}
+
+	return (&dropdown.Component{}).
+		Init().
+		SetLabel(metrics.GetRangeLabel(ctx.Locale(), ranger.GetRange())).
+		SetMenu((&menu.Component{}).Init().SetItems(items)).
+		SetType("link", false).
+		SetSize("small")
+}
+
+// 卡片组件，body为nil时渲染为加载中的占位卡片
+func (p *Template) metricCard(ctx *builder.Context, metric interface{}, uriKey string, body []byte, err error) *card.Component {
+	item := (&card.Component{}).Init()
+
+	switch {
+	case err != nil:
+		item = item.SetBody((&tpl.Component{}).Init().SetBody(ctx.TError(err)))
+	case body == nil:
+		item = item.SetLoading(true)
+	default:
+		item = item.SetBody(json.RawMessage(body))
+	}
+
+	// 时间范围选择
+	if ranger, ok := metric.(metricRanger); ok && len(ranger.GetRanges()) > 0 {
+		item = item.SetExtra(p.rangeDropdown(ctx, uriKey, ranger))
+	}
+
+	// 异步加载、出错或需要自动刷新的卡片可以通过接口重新获取内容
+	optioner, ok := metric.(metricOptioner)
+	if body == nil || (ok && optioner.GetRefreshInterval() > 0) {
+		item = item.SetApi(p.metricApi(ctx, metric, uriKey))
+	}
+	if ok {
+		item = item.SetRefreshInterval(optioner.GetRefreshInterval())
+	}
+
+	return item
+}
+
+// 单个卡片的渲染，参数：range为选择的时间范围，返回完整的卡片组件
+func (p *Template) MetricRender(ctx *builder.Context) error {
+	template := ctx.Template.(Dashboarder)
+
+	cards := template.Cards(ctx)
+	uriKey := ctx.Param("metric")
+	metric := p.findMetric(cards, uriKey)
+	if metric == nil {
+		return ctx.JSON(200, message.Error(ctx.T("dashboard.metric_not_found")))
+	}
+
+	p.initMetric(ctx, metric, ctx.QueryParam("range"))
+	body, err := p.metricBody(metric, ctx.Param("resource"), uriKey, template.GetTimeout())
+
+	return ctx.JSON(200, p.metricCard(ctx, metric, uriKey, body, err))
+}
@@ -1,6 +1,139 @@
 package metrics
 
+import (
+	"math"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/statistic"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
 type Metrics struct {
-	Title string
-	Col   int
+	Title           string
+	Col             int
+	Ranges          []string      // 可选的时间范围，第一个为默认值，例如：[]string{RangeLast7Days, RangeThisMonth}
+	Range           string        // 当前选择的时间范围
+	Lazy            bool          // 是否异步加载，页面先渲染占位卡片，再通过卡片接口加载内容
+	CacheTTL        time.Duration // 计算结果的缓存时间，为0时不缓存，缓存在使用相同语言的管理员间共享
+	Timeout         time.Duration // 计算的超时时间，为0时使用仪表盘的默认值
+	RefreshInterval int           // 前端自动刷新的间隔，单位秒，为0时不刷新
+	Locale          string        // 当前的语言，用于翻译卡片中的内置文本
+}
+
+// 是否异步加载
+func (p *Metrics) GetLazy() bool {
+	return p.Lazy
+}
+
+// 获取计算结果的缓存时间
+func (p *Metrics) GetCacheTTL() time.Duration {
+	return p.CacheTTL
+}
+
+// 获取计算的超时时间
+func (p *Metrics) GetTimeout() time.Duration {
+	return p.Timeout
+}
+
+// 获取前端自动刷新的间隔
+func (p *Metrics) GetRefreshInterval() int {
+	return p.RefreshInterval
+}
+
+// 获取可选的时间范围
+func (p *Metrics) GetRanges() []string {
+	return p.Ranges
+}
+
+// 设置当前选择的时间范围
+func (p *Metrics) SetRange(key string) {
+	p.Range = key
+}
+
+// 获取当前的时间范围，未选择或不在可选范围中时使用默认值，没有可选范围时返回空字符串
+func (p *Metrics) GetRange() string {
+	for _, v := range p.Ranges {
+		if v == p.Range {
+			return v
+		}
+	}
+	if len(p.Ranges) > 0 {
+		return p.Ranges[0]
+	}
+
+	return ""
+}
+
+// 设置当前的语言
+func (p *Metrics) SetLocale(locale string) {
+	p.Locale = locale
+}
+
+// 获取当前的语言
+func (p *Metrics) GetLocale() string {
+	return p.Locale
+}
+
+// 按当前的语言翻译
+func (p *Metrics) T(key string, args ...interface{}) string {
+	return i18n.T(p.Locale, key, args...)
+}
+
+// 统计值组件
+func (p *Metrics) valueStatistic(value float64, precision int) *statistic.Component {
+	return (&statistic.Component{}).
+		Init().
+		SetTitle(p.Title).
+		SetValue(round(value, precision)).
+		SetPrecision(precision)
+}
+
+// 当前时间段的统计值，有时间范围时同时展示与上一时间段相比的变化
+func (p *Metrics) summary(component *statistic.Component, value float64, previous float64) *space.Component {
+	body := []interface{}{component}
+	if p.GetRange() != "" {
+		body = append(body, p.compare(value, previous))
+	}
+
+	return (&space.Component{}).Init().SetSize("large").SetBody(body)
+}
+
+// 与上一时间段相比的变化率，上一时间段为0时无法计算
+func (p *Metrics) compare(value float64, previous float64) *statistic.Component {
+	component := (&statistic.Component{}).Init().SetTitle(p.T("dashboard.compare_previous"))
+
+	change, ok := Change(value, previous)
+	if !ok {
+		return component.SetValue("-")
+	}
+
+	component = component.
+		SetValue(round(change*100, 2)).
+		SetPrecision(2).
+		SetSuffix("%")
+	switch {
+	case change > 0:
+		component = component.SetPrefix("+").SetValueStyle(map[string]string{"color": "#3f8600"})
+	case change < 0:
+		component = component.SetValueStyle(map[string]string{"color": "#cf1322"})
+	}
+
+	return component
+}
+
+// 计算变化率，上一时间段为0时返回false
+func Change(value float64, previous float64) (float64, bool) {
+	if previous == 0 {
+		return 0, false
+	}
+
+	return (value - previous) / math.Abs(previous), true
+}
+
+// 按精度四舍五入
+func round(value float64, precision int) float64 {
+	pow := math.Pow10(precision)
+
+	return math.Round(value*pow) / pow
 }
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"gorm.io/gorm"
+)
+
+type Partition struct {
+	Metrics
+	DateColumn string            // 日期字段，设置了可选的时间范围时按该字段筛选
+	Labels     map[string]string // 分组值对应的名称，例如：map[string]string{"1": "正常", "0": "禁用"}
+	Limit      int               // 最多展示的分组数量，其余合并为“其他”，平均值统计时直接截断，为0时不限制
+	Precision  int
+}
+
+// 分组的统计结果
+type partitionRow struct {
+	Label    sql.NullString
+	Value    sql.NullFloat64
+	Previous sql.NullFloat64
+}
+
+// 按字段分组统计记录条数
+func (p *Partition) Count(DB *gorm.DB, column string) (*space.Component, error) {
+	return p.aggregate(DB, "COUNT", column, "")
+}
+
+// 按字段分组统计字段的合计
+func (p *Partition) Sum(DB *gorm.DB, column string, sumColumn string) (*space.Component, error) {
+	return p.aggregate(DB, "SUM", column, sumColumn)
+}
+
+// 按字段分组统计字段的平均值
+func (p *Partition) Average(DB *gorm.DB, column string, averageColumn string) (*space.Component, error) {
+	return p.aggregate(DB, "AVG", column, averageColumn)
+}
+
+// 分组值的名称
+func (p *Partition) label(value sql.NullString) string {
+	if label, ok := p.Labels[value.String]; ok {
+		return label
+	}
+	if !value.Valid || value.String == "" {
+		return p.T("dashboard.partition_unknown")
+	}
+
+	return value.String
+}
+
+// 分组统计当前及上一时间段的结果，查询出错时返回错误
+func (p *Partition) aggregate(DB *gorm.DB, fn string, column string, value string) (*space.Component, error) {
+	value = aggregateValue(fn, value)
+	query := DB.Session(&gorm.Session{})
+	totalQuery := DB.Session(&gorm.Session{})
+
+	selects := []string{column + " AS label"}
+	args := []interface{}{}
+	totalSelects := []string{}
+	totalArgs := []interface{}{}
+
+	current, previous := GetPeriods(p.GetRange(), time.Now())
+	if current != nil && p.DateColumn != "" {
+		for _, period := range []*Period{current, previous} {
+			expr, exprArgs := period.expr(fn, p.DateColumn, value)
+			selects = append(selects, expr)
+			args = append(args, exprArgs...)
+			totalSelects = append(totalSelects, expr)
+			totalArgs = append(totalArgs, exprArgs...)
+		}
+		condition, conditionArgs := (&Period{Start: previous.Start, End: current.End}).condition(p.DateColumn)
+		query = query.Where(condition, conditionArgs...)
+		totalQuery = totalQuery.Where(condition, conditionArgs...)
+		selects[1] = selects[1] + " AS value"
+		selects[2] = selects[2] + " AS previous"
+	} else {
+		selects = append(selects, fn+"("+value+") AS value", "0 AS previous")
+		totalSelects = append(totalSelects, fn+"("+value+")", "0")
+	}
+
+	results := []*partitionRow{}
+	err := query.Select(strings.Join(selects, ", "), args...).Group(column).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 只有上一时间段数据的分组不展示
+	rows := []*partitionRow{}
+	for _, row := range results {
+		if row.Value.Float64 != 0 {
+			rows = append(rows, row)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Value.Float64 > rows[j].Value.Float64
+	})
+
+	data := []map[string]interface{}{}
+	other := map[string]interface{}{"type": p.T("dashboard.partition_other"), "value": 0.0, "previous": 0.0}
+	for k, row := range rows {
+		if p.Limit > 0 && k >= p.Limit {
+			if fn != "AVG" {
+				other["value"] = other["value"].(float64) + row.Value.Float64
+				other["previous"] = other["previous"].(float64) + row.Previous.Float64
+			}
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"type":     p.label(row.Label),
+			"value":    round(row.Value.Float64, p.Precision),
+			"previous": round(row.Previous.Float64, p.Precision),
+		})
+	}
+	if p.Limit > 0 && len(rows) > p.Limit && fn != "AVG" {
+		other["value"] = round(other["value"].(float64), p.Precision)
+		other["previous"] = round(other["previous"].(float64), p.Precision)
+		data = append(data, other)
+	}
+
+	totals, err := aggregate(totalQuery, totalSelects, totalArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Result(data, totals[0], totals[1]), nil
+}
+
+// 包含组件的结果，data为饼图数据，包含type、value字段
+func (p *Partition) Result(data interface{}, value float64, previous float64) *space.Component {
+	pie := chart.NewPie(data).
+		SetAngleField("value").
+		SetColorField("type").
+		SetHeight(200).
+		SetAutoFit(true).
+		SetInnerRadius(0.6).
+		SetMeta(map[string]interface{}{
+			"value": map[string]interface{}{"alias": p.Title},
+		})
+
+	return (&space.Component{}).
+		Init().
+		SetDirection("vertical").
+		SetStyle(map[string]interface{}{"width": "100%"}).
+		SetBody([]interface{}{p.summary(p.valueStatistic(value, p.Precision), value, previous), pie})
+}
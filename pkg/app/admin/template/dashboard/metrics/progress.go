@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"gorm.io/gorm"
+)
+
+type Progress struct {
+	Metrics
+	DateColumn string  // 日期字段，设置了可选的时间范围时按该字段筛选
+	Target     float64 // 目标值，为0时不展示进度
+	Precision  int
+}
+
+// 统计记录条数与目标值的比较
+func (p *Progress) Count(DB *gorm.DB) (*space.Component, error) {
+	return p.aggregate(DB, "COUNT", "")
+}
+
+// 统计字段的合计与目标值的比较
+func (p *Progress) Sum(DB *gorm.DB, column string) (*space.Component, error) {
+	return p.aggregate(DB, "SUM", column)
+}
+
+// 统计当前及上一时间段的结果，查询出错时返回错误
+func (p *Progress) aggregate(DB *gorm.DB, fn string, column string) (*space.Component, error) {
+	value := aggregateValue(fn, column)
+
+	current, previous := GetPeriods(p.GetRange(), time.Now())
+	if current == nil || p.DateColumn == "" {
+		results, err := aggregate(DB, []string{fn + "(" + value + ")"}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return p.Result(results[0], 0), nil
+	}
+
+	selects := []string{}
+	args := []interface{}{}
+	for _, period := range []*Period{current, previous} {
+		expr, exprArgs := period.expr(fn, p.DateColumn, value)
+		selects = append(selects, expr)
+		args = append(args, exprArgs...)
+	}
+	condition, conditionArgs := (&Period{Start: previous.Start, End: current.End}).condition(p.DateColumn)
+	results, err := aggregate(DB.Where(condition, conditionArgs...), selects, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Result(results[0], results[1]), nil
+}
+
+// 完成的百分比，目标值为0时返回0
+func (p *Progress) Percent(value float64) float64 {
+	if p.Target <= 0 {
+		return 0
+	}
+
+	return value / p.Target
+}
+
+// 包含组件的结果，previous为上一时间段的值，没有时间范围时不使用
+func (p *Progress) Result(value float64, previous float64) *space.Component {
+	component := p.valueStatistic(value, p.Precision)
+	if p.Target > 0 {
+		component = component.SetSuffix("/ " + strconv.FormatFloat(round(p.Target, p.Precision), 'f', -1, 64))
+	}
+
+	body := []interface{}{p.summary(component, value, previous)}
+	if p.Target > 0 {
+		body = append(body, chart.NewProgress(p.Percent(value)).SetHeight(40))
+	}
+
+	return (&space.Component{}).
+		Init().
+		SetDirection("vertical").
+		SetStyle(map[string]interface{}{"width": "100%"}).
+		SetBody(body)
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"gorm.io/gorm"
+)
+
+// 时间范围，"Nd"格式表示最近N天
+const (
+	RangeLast7Days  = "7d"
+	RangeLast30Days = "30d"
+	RangeLast90Days = "90d"
+	RangeThisMonth  = "month"
+)
+
+// 默认的可选时间范围
+var DefaultRanges = []string{RangeLast7Days, RangeLast30Days, RangeLast90Days, RangeThisMonth}
+
+// 时间范围名称的翻译键，未找到翻译时直接展示
+var RangeLabels = map[string]string{
+	RangeLast7Days:  "dashboard.range.7d",
+	RangeLast30Days: "dashboard.range.30d",
+	RangeLast90Days: "dashboard.range.90d",
+	RangeThisMonth:  "dashboard.range.month",
+}
+
+// 时间段，包含开始时间，不包含结束时间
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// 获取时间范围在指定语言中的名称
+func GetRangeLabel(locale string, key string) string {
+	if label, ok := RangeLabels[key]; ok {
+		return i18n.T(locale, label)
+	}
+	if days := rangeDays(key); days > 0 {
+		return i18n.T(locale, "dashboard.range.days", days)
+	}
+
+	return key
+}
+
+// 解析"Nd"格式的时间范围，格式错误时返回0
+func rangeDays(key string) int {
+	if !strings.HasSuffix(key, "d") {
+		return 0
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(key, "d"))
+	if err != nil || days <= 0 {
+		return 0
+	}
+
+	return days
+}
+
+// 根据时间范围获取当前时间段及用于比较的上一时间段，时间段以天为单位并包含今天，无法解析时返回nil
+func GetPeriods(key string, now time.Time) (current *Period, previous *Period) {
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	// 本月与上月的相同天数比较
+	if key == RangeThisMonth {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		previousStart := start.AddDate(0, -1, 0)
+		previousEnd := previousStart.AddDate(0, 0, now.Day())
+		if previousEnd.After(start) {
+			previousEnd = start
+		}
+
+		return &Period{Start: start, End: end}, &Period{Start: previousStart, End: previousEnd}
+	}
+
+	days := rangeDays(key)
+	if days == 0 {
+		return nil, nil
+	}
+	start := end.AddDate(0, 0, -days)
+
+	return &Period{Start: start, End: end}, &Period{Start: start.AddDate(0, 0, -days), End: start}
+}
+
+// 时间段的查询条件
+func (p *Period) condition(column string) (string, []interface{}) {
+	return column + " >= ? AND " + column + " < ?", []interface{}{p.Start, p.End}
+}
+
+// 时间段内的聚合表达式，fn为COUNT、SUM、AVG，不在时间段内的值为NULL，不参与聚合
+func (p *Period) expr(fn string, column string, value string) (string, []interface{}) {
+	condition, args := p.condition(column)
+
+	return fn + "(CASE WHEN " + condition + " THEN " + value + " END)", args
+}
+
+// 执行只返回一行的聚合查询，使用CASE表达式代替各数据库不同的日期函数，兼容MySQL、SQLite、Postgres，NULL结果返回0
+func aggregate(DB *gorm.DB, selects []string, args []interface{}) ([]float64, error) {
+	results := make([]float64, len(selects))
+	rows, err := DB.
+		Session(&gorm.Session{}).
+		Select(strings.Join(selects, ", "), args...).
+		Rows()
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return results, rows.Err()
+	}
+
+	values := make([]sql.NullFloat64, len(selects))
+	dest := make([]interface{}, len(selects))
+	for k := range values {
+		dest[k] = &values[k]
+	}
+	err = rows.Scan(dest...)
+	for k, v := range values {
+		results[k] = v.Float64
+	}
+
+	return results, err
+}
+
+// 聚合值字段，COUNT时统计记录条数
+func aggregateValue(fn string, column string) string {
+	if fn == "COUNT" || column == "" {
+		return "1"
+	}
+
+	return column
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/chart"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/space"
+	"gorm.io/gorm"
+)
+
+// 趋势的统计单位
+const (
+	TrendByDays   = "day"
+	TrendByWeeks  = "week"
+	TrendByMonths = "month"
+)
+
+type Trend struct {
+	Metrics
+	Unit      string // 统计单位：day、week、month，默认day
+	Precision int
+}
+
+// 按天统计
+func (p *Trend) ByDays() *Trend {
+	p.Unit = TrendByDays
+	return p
+}
+
+// 按周统计，每周从周一开始
+func (p *Trend) ByWeeks() *Trend {
+	p.Unit = TrendByWeeks
+	return p
+}
+
+// 按月统计
+func (p *Trend) ByMonths() *Trend {
+	p.Unit = TrendByMonths
+	return p
+}
+
+// 按时间统计记录条数，column为日期字段
+func (p *Trend) Count(DB *gorm.DB, column string) (*space.Component, error) {
+	return p.aggregate(DB, "COUNT", column, "")
+}
+
+// 按时间统计字段的合计，column为日期字段
+func (p *Trend) Sum(DB *gorm.DB, column string, sumColumn string) (*space.Component, error) {
+	return p.aggregate(DB, "SUM", column, sumColumn)
+}
+
+// 按时间统计字段的平均值，column为日期字段
+func (p *Trend) Average(DB *gorm.DB, column string, averageColumn string) (*space.Component, error) {
+	return p.aggregate(DB, "AVG", column, averageColumn)
+}
+
+// 将时间段按统计单位拆分
+func (p *Trend) buckets(period *Period) []*Period {
+	buckets := []*Period{}
+	for start := period.Start; start.Before(period.End); {
+		var end time.Time
+		switch p.Unit {
+		case TrendByWeeks:
+			days := (8 - int(start.Weekday())) % 7
+			if days == 0 {
+				days = 7
+			}
+			end = time.Date(start.Year(), start.Month(), start.Day()+days, 0, 0, 0, 0, start.Location())
+		case TrendByMonths:
+			end = time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+		default:
+			end = time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+		}
+		if end.After(period.End) {
+			end = period.End
+		}
+		buckets = append(buckets, &Period{Start: start, End: end})
+		start = end
+	}
+
+	return buckets
+}
+
+// 时间段的名称
+func (p *Trend) label(period *Period) string {
+	if p.Unit == TrendByMonths {
+		return period.Start.Format("2006-01")
+	}
+
+	return period.Start.Format("2006-01-02")
+}
+
+// 在一次查询中统计每个时间段、当前及上一时间段的结果，查询出错时返回错误
+func (p *Trend) aggregate(DB *gorm.DB, fn string, column string, value string) (*space.Component, error) {
+	if len(p.Ranges) == 0 {
+		p.Ranges = DefaultRanges
+	}
+
+	current, previous := GetPeriods(p.GetRange(), time.Now())
+	if current == nil {
+		current, previous = GetPeriods(RangeLast30Days, time.Now())
+	}
+	buckets := p.buckets(current)
+	value = aggregateValue(fn, value)
+
+	selects := []string{}
+	args := []interface{}{}
+	for _, period := range append(buckets, current, previous) {
+		expr, exprArgs := period.expr(fn, column, value)
+		selects = append(selects, expr)
+		args = append(args, exprArgs...)
+	}
+
+	condition, conditionArgs := (&Period{Start: previous.Start, End: current.End}).condition(column)
+	results, err := aggregate(DB.Where(condition, conditionArgs...), selects, args)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []map[string]interface{}{}
+	for k, period := range buckets {
+		data = append(data, map[string]interface{}{
+			"date":  p.label(period),
+			"value": round(results[k], p.Precision),
+		})
+	}
+
+	return p.Result(data, results[len(buckets)], results[len(buckets)+1]), nil
+}
+
+// 包含组件的结果，data为折线图数据，包含date、value字段
+func (p *Trend) Result(data interface{}, value float64, previous float64) *space.Component {
+	line := chart.NewLine(data).
+		SetXField("date").
+		SetYField("value").
+		SetHeight(200).
+		SetAutoFit(true).
+		SetSmooth(true).
+		SetMeta(map[string]interface{}{
+			"value": map[string]interface{}{"alias": p.Title},
+		})
+
+	return (&space.Component{}).
+		Init().
+		SetDirection("vertical").
+		SetStyle(map[string]interface{}{"width": "100%"}).
+		SetBody([]interface{}{p.summary(p.valueStatistic(value, p.Precision), value, previous), line})
+}
@@ -73,8 +73,8 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	// 使用 IconFont 的图标配置
 	p.IconfontUrl = "//at.alicdn.com/t/font_1615691_3pgkh5uyob.js"
 
-	// 当前 layout 的语言设置，'zh-CN' | 'zh-TW' | 'en-US'
-	p.Locale = "zh-CN"
+	// 当前 layout 的语言设置，'zh-CN' | 'zh-TW' | 'en-US'，默认跟随当前请求的语言
+	p.Locale = ctx.Locale()
 
 	// 侧边菜单宽度
 	p.SiderWidth = 208
@@ -105,7 +105,7 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	p.RightMenus = []interface{}{
 		action.
 			New().
-			SetLabel("个人设置").
+			SetLabel(ctx.T("layout.account_setting")).
 			SetActionType("link").
 			SetType("link", false).
 			SetIcon("setting").
@@ -117,7 +117,7 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 
 		action.
 			New().
-			SetLabel("退出登录").
+			SetLabel(ctx.T("layout.logout")).
 			SetActionType("ajax").
 			SetType("link", false).
 			SetIcon("logout").
@@ -225,7 +225,7 @@ func (p *Template) GetMenus(ctx *builder.Context) (list interface{}, err error)
 	}
 
 	// 获取管理员菜单
-	return admin.GetMenuListById(adminInfo.Id)
+	return admin.GetMenuListById(adminInfo.Id, ctx.Locale())
 }
 
 // 组件渲染
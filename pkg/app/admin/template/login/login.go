@@ -48,7 +48,7 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	p.Redirect = "/layout/index?api=/api/admin/dashboard/index/index"
 
 	// 子标题
-	p.SubTitle = "信息丰富的世界里，唯一稀缺的就是人类的注意力"
+	p.SubTitle = ctx.T("login.subtitle")
 
 	// 如果启动了redis缓存，验证码使用redis缓存
 	if redisclient.Client != nil {
@@ -100,7 +100,7 @@ func (p *Template) GetSubTitle() string {
 // 验证码ID
 func (p *Template) CaptchaId(ctx *builder.Context) error {
 
-	return ctx.JSON(200, message.Success("操作成功", "", map[string]string{
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", map[string]string{
 		"captchaId": captcha.NewLen(4),
 	}))
 }
@@ -122,12 +122,12 @@ func (p *Template) Fields(ctx *builder.Context) []interface{} {
 
 // 登录方法
 func (p *Template) Handle(ctx *builder.Context) error {
-	return ctx.JSON(200, message.Error("请实现登录方法"))
+	return ctx.JSON(200, message.Error(ctx.T("login.not_implemented")))
 }
 
 // 退出方法
 func (p *Template) Logout(ctx *builder.Context) error {
-	return ctx.JSON(200, message.Success("退出成功", "/"))
+	return ctx.JSON(200, message.Success(ctx.T("login.logout_success"), "/"))
 }
 
 // 包裹在组件内的创建页字段
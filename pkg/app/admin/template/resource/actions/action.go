@@ -109,7 +109,7 @@ func (p *Action) TemplateInit(ctx *builder.Context) interface{} {
 // 执行行为句柄
 func (p *Action) Handle(ctx *builder.Context, query *gorm.DB) error {
 
-	return ctx.JSON(200, message.Error("Method not implemented"))
+	return ctx.JSON(200, message.Error(ctx.T("message.method_not_implemented")))
 }
 
 // 行为key
@@ -17,8 +17,8 @@ func (p *ModalForm) TemplateInit(ctx *builder.Context) interface{} {
 	p.ActionType = "modalForm"
 	p.Width = 520
 	p.Reload = "table"
-	p.CancelText = "取消"
-	p.SubmitText = "提交"
+	p.CancelText = ctx.T("action.cancel")
+	p.SubmitText = ctx.T("action.submit")
 
 	return p
 }
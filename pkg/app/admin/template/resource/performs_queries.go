@@ -286,7 +286,10 @@ func (p *Template) IndexQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 // 更新查询
 func (p *Template) UpdateQuery(ctx *builder.Context, query *gorm.DB) *gorm.DB {
 	data := map[string]interface{}{}
-	ctx.Bind(&data)
+	err := ctx.Bind(&data)
+	if err != nil {
+		ctx.Logger().Warn("bind update query failed", "error", err)
+	}
 	if data != nil {
 		if data["id"] != nil {
 			query.Where("id = ?", data["id"])
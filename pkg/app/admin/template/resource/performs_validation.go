@@ -20,7 +20,7 @@ func (p *Template) ValidatorForCreation(ctx *builder.Context, data map[string]in
 	rules := p.RulesForCreation(ctx)
 
 	// 验证数据是否合法
-	validator := p.Validator(rules, data)
+	validator := p.Validator(ctx, rules, data)
 
 	// 验证成功后回调
 	p.AfterValidation(ctx, validator)
@@ -32,7 +32,7 @@ func (p *Template) ValidatorForCreation(ctx *builder.Context, data map[string]in
 }
 
 // 验证规则
-func (p *Template) Validator(rules []*rule.Rule, data map[string]interface{}) error {
+func (p *Template) Validator(ctx *builder.Context, rules []*rule.Rule, data map[string]interface{}) error {
 	var result error
 
 	for _, rule := range rules {
@@ -81,9 +81,9 @@ func (p *Template) Validator(rules []*rule.Rule, data map[string]interface{}) er
 				ignoreField = strings.ReplaceAll(ignoreField, "}", "")
 				ignoreValue := data[ignoreField]
 
-				db.Client.Table(table).Where(ignoreField+" <> ?", ignoreValue).Where(field+" = ?", fieldValue).Count(&count)
+				db.Client.WithContext(ctx.Context()).Table(table).Where(ignoreField+" <> ?", ignoreValue).Where(field+" = ?", fieldValue).Count(&count)
 			} else {
-				db.Client.Table(table).Where(field+" = ?", fieldValue).Count(&count)
+				db.Client.WithContext(ctx.Context()).Table(table).Where(field+" = ?", fieldValue).Count(&count)
 			}
 
 			if count > 0 {
@@ -224,7 +224,7 @@ func (p *Template) ValidatorForUpdate(ctx *builder.Context, data map[string]inte
 	rules := p.RulesForUpdate(ctx)
 
 	// 验证数据是否合法
-	validator := p.Validator(rules, data)
+	validator := p.Validator(ctx, rules, data)
 
 	// 验证成功后回调
 	p.AfterValidation(ctx, validator)
@@ -301,7 +301,7 @@ func (p *Template) ValidatorForImport(ctx *builder.Context, data map[string]inte
 	rules := p.RulesForImport(ctx)
 
 	// 验证数据是否合法
-	validator := p.Validator(rules, data)
+	validator := p.Validator(ctx, rules, data)
 
 	// 验证成功后回调
 	p.AfterValidation(ctx, validator)
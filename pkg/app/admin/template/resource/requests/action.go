@@ -5,6 +5,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -21,7 +22,7 @@ func (p *ActionRequest) Handle(ctx *builder.Context) error {
 	modelInstance := template.GetModel()
 
 	// Gorm对象
-	model := db.Client.Model(modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance)
 
 	// 查询条件
 	model = template.BuildActionQuery(ctx, model)
@@ -47,6 +48,7 @@ func (p *ActionRequest) Handle(ctx *builder.Context) error {
 			for _, dropdownAction := range dropdownActioner.GetActions() {
 				uriKey := dropdownActioner.GetUriKey(dropdownAction)
 				if ctx.Param("uriKey") == uriKey {
+					telemetry.IncResourceOperation(ctx.Param("resource"), "action", uriKey)
 					result = dropdownAction.(interface {
 						Handle(*builder.Context, *gorm.DB) error
 					}).Handle(ctx, model)
@@ -62,6 +64,7 @@ func (p *ActionRequest) Handle(ctx *builder.Context) error {
 			}
 		} else {
 			if ctx.Param("uriKey") == uriKey {
+				telemetry.IncResourceOperation(ctx.Param("resource"), "action", uriKey)
 				result = v.(interface {
 					Handle(*builder.Context, *gorm.DB) error
 				}).Handle(ctx, model)
@@ -123,5 +126,5 @@ func (p *ActionRequest) Values(ctx *builder.Context) error {
 		}
 	}
 
-	return ctx.JSON(200, message.Success("获取成功", "", data))
+	return ctx.JSON(200, message.Success(ctx.T("message.fetch_success"), "", data))
 }
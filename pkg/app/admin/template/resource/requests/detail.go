@@ -29,7 +29,7 @@ func (p *DetailRequest) FillData(ctx *builder.Context) map[string]interface{} {
 	modelInstance := template.GetModel()
 
 	// Gorm对象
-	model := db.Client.Model(&modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(&modelInstance)
 
 	// 创建详情页查询
 	query := template.BuildDetailQuery(ctx, model)
@@ -132,5 +132,5 @@ func (p *DetailRequest) Values(ctx *builder.Context) error {
 	// 显示前回调
 	data = template.BeforeDetailShowing(ctx, data)
 
-	return ctx.JSON(200, message.Success("获取成功", "", data))
+	return ctx.JSON(200, message.Success(ctx.T("message.fetch_success"), "", data))
 }
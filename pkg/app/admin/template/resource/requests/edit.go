@@ -29,7 +29,7 @@ func (p *EditRequest) FillData(ctx *builder.Context) map[string]interface{} {
 	modelInstance := template.GetModel()
 
 	// Gorm对象
-	model := db.Client.Model(&modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(&modelInstance)
 
 	// 创建编辑页查询
 	query := template.BuildEditQuery(ctx, model)
@@ -146,5 +146,5 @@ func (p *EditRequest) Values(ctx *builder.Context) error {
 		data[k] = v
 	}
 
-	return ctx.JSON(200, message.Success("获取成功", "", data))
+	return ctx.JSON(200, message.Success(ctx.T("message.fetch_success"), "", data))
 }
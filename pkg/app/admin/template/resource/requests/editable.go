@@ -20,12 +20,12 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 	// 获取所有Query数据
 	data := ctx.AllQuerys()
 	if data == nil {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	id = data["id"]
 	if id == nil {
-		return ctx.JSON(200, message.Error("id不能为空！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.id_required")))
 	}
 
 	// 模版实例
@@ -35,7 +35,7 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 	modelInstance := template.GetModel()
 
 	// 创建Gorm对象
-	model := db.Client.Model(&modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(&modelInstance)
 
 	// 解析数据
 	for k, v := range data {
@@ -52,11 +52,11 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 	}
 
 	if field == "" {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	if value == nil {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	// 创建表格行内编辑查询
@@ -74,5 +74,5 @@ func (p *EditableRequest) Handle(ctx *builder.Context) error {
 		return result
 	}
 
-	return ctx.JSON(200, message.Success("操作成功"))
+	return ctx.JSON(200, message.Success(ctx.T("message.success")))
 }
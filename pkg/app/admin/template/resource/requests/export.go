@@ -111,7 +111,7 @@ func (p *ExportRequest) QueryData(ctx *builder.Context) interface{} {
 	modelInstance := template.GetModel()
 
 	// 创建Gorm对象
-	model := db.Client.Model(modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance)
 
 	// 搜索项
 	searches := template.Searches(ctx)
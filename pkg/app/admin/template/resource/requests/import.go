@@ -39,17 +39,21 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 	var requestData HandleRequest
 
 	// 绑定数据
-	ctx.Bind(&requestData)
+	err := ctx.Bind(&requestData)
+	if err != nil {
+		ctx.Logger().Warn("bind import request failed", "error", err)
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
 
 	// 判断参数
 	if len(requestData.FileId) == 0 {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	// 判断参数
 	fileId := requestData.FileId[0].Id
 	if fileId == 0 {
-		return ctx.JSON(200, message.Error("参数错误！"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	// 模版实例
@@ -59,7 +63,7 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 	modelInstance := template.GetModel()
 
 	// 创建Gorm对象
-	model := db.Client.Model(modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance)
 
 	// 获取导入数据
 	importData, err := (&models.File{}).GetExcelData(fileId)
@@ -147,13 +151,14 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 
 	// 返回导入失败错误数据
 	if !importResult {
-		filePath := ctx.Engine.GetConfig().StaticPath + "/app/storage/failImports/"
+		// 失败数据保存在私有文件目录，通过带签名的临时地址下载
+		filePath := ctx.Engine.GetConfig().PrivatePath + "/failImports/"
 		fileName := rand.MakeAlphanumeric(40) + ".xlsx"
-		fileUrl := "//" + ctx.Host() + "/storage/failImports/" + fileName
+		fileUrl := "//" + ctx.Host() + builder.PrivateURL("failImports/"+fileName, builder.DefaultSignExpire)
 
 		// 不存在路径，则创建
 		if !file.IsExist(filePath) {
-			err := os.MkdirAll(filePath, 0666)
+			err := os.MkdirAll(filePath, 0755)
 			if err != nil {
 				return ctx.JSON(200, message.Error(err.Error()))
 			}
@@ -165,7 +170,7 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 		index, _ := f.NewSheet("Sheet1")
 
 		// 创建表头
-		importHead = append(importHead, "错误信息")
+		importHead = append(importHead, ctx.T("import.error_message"))
 		for i := 1; i <= len(importHead); i++ {
 			f.SetCellValue("Sheet1", excel.GenerateColumnLabel(i)+"1", importHead[i-1])
 		}
@@ -184,15 +189,15 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 
 		tpl1 := (&tpl.Component{}).
 			Init().
-			SetBody("导入总量: " + strconv.Itoa(importTotalNum))
+			SetBody(ctx.T("import.total", importTotalNum))
 
 		tpl2 := (&tpl.Component{}).
 			Init().
-			SetBody("成功数量: " + strconv.Itoa(importSuccessedNum))
+			SetBody(ctx.T("import.succeeded", importSuccessedNum))
 
 		tpl3 := (&tpl.Component{}).
 			Init().
-			SetBody("失败数量: <span style='color:#ff4d4f'>" + strconv.Itoa(importFailedNum) + "</span> <a href='" + fileUrl + "' target='_blank'>下载失败数据</a>")
+			SetBody(ctx.T("import.failed", importFailedNum, fileUrl))
 
 		component := (&space.Component{}).
 			Init().
@@ -211,7 +216,7 @@ func (p *ImportRequest) Handle(ctx *builder.Context, indexRoute string) error {
 		return ctx.JSON(200, component)
 	}
 
-	return ctx.JSON(200, message.Success("操作成功！", strings.Replace("/layout/index?api="+indexRoute, ":resource", ctx.Param("resource"), -1)))
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), strings.Replace("/layout/index?api="+indexRoute, ":resource", ctx.Param("resource"), -1)))
 }
 
 // 将表格数据转换成表单数据
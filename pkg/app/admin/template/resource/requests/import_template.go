@@ -1,9 +1,7 @@
 package requests
 
 import (
-	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 	"time"
 
@@ -33,7 +31,7 @@ func (p *ImportTemplateRequest) Handle(ctx *builder.Context) error {
 			FieldByName("Label").
 			String()
 
-		exportTitles = append(exportTitles, label+p.getFieldRemark(v))
+		exportTitles = append(exportTitles, label+p.getFieldRemark(ctx, v))
 	}
 
 	f := excelize.NewFile()
@@ -58,7 +56,7 @@ func (p *ImportTemplateRequest) Handle(ctx *builder.Context) error {
 }
 
 // 导入字段提示信息
-func (p *ImportTemplateRequest) getFieldRemark(field interface{}) string {
+func (p *ImportTemplateRequest) getFieldRemark(ctx *builder.Context, field interface{}) string {
 	remark := ""
 
 	component := reflect.
@@ -69,7 +67,7 @@ func (p *ImportTemplateRequest) getFieldRemark(field interface{}) string {
 
 	switch component {
 	case "inputNumberField":
-		remark = "数字格式"
+		remark = ctx.T("import.remark.number")
 	case "textField":
 		remark = ""
 	case "selectField":
@@ -84,35 +82,35 @@ func (p *ImportTemplateRequest) getFieldRemark(field interface{}) string {
 		}).GetOptionLabels()
 
 		if mode != "" {
-			remark = "可多选：" + optionLabels + "；多值请用“,”分割"
+			remark = ctx.T("import.remark.multiple", optionLabels)
 		} else {
-			remark = "可选：" + optionLabels
+			remark = ctx.T("import.remark.options", optionLabels)
 		}
 	case "cascaderField":
-		remark = "级联格式，例如：省，市，县"
+		remark = ctx.T("import.remark.cascader")
 	case "checkboxField":
 		optionLabels := field.(interface {
 			GetOptionLabels() string
 		}).GetOptionLabels()
 
-		remark = "可多选项：" + optionLabels + "；多值请用“,”分割"
+		remark = ctx.T("import.remark.multiple", optionLabels)
 	case "radioField":
 		optionLabels := field.(interface {
 			GetOptionLabels() string
 		}).GetOptionLabels()
 
-		remark = "可选项：" + optionLabels
+		remark = ctx.T("import.remark.options", optionLabels)
 	case "switchField":
 		optionLabels := field.(interface {
 			GetOptionLabels() string
 		}).GetOptionLabels()
 
-		remark = "可选项：" + optionLabels
+		remark = ctx.T("import.remark.options", optionLabels)
 	case "dateField":
-		remark = "日期格式，例如：1987-02-15"
+		remark = ctx.T("import.remark.date")
 
 	case "datetimeField":
-		remark = "日期时间格式，例如：1987-02-15 20:00:00"
+		remark = ctx.T("import.remark.datetime")
 	}
 
 	var rules []*rule.Rule
@@ -128,59 +126,59 @@ func (p *ImportTemplateRequest) getFieldRemark(field interface{}) string {
 		rules = append(rules, v.GetCreationRules()...)
 	}
 
-	ruleMessage := p.getFieldRuleMessage(rules)
+	ruleMessage := p.getFieldRuleMessage(ctx, rules)
 	if ruleMessage != "" {
-		remark = remark + " 条件：" + ruleMessage
+		remark = remark + ctx.T("import.remark.rules", ruleMessage)
 	}
 
 	if remark != "" {
-		remark = "（" + remark + "）"
+		remark = ctx.T("import.remark.wrap", remark)
 	}
 
 	return remark
 }
 
 // 导入字段的规则
-func (p *ImportTemplateRequest) getFieldRuleMessage(rules []*rule.Rule) string {
+func (p *ImportTemplateRequest) getFieldRuleMessage(ctx *builder.Context, rules []*rule.Rule) string {
 	var message []string
 
 	for _, v := range rules {
 		switch v.RuleType {
 		case "required":
 			// 必填
-			message = append(message, "必填")
+			message = append(message, ctx.T("import.rule.required"))
 		case "min":
 			// 最小字符串数
-			message = append(message, "大于"+strconv.Itoa(v.Min)+"个字符")
+			message = append(message, ctx.T("import.rule.min", v.Min))
 		case "max":
 			// 最大字符串数
-			message = append(message, "小于"+strconv.Itoa(v.Max)+"个字符")
+			message = append(message, ctx.T("import.rule.max", v.Max))
 		case "email":
 			// 必须为邮箱
-			message = append(message, "必须为邮箱格式")
+			message = append(message, ctx.T("import.rule.email"))
 		case "numeric":
 			// 必须为数字
-			message = append(message, "必须为数字格式")
+			message = append(message, ctx.T("import.rule.numeric"))
 		case "url":
 			// 必须为url
-			message = append(message, "必须为链接格式")
+			message = append(message, ctx.T("import.rule.url"))
 		case "integer":
 			// 必须为整数
-			message = append(message, "必须为整数格式")
+			message = append(message, ctx.T("import.rule.integer"))
 		case "date":
 			// 必须为日期
-			message = append(message, "必须为日期格式")
+			message = append(message, ctx.T("import.rule.date"))
 		case "boolean":
 			// 必须为布尔值
-			message = append(message, "必须为布尔格式")
+			message = append(message, ctx.T("import.rule.boolean"))
 		case "unique":
 			// 必须为布尔值
-			message = append(message, "不可重复")
+			message = append(message, ctx.T("import.rule.unique"))
 		}
 	}
 
 	if len(message) > 0 {
-		return strings.Replace(strings.Trim(fmt.Sprint(message), "/"), " ", "，", -1)
+		return strings.Join(message, ctx.T("import.rule.separator"))
 	}
 
 	return ""
@@ -21,7 +21,7 @@ func (p *IndexRequest) QueryData(ctx *builder.Context) interface{} {
 
 	modelInstance := template.GetModel()
 
-	model := db.Client.Model(modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance)
 
 	// 搜索项
 	searches := template.Searches(ctx)
@@ -0,0 +1,106 @@
+package requests
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"gorm.io/gorm"
+)
+
+// 同步图片、文件字段引用的文件记录，fields为表单字段，只同步本次提交的字段
+func syncFileReferences(ctx *builder.Context, fields interface{}, id int, data map[string]interface{}) error {
+	getFields, ok := fields.([]interface{})
+	if !ok || id == 0 {
+		return nil
+	}
+
+	// 未安装引用表时不记录
+	if !db.Client.Migrator().HasTable(&model.FileReference{}) {
+		return nil
+	}
+
+	refType := ""
+	for _, v := range getFields {
+		value := reflect.ValueOf(v)
+		if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		component := value.Elem().FieldByName("Component")
+		name := value.Elem().FieldByName("Name")
+		if !component.IsValid() || !name.IsValid() {
+			continue
+		}
+
+		fileType := ""
+		switch component.String() {
+		case "imageField":
+			fileType = model.FileReferencePicture
+		case "fileField":
+			fileType = model.FileReferenceFile
+		default:
+			continue
+		}
+
+		fieldValue, ok := data[name.String()]
+		if !ok {
+			continue
+		}
+
+		// 以模型的数据表名作为引用类型
+		if refType == "" {
+			stmt := &gorm.Statement{DB: db.Client}
+			err := stmt.Parse(ctx.Template.(types.Resourcer).GetModel())
+			if err != nil {
+				return err
+			}
+			refType = stmt.Schema.Table
+		}
+
+		err := (&model.FileReference{}).Sync(refType, id, name.String(), fileType, fileReferenceIds(fieldValue))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 解析字段值中的文件ID，支持ID、包含id的对象、数组及其JSON字符串
+func fileReferenceIds(value interface{}) (ids []int) {
+	switch v := value.(type) {
+	case float64:
+		if v > 0 {
+			ids = append(ids, int(v))
+		}
+	case int:
+		if v > 0 {
+			ids = append(ids, v)
+		}
+	case string:
+		if id, err := strconv.Atoi(v); err == nil {
+			return fileReferenceIds(id)
+		}
+		var getValue interface{}
+		if err := json.Unmarshal([]byte(v), &getValue); err == nil {
+			return fileReferenceIds(getValue)
+		}
+	case map[string]interface{}:
+		return fileReferenceIds(v["id"])
+	case []map[string]interface{}:
+		for _, item := range v {
+			ids = append(ids, fileReferenceIds(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			ids = append(ids, fileReferenceIds(item)...)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,337 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+)
+
+type RestRequest struct{}
+
+// 回调响应记录器，用于将组件响应转换为JSON数据
+type restRecorder struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+// 回调返回的消息
+type restMessage struct {
+	Type    string      `json:"type"`
+	Content interface{} `json:"content"`
+	Data    interface{} `json:"data"`
+}
+
+func (p *restRecorder) Header() http.Header {
+	return p.header
+}
+
+func (p *restRecorder) Write(data []byte) (int, error) {
+	return p.body.Write(data)
+}
+
+func (p *restRecorder) WriteHeader(status int) {
+	p.status = status
+}
+
+// 执行回调并捕获回调输出的组件
+func (p *RestRequest) capture(ctx *builder.Context, callback func() error) (*restMessage, error) {
+	response := ctx.EchoContext.Response()
+	writer := response.Writer
+	recorder := &restRecorder{header: http.Header{}, status: 200}
+
+	// 临时替换输出对象
+	response.Writer = recorder
+	err := callback()
+
+	// 还原输出对象
+	response.Writer = writer
+	response.Committed = false
+	response.Status = http.StatusOK
+	response.Size = 0
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &restMessage{}
+	if recorder.body.Len() > 0 {
+		json.Unmarshal(recorder.body.Bytes(), result)
+	}
+
+	return result, nil
+}
+
+// 输出回调的消息
+func (p *RestRequest) messageResponse(ctx *builder.Context, status int, result *restMessage) error {
+	content, _ := result.Content.(string)
+	if result.Type == "error" {
+		return ctx.JSON(http.StatusUnprocessableEntity, builder.Error(content))
+	}
+
+	return ctx.JSON(status, builder.Success(content, result.Data))
+}
+
+// 判断是否开启REST接口
+func (p *RestRequest) enabled(ctx *builder.Context) bool {
+	template, ok := ctx.Template.(types.Resourcer)
+	if !ok {
+		return false
+	}
+
+	return template.GetWithRestApi()
+}
+
+// 获取路由中的ID
+func (p *RestRequest) id(ctx *builder.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	// 兼容资源中通过Query获取ID的查询
+	ctx.SetQuery("id", ctx.Param("id"))
+
+	return id, true
+}
+
+// 获取单条记录
+func (p *RestRequest) record(ctx *builder.Context) map[string]interface{} {
+	template := ctx.Template.(types.Resourcer)
+
+	data := (&DetailRequest{}).FillData(ctx)
+	if len(data) == 0 {
+		return nil
+	}
+
+	return template.BeforeDetailShowing(ctx, data)
+}
+
+// 列表
+func (p *RestRequest) Index(ctx *builder.Context) error {
+	var (
+		lists []map[string]interface{}
+		total int64
+	)
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+
+	template := ctx.Template.(types.Resourcer)
+	indexRequest := &IndexRequest{}
+
+	// 分页参数
+	page, _ := strconv.Atoi(ctx.Query("page", "1").(string))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := 0
+	if perPage, ok := template.GetPerPage().(int); ok {
+		pageSize = perPage
+	}
+	if getPageSize, err := strconv.Atoi(ctx.Query("pageSize", "").(string)); err == nil && getPageSize > 0 {
+		pageSize = getPageSize
+	}
+
+	model := db.Client.WithContext(ctx.Context()).Model(template.GetModel())
+	query := template.BuildIndexQuery(
+		ctx,
+		model,
+		template.Searches(ctx),
+		template.Filters(ctx),
+		indexRequest.columnFilters(ctx),
+		indexRequest.orderings(ctx),
+	)
+
+	if pageSize == 0 {
+		err := query.Find(&lists).Error
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+		}
+		items := indexRequest.performsList(ctx, lists)
+
+		return ctx.JSON(200, builder.Success("ok", map[string]interface{}{
+			"page":     1,
+			"pageSize": len(items),
+			"total":    len(items),
+			"items":    items,
+		}))
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+	err = query.Limit(pageSize).Offset((page - 1) * pageSize).Find(&lists).Error
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, builder.Success("ok", map[string]interface{}{
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    total,
+		"items":    indexRequest.performsList(ctx, lists),
+	}))
+}
+
+// 详情
+func (p *RestRequest) Show(ctx *builder.Context) error {
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+	if _, ok := p.id(ctx); !ok {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.T("message.invalid_params")))
+	}
+
+	data := p.record(ctx)
+	if data == nil {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.record_not_found")))
+	}
+
+	return ctx.JSON(200, builder.Success("ok", data))
+}
+
+// 创建
+func (p *RestRequest) Store(ctx *builder.Context) error {
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+
+	data := map[string]interface{}{}
+	err := json.Unmarshal(ctx.Body(), &data)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.TError(err)))
+	}
+
+	template := ctx.Template.(types.Resourcer)
+
+	// 保存数据
+	id, data, model, err := (&StoreRequest{}).Save(ctx, data)
+	if err != nil {
+		return ctx.JSON(http.StatusUnprocessableEntity, builder.Error(ctx.TError(err)))
+	}
+
+	// 保存后回调
+	result, err := p.capture(ctx, func() error {
+		return template.AfterSaved(ctx, id, data, model)
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+	if result.Type == "error" {
+		return p.messageResponse(ctx, http.StatusCreated, result)
+	}
+
+	ctx.SetQuery("id", strconv.Itoa(id))
+
+	return ctx.JSON(http.StatusCreated, builder.Success("ok", p.record(ctx)))
+}
+
+// 更新
+func (p *RestRequest) Update(ctx *builder.Context) error {
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+
+	id, ok := p.id(ctx)
+	if !ok {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.T("message.invalid_params")))
+	}
+	if p.record(ctx) == nil {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.record_not_found")))
+	}
+
+	data := map[string]interface{}{}
+	err := json.Unmarshal(ctx.Body(), &data)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.TError(err)))
+	}
+
+	// 以路由中的ID为准，并回写请求体，供更新查询使用
+	data["id"] = float64(id)
+	body, err := json.Marshal(data)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.TError(err)))
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	template := ctx.Template.(types.Resourcer)
+
+	// 更新数据
+	data, query, err := (&UpdateRequest{}).Save(ctx, data)
+	if err != nil {
+		return ctx.JSON(http.StatusUnprocessableEntity, builder.Error(ctx.TError(err)))
+	}
+
+	// 保存后回调
+	result, err := p.capture(ctx, func() error {
+		return template.AfterSaved(ctx, id, data, query)
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+	if result.Type == "error" {
+		return p.messageResponse(ctx, 200, result)
+	}
+
+	return ctx.JSON(200, builder.Success("ok", p.record(ctx)))
+}
+
+// 删除
+func (p *RestRequest) Destroy(ctx *builder.Context) error {
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+	if _, ok := p.id(ctx); !ok {
+		return ctx.JSON(http.StatusBadRequest, builder.Error(ctx.T("message.invalid_params")))
+	}
+	if p.record(ctx) == nil {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.record_not_found")))
+	}
+
+	template := ctx.Template.(types.Resourcer)
+
+	// 创建行为查询
+	model := db.Client.WithContext(ctx.Context()).Model(template.GetModel())
+	query := template.BuildActionQuery(ctx, model)
+
+	// 删除数据
+	err := query.Delete("").Error
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+
+	// 执行完后回调
+	err = template.AfterAction(ctx, "delete", query)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// 执行行为
+func (p *RestRequest) Action(ctx *builder.Context) error {
+	if !p.enabled(ctx) {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.not_found")))
+	}
+
+	result, err := p.capture(ctx, func() error {
+		return (&ActionRequest{}).Handle(ctx)
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, builder.Error(ctx.TError(err)))
+	}
+	if result.Type == "" {
+		return ctx.JSON(http.StatusNotFound, builder.Error(ctx.T("message.action_not_found")))
+	}
+
+	return p.messageResponse(ctx, 200, result)
+}
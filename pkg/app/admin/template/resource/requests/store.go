@@ -10,6 +10,8 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"gorm.io/gorm"
 )
 
 type StoreRequest struct{}
@@ -17,7 +19,26 @@ type StoreRequest struct{}
 // 执行行为
 func (p *StoreRequest) Handle(ctx *builder.Context) error {
 	data := map[string]interface{}{}
-	ctx.Bind(&data)
+	err := ctx.Bind(&data)
+	if err != nil {
+		ctx.Logger().Warn("bind store request failed", "error", err)
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	// 模版实例
+	template := ctx.Template.(types.Resourcer)
+
+	// 保存数据
+	id, data, model, err := p.Save(ctx, data)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return template.AfterSaved(ctx, id, data, model)
+}
+
+// 验证并保存数据，返回新增记录的ID
+func (p *StoreRequest) Save(ctx *builder.Context, data map[string]interface{}) (int, map[string]interface{}, *gorm.DB, error) {
 
 	// 模版实例
 	template := ctx.Template.(types.Resourcer)
@@ -31,13 +52,13 @@ func (p *StoreRequest) Handle(ctx *builder.Context) error {
 	// 验证数据合法性
 	validator := template.ValidatorForCreation(ctx, data)
 	if validator != nil {
-		return ctx.JSON(200, message.Error(validator.Error()))
+		return 0, data, nil, validator
 	}
 
 	// 保存前回调
 	data, err := template.BeforeSaving(ctx, data)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return 0, data, nil, err
 	}
 
 	// 重组数据
@@ -74,7 +95,11 @@ func (p *StoreRequest) Handle(ctx *builder.Context) error {
 	structs.SetValues(dataInstance, newData)
 
 	// 获取对象
-	model := db.Client.Model(modelInstance).Create(dataInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance).Create(dataInstance)
+	if model.Error != nil {
+		ctx.Logger().Error("create record failed", "error", model.Error)
+		return 0, data, model, model.Error
+	}
 
 	// 因为gorm使用结构体，不更新零值，需要使用map更新零值
 	reflectId := reflect.
@@ -82,14 +107,24 @@ func (p *StoreRequest) Handle(ctx *builder.Context) error {
 		Elem().
 		FieldByName("Id")
 	if !reflectId.IsValid() {
-		return ctx.JSON(200, message.Error("参数错误"))
+		return 0, data, model, i18n.NewError("message.invalid_params")
 	}
 
 	id := int(reflectId.Int())
-	db.Client.
+	err = db.Client.WithContext(ctx.Context()).
 		Model(&modelInstance).
 		Where("id = ?", id).
-		Updates(newData)
+		Updates(newData).Error
+	if err != nil {
+		ctx.Logger().Error("update created record failed", "id", id, "error", err)
+		return id, data, model, err
+	}
 
-	return template.AfterSaved(ctx, id, data, model)
+	// 记录图片、文件字段引用的文件
+	err = syncFileReferences(ctx, template.CreationFieldsWithoutWhen(ctx), id, data)
+	if err != nil {
+		return id, data, model, err
+	}
+
+	return id, data, model, nil
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"gorm.io/gorm"
 )
 
 type UpdateRequest struct{}
@@ -23,9 +25,24 @@ func (p *UpdateRequest) Handle(ctx *builder.Context) error {
 		return ctx.JSON(200, message.Error(err.Error()))
 	}
 
+	// 模版实例
+	template := ctx.Template.(types.Resourcer)
+
+	// 保存数据
+	data, query, err := p.Save(ctx, data)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return template.AfterSaved(ctx, int(data["id"].(float64)), data, query)
+}
+
+// 验证并更新数据
+func (p *UpdateRequest) Save(ctx *builder.Context, data map[string]interface{}) (map[string]interface{}, *gorm.DB, error) {
+
 	// 验证参数合法性
-	if data["id"] == "" {
-		return ctx.JSON(200, message.Error("参数错误"))
+	if _, ok := data["id"].(float64); !ok {
+		return data, nil, i18n.NewError("message.invalid_params")
 	}
 
 	// 模版实例
@@ -37,13 +54,13 @@ func (p *UpdateRequest) Handle(ctx *builder.Context) error {
 	// 验证数据合法性
 	validator := template.ValidatorForUpdate(ctx, data)
 	if validator != nil {
-		return ctx.JSON(200, message.Error(validator.Error()))
+		return data, nil, validator
 	}
 
 	// 保存前回调
-	data, err = template.BeforeSaving(ctx, data)
+	data, err := template.BeforeSaving(ctx, data)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return data, nil, err
 	}
 
 	// 重组数据
@@ -77,13 +94,23 @@ func (p *UpdateRequest) Handle(ctx *builder.Context) error {
 	}
 
 	// 获取对象
-	model := db.Client.Model(modelInstance)
+	model := db.Client.WithContext(ctx.Context()).Model(modelInstance)
 
 	// 创建更新查询
 	query := template.BuildUpdateQuery(ctx, model)
 
 	// 更新数据
 	query = query.Updates(newData)
+	if query.Error != nil {
+		ctx.Logger().Error("update record failed", "error", query.Error)
+		return data, query, query.Error
+	}
 
-	return template.AfterSaved(ctx, int(data["id"].(float64)), data, query)
+	// 记录图片、文件字段引用的文件
+	err = syncFileReferences(ctx, template.UpdateFieldsWithoutWhen(ctx), int(data["id"].(float64)), data)
+	if err != nil {
+		return data, query, err
+	}
+
+	return data, query, nil
 }
@@ -229,8 +229,8 @@ func (p *Template) DetailExtraActions(ctx *builder.Context) interface{} {
 func (p *Template) BuildAction(ctx *builder.Context, item interface{}) interface{} {
 	actionInstance := item.(types.Actioner)
 
-	// 行为名称
-	name := actionInstance.GetName()
+	// 行为名称，支持翻译键
+	name := ctx.T(actionInstance.GetName())
 
 	// 是否携带Loading
 	withLoading := actionInstance.GetWithLoading()
@@ -263,10 +263,10 @@ func (p *Template) BuildAction(ctx *builder.Context, item interface{}) interface
 	icon := actionInstance.GetIcon()
 
 	// 确认操作标题
-	confirmTitle := actionInstance.GetConfirmTitle()
+	confirmTitle := ctx.T(actionInstance.GetConfirmTitle())
 
 	// 确认操作提示信息
-	confirmText := actionInstance.GetConfirmText()
+	confirmText := ctx.T(actionInstance.GetConfirmText())
 
 	// 确认操作类型
 	confirmType := actionInstance.GetConfirmType()
@@ -28,7 +28,7 @@ func (p *Template) IndexSearches(ctx *builder.Context) interface{} {
 	withExport := template.GetWithExport()
 	if withExport {
 		search = search.
-			SetExportText("导出").
+			SetExportText(ctx.T("resource.export")).
 			SetExportApi(strings.Replace(ExportPath, ":resource", ctx.Param("resource"), -1))
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/template/resource/types"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -28,6 +29,10 @@ const (
 	ImportTemplatePath = "/api/admin/:resource/import/template"       // 导入模板路径
 	SettingFormPath    = "/api/admin/:resource/setting/form"          // 设置表单路径
 	FormPath           = "/api/admin/:resource/:uriKey/form"          // 通用表单资源路径
+	RestPathPrefix     = "/api/v1/"                                   // REST接口路径前缀
+	RestIndexPath      = RestPathPrefix + ":resource"                 // REST列表、创建路径
+	RestDetailPath     = RestPathPrefix + ":resource/:id"             // REST详情、更新、删除路径
+	RestActionPath     = RestPathPrefix + ":resource/action/:uriKey"  // REST执行行为路径
 )
 
 // 增删改查模板
@@ -49,6 +54,7 @@ type Template struct {
 	Model                  interface{}            // 挂载模型
 	Field                  map[string]interface{} // 注入的字段数据
 	WithExport             bool                   // 是否具有导出功能
+	WithRestApi            bool                   // 是否开启REST接口
 }
 
 // 初始化
@@ -69,10 +75,10 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	p.Table = (&table.Component{}).Init()
 
 	// 列表页表格行为列显示文字，既字段的列名
-	p.TableActionColumnTitle = "操作"
+	p.TableActionColumnTitle = ctx.T("resource.action_column")
 
 	// 列表页表格标题后缀
-	p.TableTitleSuffix = "列表"
+	p.TableTitleSuffix = ctx.T("resource.list_suffix")
 
 	// 页面是否携带返回Icon
 	p.BackIcon = true
@@ -98,6 +104,16 @@ func (p *Template) RouteInit() interface{} {
 	p.GET(SettingFormPath, p.FormRender)              // 设置表单
 	p.GET(FormPath, p.FormRender)                     // 通用表单资源
 
+	// 开启REST接口的资源才注册REST路由，同时才会生成对应的权限
+	if p.WithRestApi {
+		p.GET(RestIndexPath, p.RestIndexRender)       // REST列表
+		p.POST(RestIndexPath, p.RestStoreRender)      // REST创建
+		p.GET(RestDetailPath, p.RestShowRender)       // REST详情
+		p.PATCH(RestDetailPath, p.RestUpdateRender)   // REST更新
+		p.DELETE(RestDetailPath, p.RestDestroyRender) // REST删除
+		p.POST(RestActionPath, p.RestActionRender)    // REST执行行为
+	}
+
 	return p
 }
 
@@ -181,6 +197,11 @@ func (p *Template) GetWithExport() bool {
 	return p.WithExport
 }
 
+// 获取是否开启REST接口
+func (p *Template) GetWithRestApi() bool {
+	return p.WithRestApi
+}
+
 // 设置单列字段
 func (p *Template) SetField(fieldData map[string]interface{}) interface{} {
 	p.Field = fieldData
@@ -235,6 +256,8 @@ func (p *Template) AfterAction(ctx *builder.Context, uriKey string, query *gorm.
 
 // 列表页渲染
 func (p *Template) IndexRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "index")
+
 	template := ctx.Template.(types.Resourcer)
 
 	// 获取数据
@@ -251,6 +274,8 @@ func (p *Template) IndexRender(ctx *builder.Context) error {
 
 // 表格行内编辑
 func (p *Template) EditableRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "save")
+
 	return (&requests.EditableRequest{}).Handle(ctx)
 }
 
@@ -282,6 +307,8 @@ func (p *Template) CreationRender(ctx *builder.Context) error {
 
 // 创建方法
 func (p *Template) StoreRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "store")
+
 	return (&requests.StoreRequest{}).Handle(ctx)
 }
 
@@ -311,6 +338,8 @@ func (p *Template) EditValuesRender(ctx *builder.Context) error {
 
 // 保存编辑值
 func (p *Template) SaveRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "save")
+
 	return (&requests.UpdateRequest{}).Handle(ctx)
 }
 
@@ -335,11 +364,15 @@ func (p *Template) DetailRender(ctx *builder.Context) error {
 
 // 导出数据
 func (p *Template) ExportRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "export")
+
 	return (&requests.ExportRequest{}).Handle(ctx)
 }
 
 // 导入数据
 func (p *Template) ImportRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "import")
+
 	return (&requests.ImportRequest{}).Handle(ctx, IndexPath)
 }
 
@@ -364,6 +397,49 @@ func (p *Template) FormRender(ctx *builder.Context) error {
 	return ctx.JSON(200, result)
 }
 
+// REST列表
+func (p *Template) RestIndexRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "index")
+
+	return (&requests.RestRequest{}).Index(ctx)
+}
+
+// REST创建
+func (p *Template) RestStoreRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "store")
+
+	return (&requests.RestRequest{}).Store(ctx)
+}
+
+// REST详情
+func (p *Template) RestShowRender(ctx *builder.Context) error {
+	return (&requests.RestRequest{}).Show(ctx)
+}
+
+// REST更新
+func (p *Template) RestUpdateRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "save")
+
+	return (&requests.RestRequest{}).Update(ctx)
+}
+
+// REST删除
+func (p *Template) RestDestroyRender(ctx *builder.Context) error {
+	p.countOperation(ctx, "delete")
+
+	return (&requests.RestRequest{}).Destroy(ctx)
+}
+
+// REST执行行为
+func (p *Template) RestActionRender(ctx *builder.Context) error {
+	return (&requests.RestRequest{}).Action(ctx)
+}
+
+// 记录资源操作次数，行为的操作次数在执行行为时记录
+func (p *Template) countOperation(ctx *builder.Context, operation string) {
+	telemetry.IncResourceOperation(ctx.Param("resource"), operation, "")
+}
+
 // 页面组件渲染
 func (p *Template) PageComponentRender(ctx *builder.Context, body interface{}) interface{} {
 	template := ctx.Template.(types.Resourcer)
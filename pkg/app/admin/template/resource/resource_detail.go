@@ -12,7 +12,7 @@ func (p *Template) DetailTitle(ctx *builder.Context) string {
 	template := ctx.Template.(types.Resourcer)
 	title := template.GetTitle()
 
-	return title + "详情"
+	return ctx.T("resource.detail_title", title)
 }
 
 // 渲染详情页组件
@@ -44,6 +44,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/treeselect"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/week"
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/form/fields/year"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 )
 
 // 后台字段组件
@@ -71,7 +72,7 @@ func fieldParser(field interface{}, params []interface{}, placeholder string) in
 	}
 
 	if placeholder != "" && len(params) > 1 {
-		v.FieldByName("Placeholder").Set(reflect.ValueOf(placeholder + params[1].(string)))
+		v.FieldByName("Placeholder").Set(reflect.ValueOf(i18n.T("", placeholder, params[1].(string))))
 	}
 
 	return field
@@ -95,21 +96,21 @@ func (p *Field) Hidden(params ...interface{}) *hidden.Component {
 //
 // field.Text("username", "输入框") 或 field.Text("username", "输入框", func() interface{} { return p.Field["username"] })
 func (p *Field) Text(params ...interface{}) *text.Component {
-	return fieldParser(text.New(), params, "请输入").(*text.Component)
+	return fieldParser(text.New(), params, "field.placeholder.input").(*text.Component)
 }
 
 // 文本域组件
 //
 // field.TextArea("name", "文本域") 或 field.TextArea("name", "文本域", func() interface{} { return p.Field["name"] })
 func (p *Field) TextArea(params ...interface{}) *textarea.Component {
-	return fieldParser(textarea.New(), params, "请输入").(*textarea.Component)
+	return fieldParser(textarea.New(), params, "field.placeholder.input").(*textarea.Component)
 }
 
 // 密码组件
 //
 // field.Password("name", "密码框") 或 field.Password("name", "密码", func() interface{} { return p.Field["name"] })
 func (p *Field) Password(params ...interface{}) *password.Component {
-	return fieldParser(password.New(), params, "请输入").(*password.Component)
+	return fieldParser(password.New(), params, "field.placeholder.input").(*password.Component)
 }
 
 // 单选组件
@@ -165,28 +166,28 @@ func (p *Field) Switch(params ...interface{}) *switchfield.Component {
 //
 // field.Tree("name", "树形组件") 或 field.Tree("name", "树形组件", func() interface{} { return p.Field["name"] })
 func (p *Field) Tree(params ...interface{}) *tree.Component {
-	return fieldParser(tree.New(), params, "请选择").(*tree.Component)
+	return fieldParser(tree.New(), params, "field.placeholder.select").(*tree.Component)
 }
 
 // 图标组件
 //
 // field.Icon("name", "图标") 或 field.Icon("name", "图标", func() interface{} { return p.Field["name"] })
 func (p *Field) Icon(params ...interface{}) *icon.Component {
-	return fieldParser(icon.New(), params, "请选择").(*icon.Component)
+	return fieldParser(icon.New(), params, "field.placeholder.select").(*icon.Component)
 }
 
 // 下拉框组件
 //
 // field.Select("name", "文本") 或 field.Select("name", "文本", func() interface{} { return p.Field["name"] })
 func (p *Field) Select(params ...interface{}) *selectfield.Component {
-	return fieldParser(selectfield.New(), params, "请选择").(*selectfield.Component)
+	return fieldParser(selectfield.New(), params, "field.placeholder.select").(*selectfield.Component)
 }
 
 // 级联菜单组件
 //
 // field.Cascader("name", "级联菜单") 或 field.Cascader("name", "级联菜单", func() interface{} { return p.Field["name"] })
 func (p *Field) Cascader(params ...interface{}) *cascader.Component {
-	return fieldParser(cascader.New(), params, "请选择").(*cascader.Component)
+	return fieldParser(cascader.New(), params, "field.placeholder.select").(*cascader.Component)
 }
 
 // 图片组件
@@ -273,7 +274,7 @@ func (p *Field) Month(params ...interface{}) *month.Component {
 //
 // field.Number("name", "数字") 或 field.Number("name", "数字", func() interface{} { return p.Field["name"] })
 func (p *Field) Number(params ...interface{}) *number.Component {
-	return fieldParser(number.New(), params, "请输入").(*number.Component)
+	return fieldParser(number.New(), params, "field.placeholder.input").(*number.Component)
 }
 
 // 日期-季度组件
@@ -403,12 +404,12 @@ func (p *Field) Transfer(params ...interface{}) *transfer.Component {
 //
 // field.ImageCaptcha("captcha", "验证码") 或 field.ImageCaptcha("captcha", "验证码", func() interface{} { return p.Field["username"] })
 func (p *Field) ImageCaptcha(params ...interface{}) *imagecaptcha.Component {
-	return fieldParser(imagecaptcha.New(), params, "请输入").(*imagecaptcha.Component)
+	return fieldParser(imagecaptcha.New(), params, "field.placeholder.input").(*imagecaptcha.Component)
 }
 
 // 短信验证码组件
 //
 // field.SmsCaptcha("code", "输入框") 或 field.SmsCaptcha("code", "输入框", func() interface{} { return p.Field["username"] })
 func (p *Field) SmsCaptcha(params ...interface{}) *smscaptcha.Component {
-	return fieldParser(smscaptcha.New(), params, "请输入").(*smscaptcha.Component)
+	return fieldParser(smscaptcha.New(), params, "field.placeholder.input").(*smscaptcha.Component)
 }
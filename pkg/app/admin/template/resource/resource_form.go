@@ -28,10 +28,10 @@ func (p *Template) FormTitle(ctx *builder.Context) string {
 
 	// 解析标题
 	if ctx.IsCreating() {
-		return "创建" + title
+		return ctx.T("resource.create_title", title)
 	} else {
 		if ctx.IsEditing() {
-			return "编辑" + title
+			return ctx.T("resource.edit_title", title)
 		}
 	}
 
@@ -142,5 +142,5 @@ func (p *Template) AfterSaved(ctx *builder.Context, id int, data map[string]inte
 		return ctx.JSON(200, message.Error(result.Error.Error()))
 	}
 
-	return ctx.JSON(200, message.Success("操作成功！", strings.Replace("/layout/index?api="+IndexPath, ":resource", ctx.Param("resource"), -1)))
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), strings.Replace("/layout/index?api="+IndexPath, ":resource", ctx.Param("resource"), -1)))
 }
@@ -60,6 +60,9 @@ type Resourcer interface {
 	// 获取是否具有导出功能
 	GetWithExport() bool
 
+	// 获取是否开启REST接口
+	GetWithRestApi() bool
+
 	// 设置单列字段
 	SetField(fieldData map[string]interface{}) interface{}
 
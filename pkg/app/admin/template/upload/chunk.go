@@ -0,0 +1,213 @@
+package upload
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 分片存储，同一目录共用一个实例
+var chunkStores sync.Map
+
+// 正在合并的上传会话，同一会话同时只能合并一次
+var chunkCompleting sync.Map
+
+// 创建分片上传的请求参数
+type ChunkInitRequest struct {
+	Name        string `json:"name"`        // 文件名称
+	Size        int64  `json:"size"`        // 文件大小
+	Fingerprint string `json:"fingerprint"` // 文件指纹，例如文件名称、大小和修改时间，相同指纹会复用未完成的会话
+}
+
+// 完成分片上传的请求参数
+type ChunkCompleteRequest struct {
+	UploadId string `json:"uploadId"` // 上传会话ID
+}
+
+// 获取分片存储
+func (p *Template) chunkStore(template Uploader) *storage.ChunkStore {
+	store, _ := chunkStores.LoadOrStore(
+		template.GetChunkPath(),
+		storage.NewChunkStore(template.GetChunkPath(), template.GetChunkExpire()),
+	)
+
+	return store.(*storage.ChunkStore)
+}
+
+// 获取分片上传的所属用户
+func (p *Template) chunkOwner(ctx *builder.Context) (string, error) {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return "", err
+	}
+
+	return "ADMINID:" + strconv.Itoa(adminInfo.Id), nil
+}
+
+// 获取当前用户的上传会话
+func (p *Template) chunkSession(ctx *builder.Context, template Uploader, uploadId string) (*storage.ChunkSession, error) {
+	owner, err := p.chunkOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := p.chunkStore(template).Get(uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if session.Owner != owner {
+		return nil, i18n.NewError("storage.chunk_session_not_found")
+	}
+
+	return session, nil
+}
+
+// 创建分片上传，返回上传会话及已上传的分片，用于断点续传
+func (p *Template) ChunkInit(ctx *builder.Context) error {
+	data := &ChunkInitRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	if data.Name == "" || data.Size <= 0 {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	template := ctx.Template.(Uploader)
+	if limitSize := template.GetLimitSize(); limitSize > 0 && data.Size > limitSize {
+		return ctx.JSON(200, message.Error(ctx.T("storage.size_exceeded")))
+	}
+
+	// 检查剩余存储空间，合并分片时会再次检查
+	err := p.checkQuota(ctx, template, data.Size)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	owner, err := p.chunkOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	session, err := p.chunkStore(template).Create(owner, data.Name, data.Size, template.GetChunkSize(), data.Fingerprint)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", session))
+}
+
+// 获取分片上传状态
+func (p *Template) ChunkStatus(ctx *builder.Context) error {
+	uploadId := ctx.Query("uploadId", "").(string)
+
+	session, err := p.chunkSession(ctx, ctx.Template.(Uploader), uploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", session))
+}
+
+// 上传分片，请求体为分片内容，参数uploadId为上传会话ID，index为分片序号，checksum为分片的sha256值
+func (p *Template) ChunkUpload(ctx *builder.Context) error {
+	uploadId := ctx.Query("uploadId", "").(string)
+	index, err := strconv.Atoi(ctx.Query("index", "").(string))
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	checksum := ctx.Query("checksum", "").(string)
+	if checksum == "" {
+		checksum = ctx.Header("X-Chunk-Checksum")
+	}
+
+	template := ctx.Template.(Uploader)
+	session, err := p.chunkSession(ctx, template, uploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	store := p.chunkStore(template)
+	err = store.WriteChunk(session, index, ctx.Request.Body, checksum)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	session, err = store.Get(uploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", session))
+}
+
+// 完成分片上传，合并分片写入存储驱动，结果与普通上传一致
+func (p *Template) ChunkComplete(ctx *builder.Context) error {
+	data := &ChunkCompleteRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 重复提交时拒绝，避免同一文件被保存多次
+	lock, _ := chunkCompleting.LoadOrStore(data.UploadId, &sync.Mutex{})
+	if !lock.(*sync.Mutex).TryLock() {
+		return ctx.JSON(200, message.Error(ctx.T("storage.chunk_completing")))
+	}
+	defer func() {
+		chunkCompleting.Delete(data.UploadId)
+		lock.(*sync.Mutex).Unlock()
+	}()
+
+	template := ctx.Template.(Uploader)
+	session, err := p.chunkSession(ctx, template, data.UploadId)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	store := p.chunkStore(template)
+	reader, err := store.Reader(session)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	defer reader.Close()
+
+	fileSystem := p.newFileSystem(ctx, template).
+		Reader(&storage.File{
+			Name:   session.Name,
+			Size:   session.Size,
+			Reader: reader,
+		})
+
+	// 按剩余存储空间限制文件大小
+	err = p.limitQuota(ctx, template, fileSystem)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 上传前回调
+	getFileSystem, fileInfo, err := template.BeforeHandle(ctx, fileSystem)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	if fileInfo == nil {
+		fileInfo, err = getFileSystem.
+			WithImageWH().
+			RandName().
+			Path(p.savePath(ctx, template)).
+			Save()
+		if err != nil {
+			return p.handleError(ctx, template, fileInfo, err)
+		}
+	}
+
+	// 合并完成后删除分片
+	reader.Close()
+	store.Remove(session.Id)
+
+	return template.AfterHandle(ctx, fileInfo)
+}
@@ -0,0 +1,158 @@
+package upload
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+)
+
+// 上传凭证过期后，仍然允许完成直传的时间，用于等待较大文件上传结束
+const directCompleteGrace = time.Hour
+
+// 创建浏览器直传的请求参数
+type DirectInitRequest struct {
+	Name        string `json:"name"`        // 文件名称
+	Size        int64  `json:"size"`        // 文件大小
+	ContentType string `json:"contentType"` // 文件类型
+}
+
+// 完成浏览器直传的请求参数，除hash外均为创建时返回的值
+type DirectCompleteRequest struct {
+	Key         string `json:"key"`         // 暂存路径
+	Name        string `json:"name"`        // 文件名称
+	Size        int64  `json:"size"`        // 文件大小
+	ContentType string `json:"contentType"` // 文件类型
+	Expires     string `json:"expires"`     // 过期时间
+	Signature   string `json:"signature"`   // 签名
+	Hash        string `json:"hash"`        // 文件的sha256值，可选，提交时与存储中的文件比较
+}
+
+// 直传凭证的签名内容，凭证只能由申请的用户在同一资源下使用
+func (p *Template) directSignValue(ctx *builder.Context, owner string, data *DirectCompleteRequest) string {
+	return strings.Join([]string{
+		ctx.Param("resource"),
+		owner,
+		data.Key,
+		data.Name,
+		strconv.FormatInt(data.Size, 10),
+		data.ContentType,
+	}, "\n")
+}
+
+// 创建浏览器直传，按模板的文件大小、类型限制生成上传签名，浏览器上传完成后调用DirectComplete
+//
+// 返回数据中upload为上传地址及表单字段，complete为完成直传时需要提交的数据
+func (p *Template) DirectInit(ctx *builder.Context) error {
+	data := &DirectInitRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+	if data.Name == "" || data.Size <= 0 || data.ContentType == "" {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
+	}
+
+	template := ctx.Template.(Uploader)
+	if limitSize := template.GetLimitSize(); limitSize > 0 && data.Size > limitSize {
+		return ctx.JSON(200, message.Error(ctx.T("storage.size_exceeded")))
+	}
+
+	// 检查文件类型，图片宽高及文件内容在上传完成后检查
+	fileExt := storage.ContentTypeList[data.ContentType]
+	if fileExt == "" {
+		return ctx.JSON(200, message.Error(ctx.T("storage.ext_unknown")))
+	}
+	err := p.newFileSystem(ctx, template).
+		Reader(&storage.File{
+			Name:        data.Name,
+			Size:        data.Size,
+			ContentType: data.ContentType,
+			Content:     []byte{},
+		}).
+		LimitImageWidth(0).
+		LimitImageHeight(0).
+		CheckFile()
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 检查剩余存储空间，完成直传时会再次检查
+	err = p.checkQuota(ctx, template, data.Size)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	owner, err := p.chunkOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	// 直传到暂存目录，完成直传时校验及扫描通过后再移动到保存路径
+	expire := template.GetDirectExpire()
+	key := template.GetStagingPath() + rand.MakeAlphanumeric(40) + "." + fileExt
+	upload, err := p.newFileSystem(ctx, template).PresignUpload(&storage.UploadPolicy{
+		Key:         key,
+		ContentType: data.ContentType,
+		Size:        data.Size,
+		MaxSize:     template.GetLimitSize(),
+		Expire:      expire,
+	})
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	complete := &DirectCompleteRequest{
+		Key:         key,
+		Name:        data.Name,
+		Size:        data.Size,
+		ContentType: data.ContentType,
+		Expires:     strconv.FormatInt(time.Now().Add(expire+directCompleteGrace).Unix(), 10),
+	}
+	complete.Signature = builder.Sign(p.directSignValue(ctx, owner, complete), complete.Expires)
+
+	return ctx.JSON(200, message.Success(ctx.T("message.success"), "", map[string]interface{}{
+		"upload":   upload,
+		"complete": complete,
+	}))
+}
+
+// 完成浏览器直传，校验暂存文件的大小、类型、哈希值并执行扫描，通过后移动到保存路径，结果与普通上传一致
+func (p *Template) DirectComplete(ctx *builder.Context) error {
+	data := &DirectCompleteRequest{}
+	if err := ctx.BodyParser(data); err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	owner, err := p.chunkOwner(ctx)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	err = builder.VerifySignature(p.directSignValue(ctx, owner, data), data.Expires, data.Signature)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	template := ctx.Template.(Uploader)
+	fileSystem := p.newFileSystem(ctx, template).Path(p.savePath(ctx, template))
+
+	// 按剩余存储空间限制文件大小，超出时删除已上传的文件
+	err = p.limitQuota(ctx, template, fileSystem)
+	if err != nil {
+		if driver, driverErr := fileSystem.GetDriver(); driverErr == nil {
+			driver.Delete(ctx.Context(), data.Key)
+		}
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
+	result, err := fileSystem.Verify(data.Key, data.Name, data.Size, data.Hash)
+	if err != nil {
+		return p.handleError(ctx, template, result, err)
+	}
+
+	return template.AfterHandle(ctx, result)
+}
@@ -0,0 +1,40 @@
+package upload
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 获取当前用户的剩余存储空间，单位为字节，不限制时返回-1
+func (p *Template) GetQuotaRemaining(ctx *builder.Context) (int64, error) {
+	adminInfo, err := (&model.Admin{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil {
+		return 0, err
+	}
+
+	return (&model.StorageQuota{}).GetRemaining("ADMINID", adminInfo.Id)
+}
+
+// 检查已知大小的文件是否超出存储配额
+func (p *Template) checkQuota(ctx *builder.Context, template Uploader, size int64) error {
+	remaining, err := template.GetQuotaRemaining(ctx)
+	if err != nil {
+		return err
+	}
+	if remaining >= 0 && size > remaining {
+		return storage.QuotaError(remaining)
+	}
+
+	return nil
+}
+
+// 按剩余空间限制文件大小，大小未知的数据流在写入时检查
+func (p *Template) limitQuota(ctx *builder.Context, template Uploader, fileSystem *storage.FileSystem) error {
+	remaining, err := template.GetQuotaRemaining(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fileSystem.LimitQuota(remaining)
+}
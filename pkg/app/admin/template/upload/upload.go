@@ -1,12 +1,13 @@
 package upload
 
 import (
-	"bytes"
 	"encoding/base64"
 	"io"
-	"mime/multipart"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/component/message"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
@@ -17,14 +18,24 @@ import (
 // 文件上传
 type Template struct {
 	builder.Template
-	LimitSize        int64                // 限制文件大小
-	LimitType        []string             // 限制文件类型
-	LimitImageWidth  int                  // 限制图片宽度
-	LimitImageHeight int                  // 限制图片高度
-	Driver           string               // 存储驱动
-	SavePath         string               // 保存路径
-	OSSConfig        *storage.OSSConfig   // OSS配置
-	MinioConfig      *storage.MinioConfig // Minio配置
+	LimitSize        int64                   // 限制文件大小
+	LimitType        []string                // 限制文件类型
+	LimitImageWidth  int                     // 限制图片宽度
+	LimitImageHeight int                     // 限制图片高度
+	Driver           string                  // 存储驱动，对应storage中注册的驱动名称，例如：local、oss、minio、s3、memory
+	SavePath         string                  // 保存路径
+	OSSConfig        *storage.OSSConfig      // OSS配置
+	MinioConfig      *storage.MinioConfig    // Minio配置
+	S3Config         *storage.S3Config       // S3配置
+	ChunkSize        int64                   // 分片上传的分片大小，默认5MB
+	ChunkPath        string                  // 分片上传的临时目录，默认为系统临时目录下的quark-chunks
+	ChunkExpire      time.Duration           // 未完成的分片上传保留时间，默认24小时
+	ImageVariants    []*storage.ImageVariant // 图片规格，例如缩略图
+	Visibility       string                  // 文件可见性：public、private，默认public；本地私有文件保存在私有目录中，不能通过WEB根目录访问
+	Scanners         []storage.Scanner       // 文件内容扫描器，例如：storage.NewClamAVScanner("tcp", "127.0.0.1:3310")、storage.NewRuleScanner()
+	QuarantinePath   string                  // 隔离目录，未通过扫描的文件移动到该目录，默认./storage/quarantine/
+	DirectExpire     time.Duration           // 浏览器直传凭证的有效期，默认15分钟，仅OSS、Minio、S3等支持直传的驱动可用
+	StagingPath      string                  // 浏览器直传的暂存目录，文件校验及扫描通过后移动到保存路径，默认./storage/staging/
 }
 
 // 初始化
@@ -38,8 +49,17 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	// 初始化数据对象
 	p.DB = db.Client
 
-	// 默认本地上传
+	// 默认本地上传，应用配置了默认存储时使用该配置
 	p.Driver = storage.LocalDriver
+	if config := builder.GetConfig(); config != nil && config.Storage != nil {
+		if config.Storage.Driver != "" {
+			p.Driver = config.Storage.Driver
+		}
+		p.OSSConfig = config.Storage.OSSConfig
+		p.MinioConfig = config.Storage.MinioConfig
+		p.S3Config = config.Storage.S3Config
+		p.Visibility = config.Storage.Visibility
+	}
 
 	return p
 }
@@ -48,6 +68,12 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 func (p *Template) RouteInit() interface{} {
 	p.POST("/api/admin/upload/:resource/handle", p.Handle)
 	p.POST("/api/admin/upload/:resource/base64Handle", p.HandleFromBase64)
+	p.POST("/api/admin/upload/:resource/chunk/init", p.ChunkInit)
+	p.GET("/api/admin/upload/:resource/chunk/status", p.ChunkStatus)
+	p.POST("/api/admin/upload/:resource/chunk/upload", p.ChunkUpload)
+	p.POST("/api/admin/upload/:resource/chunk/complete", p.ChunkComplete)
+	p.POST("/api/admin/upload/:resource/direct/init", p.DirectInit)
+	p.POST("/api/admin/upload/:resource/direct/complete", p.DirectComplete)
 
 	return p
 }
@@ -92,29 +118,109 @@ func (p *Template) GetMinioConfig() *storage.MinioConfig {
 	return p.MinioConfig
 }
 
-// 执行上传
-func (p *Template) Handle(ctx *builder.Context) error {
-	var (
-		result *storage.FileInfo
-		err    error
-	)
+// 获取S3配置
+func (p *Template) GetS3Config() *storage.S3Config {
+	return p.S3Config
+}
 
-	limitW := ctx.Query("limitW", "")
-	limitH := ctx.Query("limitH", "")
+// 获取分片大小
+func (p *Template) GetChunkSize() int64 {
+	return p.ChunkSize
+}
 
-	contentTypes := strings.Split(ctx.Header("Content-Type"), "; ")
-	if len(contentTypes) != 2 {
-		return ctx.JSON(200, message.Error("Content-Type error"))
+// 获取分片临时目录
+func (p *Template) GetChunkPath() string {
+	return p.ChunkPath
+}
 
+// 获取未完成分片上传的保留时间
+func (p *Template) GetChunkExpire() time.Duration {
+	return p.ChunkExpire
+}
+
+// 获取浏览器直传凭证的有效期
+func (p *Template) GetDirectExpire() time.Duration {
+	if p.DirectExpire <= 0 {
+		return 15 * time.Minute
 	}
-	if contentTypes[0] != "multipart/form-data" {
-		return ctx.JSON(200, message.Error("Content-Type must use multipart/form-data"))
+
+	return p.DirectExpire
+}
+
+// 获取文件可见性
+func (p *Template) GetVisibility() string {
+	if p.Visibility == "" {
+		return storage.VisibilityPublic
 	}
 
-	template := ctx.Template.(Uploader)
+	return p.Visibility
+}
+
+// 获取文件内容扫描器
+func (p *Template) GetScanners() []storage.Scanner {
+	return p.Scanners
+}
+
+// 获取隔离目录
+func (p *Template) GetQuarantinePath() string {
+	if p.QuarantinePath == "" {
+		return "./storage/quarantine/"
+	}
+
+	return p.QuarantinePath
+}
+
+// 获取浏览器直传的暂存目录
+func (p *Template) GetStagingPath() string {
+	if p.StagingPath == "" {
+		return "./storage/staging/"
+	}
+
+	return p.StagingPath
+}
+
+// 获取当前配置的存储驱动
+func (p *Template) GetStorageDriver() (storage.Driver, error) {
+	return storage.GetDriver(p.Driver, &storage.Config{
+		OSSConfig:   p.OSSConfig,
+		MinioConfig: p.MinioConfig,
+		S3Config:    p.S3Config,
+		Visibility:  p.GetVisibility(),
+	})
+}
+
+// 获取实际的保存路径，本地存储的私有文件保存到私有目录，例如：./web/app/storage/files/ 保存为 ./storage/files/
+func (p *Template) savePath(ctx *builder.Context, template Uploader) string {
+	savePath := template.GetSavePath()
+	if template.GetVisibility() != storage.VisibilityPrivate {
+		return savePath
+	}
+	if driver := template.GetDriver(); driver != "" && driver != storage.LocalDriver {
+		return savePath
+	}
+
+	privatePath := path.Clean(filepath.ToSlash(ctx.Engine.GetConfig().PrivatePath))
+	name := path.Clean(filepath.ToSlash(savePath))
+	if name == privatePath || strings.HasPrefix(name, privatePath+"/") {
+		return savePath
+	}
+
+	// 去掉WEB根目录及其中的storage目录
+	name = strings.TrimPrefix(name, "web/app/")
+	name = strings.TrimPrefix(name, "storage/")
+
+	name = path.Join(privatePath, name)
+	if !path.IsAbs(name) {
+		name = "./" + name
+	}
+
+	return name + "/"
+}
 
-	limitSize := template.GetLimitSize()
-	limitType := template.GetLimitType()
+// 根据模板配置创建文件系统，图片宽高限制可以通过limitW、limitH参数覆盖
+func (p *Template) newFileSystem(ctx *builder.Context, template Uploader) *storage.FileSystem {
+	limitW := ctx.Query("limitW", "")
+	limitH := ctx.Query("limitH", "")
 
 	limitImageWidth := template.GetLimitImageWidth()
 	if limitW.(string) != "" {
@@ -126,61 +232,100 @@ func (p *Template) Handle(ctx *builder.Context) error {
 
 	limitImageHeight := template.GetLimitImageHeight()
 	if limitH.(string) != "" {
-		getLimitImageWidth, err := strconv.Atoi(limitH.(string))
+		getLimitImageHeight, err := strconv.Atoi(limitH.(string))
 		if err == nil {
-			limitImageWidth = getLimitImageWidth
+			limitImageHeight = getLimitImageHeight
 		}
 	}
 
-	driver := template.GetDriver()
-	ossConfig := template.GetOSSConfig()
-	minioConfig := template.GetMinioConfig()
-	savePath := template.GetSavePath()
+	return storage.
+		New(&storage.Config{
+			LimitSize:        template.GetLimitSize(),
+			LimitType:        template.GetLimitType(),
+			LimitImageWidth:  limitImageWidth,
+			LimitImageHeight: limitImageHeight,
+			Driver:           template.GetDriver(),
+			CheckFileExist:   true,
+			OSSConfig:        template.GetOSSConfig(),
+			MinioConfig:      template.GetMinioConfig(),
+			S3Config:         template.GetS3Config(),
+			Visibility:       template.GetVisibility(),
+			Scanners:         template.GetScanners(),
+			QuarantinePath:   template.GetQuarantinePath(),
+		}).
+		WithContext(ctx.Context())
+}
+
+// 执行上传
+func (p *Template) Handle(ctx *builder.Context) error {
+	var (
+		result *storage.FileInfo
+		err    error
+	)
+
+	contentTypes := strings.Split(ctx.Header("Content-Type"), "; ")
+	if len(contentTypes) != 2 {
+		return ctx.JSON(200, message.Error(ctx.T("message.content_type_error")))
 
-	byteReader := bytes.NewReader(ctx.Body())
-	multipartReader := multipart.NewReader(byteReader, strings.TrimLeft(contentTypes[1], "boundary="))
-	for p, err := multipartReader.NextPart(); err != io.EOF; p, err = multipartReader.NextPart() {
-		if p.FormName() == "file" {
-			fileData, _ := io.ReadAll(p)
-			fileSystem := storage.
-				New(&storage.Config{
-					LimitSize:        limitSize,
-					LimitType:        limitType,
-					LimitImageWidth:  limitImageWidth,
-					LimitImageHeight: limitImageHeight,
-					Driver:           driver,
-					CheckFileExist:   true,
-					OSSConfig:        ossConfig,
-					MinioConfig:      minioConfig,
-				}).
-				Reader(&storage.File{
-					Header:  p.Header,
-					Name:    p.FileName(),
-					Content: fileData,
-				})
-
-			// 上传前回调
-			getFileSystem, fileInfo, err := template.BeforeHandle(ctx, fileSystem)
-			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
-			}
-			if fileInfo != nil {
-				return template.AfterHandle(ctx, fileInfo)
-			}
-
-			result, err = getFileSystem.
-				WithImageWH().
-				RandName().
-				Path(savePath).
-				Save()
-			if err != nil {
-				return ctx.JSON(200, message.Error(err.Error()))
-			}
-		}
 	}
+	if contentTypes[0] != "multipart/form-data" {
+		return ctx.JSON(200, message.Error(ctx.T("message.content_type_multipart")))
+	}
+
+	template := ctx.Template.(Uploader)
+	savePath := p.savePath(ctx, template)
 
+	// 逐个读取表单分段，文件内容以数据流形式直接写入存储驱动
+	multipartReader, err := ctx.Request.MultipartReader()
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.T("message.content_type_multipart")))
+	}
+	for {
+		part, err := multipartReader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		fileSystem := p.newFileSystem(ctx, template).
+			Reader(&storage.File{
+				Header: part.Header,
+				Name:   part.FileName(),
+				Reader: part,
+			})
+
+		// 按剩余存储空间限制文件大小
+		err = p.limitQuota(ctx, template, fileSystem)
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+
+		// 上传前回调
+		getFileSystem, fileInfo, err := template.BeforeHandle(ctx, fileSystem)
+		if err != nil {
+			return ctx.JSON(200, message.Error(ctx.TError(err)))
+		}
+		if fileInfo != nil {
+			return template.AfterHandle(ctx, fileInfo)
+		}
+
+		result, err = getFileSystem.
+			WithImageWH().
+			RandName().
+			Path(savePath).
+			Save()
+		if err != nil {
+			return p.handleError(ctx, template, result, err)
+		}
+	}
+
+	if result == nil {
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	return template.AfterHandle(ctx, result)
@@ -193,72 +338,42 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 		err    error
 	)
 
-	limitW := ctx.Query("limitW", "")
-	limitH := ctx.Query("limitH", "")
-
 	data := map[string]interface{}{}
 	if err := ctx.BodyParser(&data); err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 	if data["file"] == nil {
-		return ctx.JSON(200, message.Error("参数错误"))
+		return ctx.JSON(200, message.Error(ctx.T("message.invalid_params")))
 	}
 
 	files := strings.Split(data["file"].(string), ",")
 	if len(files) != 2 {
-		return ctx.JSON(200, message.Error("格式错误"))
+		return ctx.JSON(200, message.Error(ctx.T("message.format_error")))
 	}
 
 	fileData, err := base64.StdEncoding.DecodeString(files[1]) // 把文件写入到buffer
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 
 	template := ctx.Template.(Uploader)
+	savePath := p.savePath(ctx, template)
 
-	limitSize := template.GetLimitSize()
-	limitType := template.GetLimitType()
-
-	limitImageWidth := template.GetLimitImageWidth()
-	if limitW.(string) != "" {
-		getLimitImageWidth, err := strconv.Atoi(limitW.(string))
-		if err == nil {
-			limitImageWidth = getLimitImageWidth
-		}
-	}
-
-	limitImageHeight := template.GetLimitImageHeight()
-	if limitH.(string) != "" {
-		getLimitImageWidth, err := strconv.Atoi(limitH.(string))
-		if err == nil {
-			limitImageWidth = getLimitImageWidth
-		}
-	}
-
-	savePath := template.GetSavePath()
-	driver := template.GetDriver()
-	ossConfig := template.GetOSSConfig()
-	minioConfig := template.GetMinioConfig()
-
-	fileSystem := storage.
-		New(&storage.Config{
-			LimitSize:        limitSize,
-			LimitType:        limitType,
-			LimitImageWidth:  limitImageWidth,
-			LimitImageHeight: limitImageHeight,
-			Driver:           driver,
-			CheckFileExist:   true,
-			OSSConfig:        ossConfig,
-			MinioConfig:      minioConfig,
-		}).
+	fileSystem := p.newFileSystem(ctx, template).
 		Reader(&storage.File{
 			Content: fileData,
 		})
 
+	// 按剩余存储空间限制文件大小
+	err = p.limitQuota(ctx, template, fileSystem)
+	if err != nil {
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
+	}
+
 	// 上传前回调
 	getFileSystem, fileInfo, err := template.BeforeHandle(ctx, fileSystem)
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return ctx.JSON(200, message.Error(ctx.TError(err)))
 	}
 	if fileInfo != nil {
 		return template.AfterHandle(ctx, fileInfo)
@@ -271,7 +386,7 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 		Save()
 
 	if err != nil {
-		return ctx.JSON(200, message.Error(err.Error()))
+		return p.handleError(ctx, template, result, err)
 	}
 
 	return template.AfterHandle(ctx, result)
@@ -282,7 +397,21 @@ func (p *Template) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSy
 	return fileSystem, nil, nil
 }
 
+// 处理保存失败，未通过扫描的文件交给隔离回调处理
+func (p *Template) handleError(ctx *builder.Context, template Uploader, result *storage.FileInfo, err error) error {
+	if result != nil && result.ScanStatus == storage.ScanInfected {
+		return template.QuarantineHandle(ctx, result, err)
+	}
+
+	return ctx.JSON(200, message.Error(ctx.TError(err)))
+}
+
+// 文件未通过扫描回调，result为隔离文件的信息，未设置隔离目录时路径为空
+func (p *Template) QuarantineHandle(ctx *builder.Context, result *storage.FileInfo, err error) error {
+	return ctx.JSON(200, message.Error(ctx.TError(err)))
+}
+
 // 上传后回调
 func (p *Template) AfterHandle(ctx *builder.Context, result *storage.FileInfo) error {
-	return ctx.JSON(200, message.Success("上传成功", "", result))
+	return ctx.JSON(200, message.Success(ctx.T("message.upload_success"), "", result))
 }
@@ -1,6 +1,8 @@
 package upload
 
 import (
+	"time"
+
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
 )
@@ -34,15 +36,69 @@ type Uploader interface {
 	// 获取Minio配置
 	GetMinioConfig() *storage.MinioConfig
 
+	// 获取S3配置
+	GetS3Config() *storage.S3Config
+
+	// 获取分片大小
+	GetChunkSize() int64
+
+	// 获取分片临时目录
+	GetChunkPath() string
+
+	// 获取未完成分片上传的保留时间
+	GetChunkExpire() time.Duration
+
+	// 获取图片规格
+	GetImageVariants() []*storage.ImageVariant
+
+	// 获取浏览器直传凭证的有效期
+	GetDirectExpire() time.Duration
+
+	// 获取浏览器直传的暂存目录
+	GetStagingPath() string
+
+	// 获取文件可见性
+	GetVisibility() string
+
+	// 获取文件内容扫描器
+	GetScanners() []storage.Scanner
+
+	// 获取隔离目录
+	GetQuarantinePath() string
+
+	// 获取当前用户的剩余存储空间
+	GetQuotaRemaining(ctx *builder.Context) (int64, error)
+
 	// 执行上传
 	Handle(ctx *builder.Context) error
 
 	// 通过Base64执行上传
 	HandleFromBase64(ctx *builder.Context) error
 
+	// 创建分片上传
+	ChunkInit(ctx *builder.Context) error
+
+	// 获取分片上传状态
+	ChunkStatus(ctx *builder.Context) error
+
+	// 上传分片
+	ChunkUpload(ctx *builder.Context) error
+
+	// 完成分片上传
+	ChunkComplete(ctx *builder.Context) error
+
+	// 创建浏览器直传
+	DirectInit(ctx *builder.Context) error
+
+	// 完成浏览器直传
+	DirectComplete(ctx *builder.Context) error
+
 	// 上传前回调
 	BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
 
+	// 文件未通过扫描回调
+	QuarantineHandle(ctx *builder.Context, result *storage.FileInfo, err error) error
+
 	// 上传后回调
 	AfterHandle(ctx *builder.Context, result *storage.FileInfo) error
 }
@@ -0,0 +1,137 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 获取图片规格
+func (p *Template) GetImageVariants() []*storage.ImageVariant {
+	return p.ImageVariants
+}
+
+// 根据名称获取图片规格
+func (p *Template) GetImageVariant(name string) *storage.ImageVariant {
+	for _, v := range p.ImageVariants {
+		if v.Name == name {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// 读取存储中的文件
+func (p *Template) readObject(ctx context.Context, driver storage.Driver, key string) ([]byte, error) {
+	reader, err := driver.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// 生成图片规格并保存到存储中
+func (p *Template) generateImageVariant(ctx context.Context, driver storage.Driver, originPath string, content []byte, variant *storage.ImageVariant) ([]byte, error) {
+	result, err := variant.Process(content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = driver.Put(ctx, variant.Key(originPath), bytes.NewReader(result), int64(len(result)), variant.ContentType(originPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 生成上传时需要生成的图片规格，无法解码的图片（例如svg）不生成
+func (p *Template) GenerateImageVariants(ctx context.Context, originPath string) error {
+	var content []byte
+
+	driver, err := p.GetStorageDriver()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range p.ImageVariants {
+		if !v.OnUpload {
+			continue
+		}
+
+		// 相同内容的图片共用一个文件，规格已存在时不再生成
+		if exists, _ := driver.Exists(ctx, v.Key(originPath)); exists {
+			continue
+		}
+
+		if content == nil {
+			content, err = p.readObject(ctx, driver, originPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = p.generateImageVariant(ctx, driver, originPath, content, v)
+		if errors.Is(err, image.ErrFormat) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 获取图片规格的内容，规格文件不存在时生成并保存到存储中
+func (p *Template) ImageVariant(ctx context.Context, originPath string, name string) ([]byte, string, error) {
+	variant := p.GetImageVariant(name)
+	if variant == nil {
+		return nil, "", i18n.NewError("storage.image_variant_not_found")
+	}
+
+	driver, err := p.GetStorageDriver()
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := p.readObject(ctx, driver, variant.Key(originPath))
+	if err == nil {
+		return content, variant.ContentType(originPath), nil
+	}
+	if !errors.Is(err, storage.ErrNotExist) {
+		return nil, "", err
+	}
+
+	content, err = p.readObject(ctx, driver, originPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err = p.generateImageVariant(ctx, driver, originPath, content, variant)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, variant.ContentType(originPath), nil
+}
+
+// 删除图片的所有规格文件
+func (p *Template) RemoveImageVariants(ctx context.Context, originPath string) {
+	driver, err := p.GetStorageDriver()
+	if err != nil {
+		return
+	}
+
+	for _, v := range p.ImageVariants {
+		driver.Delete(ctx, v.Key(originPath))
+	}
+}
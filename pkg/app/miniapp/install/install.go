@@ -1,26 +1,42 @@
 package install
 
 import (
-	"github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/model"
+	v20261019000101 "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/install/v20261019000101"
+	v20261019000102 "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/install/v20261019000102"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/migrate"
 	"gorm.io/gorm"
 )
 
-// 执行安装操作
-func Handle() {
-
-	// 迁移数据
-	db.Client.AutoMigrate(
-		&model.User{},
-	)
+// MiniApp的数据库迁移，新增迁移时追加到末尾，已发布的迁移不能修改，数据表结构及填充数据使用发布时的版本，不能直接使用数据模型
+var Migrations = []*migrate.Migration{
+	{
+		Version:     "20261019000101",
+		Description: "create miniapp tables",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(v20261019000101.Models()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(v20261019000101.Models()...)
+		},
+	},
+	{
+		Version:     "20261019000102",
+		Description: "seed miniapp data",
+		Up: func(tx *gorm.DB) error {
+			return v20261019000102.Seed(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	},
+}
 
-	// 如果用户不存在，初始化数据库数据
-	userInfo, err := (&model.User{}).GetInfoById(1)
-	if err != nil && err != gorm.ErrRecordNotFound {
+// 执行安装操作，执行未完成的数据库迁移，可以重复执行，需要在管理后台安装之后执行
+func Handle() {
+	migrate.Register(Migrations...)
+	err := migrate.Up(db.Client)
+	if err != nil {
 		panic(err)
 	}
-	if userInfo.Id == 0 {
-		// 数据填充
-		(&model.User{}).Seeder()
-	}
 }
@@ -0,0 +1,35 @@
+// 迁移20261019000101创建的数据表结构，迁移发布后不能修改
+//
+// 结构体名称与数据模型一致，以使用相同的表名；之后的结构变更在新的迁移中完成，不能修改本包或使用数据模型代替
+package v20261019000101
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	Id            int               `gorm:"autoIncrement"`
+	Username      string            `gorm:"size:20;index:users_username_unique,unique;not null"`
+	Nickname      string            `gorm:"size:200;not null"`
+	Sex           int               `gorm:"size:4;not null;default:1"`
+	Email         string            `gorm:"size:50;index:users_email_unique,unique;not null"`
+	Phone         string            `gorm:"size:11;index:users_phone_unique,unique;not null"`
+	Password      string            `gorm:"size:255;not null"`
+	Avatar        string            `gorm:"size:1000"`
+	LastLoginIp   string            `gorm:"size:255"`
+	LastLoginTime datetime.Datetime `gorm:"default:null"`
+	WxOpenid      string            `gorm:"size:255"`
+	WxUnionid     string            `gorm:"size:255"`
+	Status        int               `gorm:"size:1;not null;default:1"`
+	CreatedAt     datetime.Datetime
+	UpdatedAt     datetime.Datetime
+	DeletedAt     gorm.DeletedAt
+}
+
+// 迁移创建的全部数据表
+func Models() []interface{} {
+	return []interface{}{
+		&User{},
+	}
+}
@@ -0,0 +1,39 @@
+// 迁移20261019000102填充的初始数据，迁移发布后不能修改
+//
+// 使用迁移20261019000101及管理后台迁移20261019000001的数据表结构，不能使用数据模型代替
+package v20261019000102
+
+import (
+	adminv20261019000001 "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install/v20261019000001"
+	adminv20261019000002 "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/install/v20261019000002"
+	v20261019000101 "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/install/v20261019000101"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
+	"gorm.io/gorm"
+)
+
+// 填充用户菜单及默认用户，菜单路径已存在时跳过，已存在用户（包括已删除的）时不再创建默认用户
+func Seed(tx *gorm.DB) error {
+
+	// 创建菜单
+	menuSeeders := []adminv20261019000001.Menu{
+		{Id: 101, Name: "用户管理", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 0, Path: "/user", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
+		{Id: 102, Name: "用户列表", GuardName: "admin", Icon: "", Type: 2, Pid: 101, Sort: 0, Path: "/api/admin/user/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
+	}
+	err := adminv20261019000002.SeedMenus(tx, menuSeeders)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	err = tx.Unscoped().Model(&v20261019000101.User{}).Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+
+	seeders := []v20261019000101.User{
+		{Username: "tangtanglove", Nickname: "默认用户", Email: "tangtanglove@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: datetime.Now()},
+	}
+
+	return tx.Create(&seeders).Error
+}
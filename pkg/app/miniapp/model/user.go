@@ -1,14 +1,14 @@
 package model
 
 import (
-	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	adminmodel "github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/datetime"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/hash"
 	"gorm.io/gorm"
 )
 
@@ -45,28 +45,6 @@ type UserClaims struct {
 	jwt.RegisteredClaims
 }
 
-// 用户Seeder
-func (model *User) Seeder() {
-
-	// 如果菜单已存在，不执行Seeder操作
-	if (&adminmodel.Menu{}).IsExist(18) {
-		return
-	}
-
-	// 创建菜单
-	menuSeeders := []*adminmodel.Menu{
-		{Id: 18, Name: "用户管理", GuardName: "admin", Icon: "icon-user", Type: 1, Pid: 0, Sort: 0, Path: "/user", Show: 1, IsEngine: 0, IsLink: 0, Status: 1},
-		{Id: 19, Name: "用户列表", GuardName: "admin", Icon: "", Type: 2, Pid: 18, Sort: 0, Path: "/api/admin/user/index", Show: 1, IsEngine: 1, IsLink: 0, Status: 1},
-	}
-	db.Client.Create(&menuSeeders)
-
-	seeders := []User{
-		{Username: "tangtanglove", Nickname: "默认用户", Email: "tangtanglove@yourweb.com", Phone: "10086", Password: hash.Make("123456"), Sex: 1, Status: 1, LastLoginTime: datetime.Now()},
-	}
-
-	db.Client.Create(&seeders)
-}
-
 // 获取用户JWT信息
 func (model *User) GetClaims(UserInfo *User) (userClaims *UserClaims) {
 	userClaims = &UserClaims{
@@ -79,11 +57,11 @@ func (model *User) GetClaims(UserInfo *User) (userClaims *UserClaims) {
 		UserInfo.Avatar,
 		"user",
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 过期时间，默认24小时
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // 颁发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                     // 不早于时间
-			Issuer:    "QuarkGo",                                          // 颁发人
-			Subject:   "User Token",                                       // 主题信息
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(builder.GetJwtExpire())), // 过期时间，默认24小时
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                             // 颁发时间
+			NotBefore: jwt.NewNumericDate(time.Now()),                             // 不早于时间
+			Issuer:    "QuarkGo",                                                  // 颁发人
+			Subject:   "User Token",                                               // 主题信息
 		},
 	}
 
@@ -98,11 +76,11 @@ func (model *User) GetAuthUser(appKey string, tokenString string) (userClaims *U
 	if err != nil {
 		if ve, ok := err.(*jwt.ValidationError); ok {
 			if ve.Errors&jwt.ValidationErrorMalformed != 0 {
-				return nil, errors.New("token格式错误")
+				return nil, i18n.NewError("token.malformed")
 			} else if ve.Errors&jwt.ValidationErrorExpired != 0 {
-				return nil, errors.New("token已过期")
+				return nil, i18n.NewError("token.expired")
 			} else if ve.Errors&jwt.ValidationErrorNotValidYet != 0 {
-				return nil, errors.New("token未生效")
+				return nil, i18n.NewError("token.not_valid_yet")
 			} else {
 				return nil, err
 			}
@@ -113,7 +91,7 @@ func (model *User) GetAuthUser(appKey string, tokenString string) (userClaims *U
 		return claims, nil
 	}
 
-	return nil, errors.New("token不可用")
+	return nil, i18n.NewError("token.invalid")
 }
 
 // 通过ID获取用户信息
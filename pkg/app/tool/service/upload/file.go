@@ -1,6 +1,7 @@
 package upload
 
 import (
+	"errors"
 	"reflect"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/tool/template/upload"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"gorm.io/gorm"
 )
 
 type File struct {
@@ -45,31 +47,24 @@ func (p *File) Init(ctx *builder.Context) interface{} {
 	return p
 }
 
-// 上传前回调
+// 上传前回调，已保存过相同内容的文件时不再扫描及写入存储
 func (p *File) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
-	fileHash, err := fileSystem.GetFileHash()
-	if err != nil {
-		return fileSystem, nil, err
-	}
-
-	getFileInfo, _ := (&model.File{}).GetInfoByHash(fileHash)
-	if err != nil {
-		return fileSystem, nil, err
-	}
-	if getFileInfo.Id != 0 {
-		fileInfo := &storage.FileInfo{
-			Name: getFileInfo.Name,
-			Size: getFileInfo.Size,
-			Ext:  getFileInfo.Ext,
-			Path: getFileInfo.Path,
-			Url:  getFileInfo.Url,
-			Hash: getFileInfo.Hash,
+	return fileSystem.Dedup(func(hash string) (*storage.FileInfo, error) {
+		getFileInfo, err := (&model.File{}).GetInfoByHash(hash)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		return fileSystem, fileInfo, err
-	}
-
-	return fileSystem, nil, err
+		return &storage.FileInfo{
+			Path:       getFileInfo.Path,
+			Url:        getFileInfo.Url,
+			ScanStatus: getFileInfo.ScanStatus,
+			ScanResult: getFileInfo.ScanResult,
+		}, nil
+	}), nil, nil
 }
 
 // 上传完成后回调
@@ -79,23 +74,40 @@ func (p *File) AfterHandle(ctx *builder.Context, result *storage.FileInfo) error
 		Elem().
 		FieldByName("Driver").String()
 
-	// 重写url
-	if driver == storage.LocalDriver {
-		result.Url = (&model.File{}).GetPath(result.Url)
-	}
+	// 相同内容的文件只保存一份，删除本次重复上传的文件并返回已有记录
+	id := 0
+	getFileInfo, _ := (&model.File{}).GetInfoByHash(result.Hash)
+	if getFileInfo.Id != 0 {
+		if getFileInfo.Path != result.Path {
+			if storageDriver, err := p.GetStorageDriver(); err == nil {
+				storageDriver.Delete(ctx.Context(), result.Path)
+			}
+		}
+		id = getFileInfo.Id
+		result.Path = getFileInfo.Path
+		result.Url = getFileInfo.Url
+	} else {
+		// 重写url
+		if driver == storage.LocalDriver {
+			result.Url = (&model.File{}).GetPath(result.Url)
+		}
 
-	// 插入数据库
-	id, err := (&model.File{}).InsertGetId(&model.File{
-		Name:   result.Name,
-		Size:   result.Size,
-		Ext:    result.Ext,
-		Path:   result.Path,
-		Url:    result.Url,
-		Hash:   result.Hash,
-		Status: 1,
-	})
-	if err != nil {
-		return ctx.JSONError(err.Error())
+		// 插入数据库
+		var err error
+		id, err = (&model.File{}).InsertGetId(&model.File{
+			ObjType: upload.QuotaObjType,
+			ObjId:   p.GetUploaderId(ctx),
+			Name:    result.Name,
+			Size:    result.Size,
+			Ext:     result.Ext,
+			Path:    result.Path,
+			Url:     result.Url,
+			Hash:    result.Hash,
+			Status:  1,
+		})
+		if err != nil {
+			return ctx.JSONError(err.Error())
+		}
 	}
 
 	return ctx.JSONOk("上传成功", map[string]interface{}{
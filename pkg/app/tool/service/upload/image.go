@@ -1,6 +1,7 @@
 package upload
 
 import (
+	"errors"
 	"reflect"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/quarkcloudio/quark-go/v2/pkg/app/tool/template/upload"
 	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
 	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"gorm.io/gorm"
 )
 
 type Image struct {
@@ -33,33 +35,22 @@ func (p *Image) Init(ctx *builder.Context) interface{} {
 	return p
 }
 
-// 上传前回调
+// 上传前回调，已保存过相同内容的图片时不再扫描及写入存储
 func (p *Image) BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error) {
-	fileHash, err := fileSystem.GetFileHash()
-	if err != nil {
-		return fileSystem, nil, err
-	}
-
-	pictureInfo, _ := (&model.Picture{}).GetInfoByHash(fileHash)
-	if err != nil {
-		return fileSystem, nil, err
-	}
-	if pictureInfo.Id != 0 {
-		fileInfo := &storage.FileInfo{
-			Name:   pictureInfo.Name,
-			Size:   pictureInfo.Size,
-			Width:  pictureInfo.Width,
-			Height: pictureInfo.Height,
-			Ext:    pictureInfo.Ext,
-			Path:   pictureInfo.Path,
-			Url:    pictureInfo.Url,
-			Hash:   pictureInfo.Hash,
+	return fileSystem.Dedup(func(hash string) (*storage.FileInfo, error) {
+		pictureInfo, err := (&model.Picture{}).GetInfoByHash(hash)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		return fileSystem, fileInfo, err
-	}
-
-	return fileSystem, nil, err
+		return &storage.FileInfo{
+			Path: pictureInfo.Path,
+			Url:  pictureInfo.Url,
+		}, nil
+	}), nil, nil
 }
 
 // 上传完成后回调
@@ -69,26 +60,43 @@ func (p *Image) AfterHandle(ctx *builder.Context, result *storage.FileInfo) erro
 		Elem().
 		FieldByName("Driver").String()
 
-	// 重写url
-	if driver == storage.LocalDriver {
-		result.Url = (&model.Picture{}).GetPath(result.Url)
-	}
-
-	// 插入数据库
-	id, err := (&model.Picture{}).InsertGetId(&model.Picture{
-		Name:   result.Name,
-		Size:   result.Size,
-		Width:  result.Width,
-		Height: result.Height,
-		Ext:    result.Ext,
-		Path:   result.Path,
-		Url:    result.Url,
-		Hash:   result.Hash,
-		Status: 1,
-	})
+	// 相同内容的图片只保存一份，删除本次重复上传的文件并返回已有记录
+	id := 0
+	pictureInfo, _ := (&model.Picture{}).GetInfoByHash(result.Hash)
+	if pictureInfo.Id != 0 {
+		if pictureInfo.Path != result.Path {
+			if storageDriver, err := p.GetStorageDriver(); err == nil {
+				storageDriver.Delete(ctx.Context(), result.Path)
+			}
+		}
+		id = pictureInfo.Id
+		result.Path = pictureInfo.Path
+		result.Url = pictureInfo.Url
+	} else {
+		// 重写url
+		if driver == storage.LocalDriver {
+			result.Url = (&model.Picture{}).GetPath(result.Url)
+		}
 
-	if err != nil {
-		return ctx.JSONError(err.Error())
+		// 插入数据库
+		var err error
+		id, err = (&model.Picture{}).InsertGetId(&model.Picture{
+			ObjType: upload.QuotaObjType,
+			ObjId:   p.GetUploaderId(ctx),
+			Name:    result.Name,
+			Size:    result.Size,
+			Width:   result.Width,
+			Height:  result.Height,
+			Ext:     result.Ext,
+			Path:    result.Path,
+			Url:     result.Url,
+			Hash:    result.Hash,
+			Status:  1,
+		})
+
+		if err != nil {
+			return ctx.JSONError(err.Error())
+		}
 	}
 
 	return ctx.JSONOk("上传成功", map[string]interface{}{
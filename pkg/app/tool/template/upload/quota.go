@@ -0,0 +1,49 @@
+package upload
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/app/admin/model"
+	miniappmodel "github.com/quarkcloudio/quark-go/v2/pkg/app/miniapp/model"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+)
+
+// 文件所属类型，按用户统计存储用量
+const QuotaObjType = "UID"
+
+// 获取当前上传用户的id，未登录时返回0
+func (p *Template) GetUploaderId(ctx *builder.Context) int {
+	userInfo, err := (&miniappmodel.User{}).GetAuthUser(ctx.Engine.GetConfig().AppKey, ctx.Token())
+	if err != nil || userInfo.GuardName != "user" {
+		return 0
+	}
+
+	return userInfo.Id
+}
+
+// 获取当前用户的剩余存储空间，单位为字节，不限制时返回-1；配置了存储配额时，未登录用户不能上传
+func (p *Template) GetQuotaRemaining(ctx *builder.Context) (int64, error) {
+	quota := &model.StorageQuota{}
+	uid := p.GetUploaderId(ctx)
+	if uid == 0 {
+		if quota.IsLimited(QuotaObjType) {
+			return 0, i18n.NewError("storage.quota_login_required")
+		}
+
+		return -1, nil
+	}
+
+	return quota.GetRemaining(QuotaObjType, uid)
+}
+
+// 按剩余空间限制文件大小，大小未知的数据流在写入时检查
+func (p *Template) limitQuota(ctx *builder.Context, fileSystem *storage.FileSystem) error {
+	remaining, err := ctx.Template.(interface {
+		GetQuotaRemaining(ctx *builder.Context) (int64, error)
+	}).GetQuotaRemaining(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fileSystem.LimitQuota(remaining)
+}
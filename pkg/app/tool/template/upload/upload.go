@@ -1,10 +1,8 @@
 package upload
 
 import (
-	"bytes"
 	"encoding/base64"
 	"io"
-	"mime/multipart"
 	"reflect"
 	"strconv"
 	"strings"
@@ -21,10 +19,13 @@ type Template struct {
 	LimitType        interface{}          // 限制文件类型
 	LimitImageWidth  int64                // 限制图片宽度
 	LimitImageHeight int64                // 限制图片高度
-	Driver           string               // 存储驱动
+	Driver           string               // 存储驱动，对应storage中注册的驱动名称，例如：local、oss、minio、s3、memory
 	SavePath         string               // 保存路径
 	OSSConfig        *storage.OSSConfig   // OSS配置
 	MinioConfig      *storage.MinioConfig // Minio配置
+	S3Config         *storage.S3Config    // S3配置
+	Scanners         []storage.Scanner    // 文件内容扫描器
+	QuarantinePath   string               // 隔离目录，未通过扫描的文件移动到该目录，为空时直接删除
 }
 
 // 初始化
@@ -38,8 +39,16 @@ func (p *Template) TemplateInit(ctx *builder.Context) interface{} {
 	// 初始化数据对象
 	p.DB = db.Client
 
-	// 默认本地上传
+	// 默认本地上传，应用配置了默认存储时使用该配置
 	p.Driver = storage.LocalDriver
+	if config := builder.GetConfig(); config != nil && config.Storage != nil {
+		if config.Storage.Driver != "" {
+			p.Driver = config.Storage.Driver
+		}
+		p.OSSConfig = config.Storage.OSSConfig
+		p.MinioConfig = config.Storage.MinioConfig
+		p.S3Config = config.Storage.S3Config
+	}
 
 	return p
 }
@@ -54,6 +63,15 @@ func (p *Template) RouteInit() interface{} {
 	return p
 }
 
+// 获取当前配置的存储驱动
+func (p *Template) GetStorageDriver() (storage.Driver, error) {
+	return storage.GetDriver(p.Driver, &storage.Config{
+		OSSConfig:   p.OSSConfig,
+		MinioConfig: p.MinioConfig,
+		S3Config:    p.S3Config,
+	})
+}
+
 // 执行上传
 func (p *Template) Handle(ctx *builder.Context) error {
 	var (
@@ -121,59 +139,95 @@ func (p *Template) Handle(ctx *builder.Context) error {
 		Elem().
 		FieldByName("MinioConfig").Interface()
 
+	s3Config := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("S3Config").Interface()
+
+	scanners := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("Scanners").Interface()
+
+	quarantinePath := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("QuarantinePath").String()
+
 	savePath := reflect.
 		ValueOf(ctx.Template).
 		Elem().
 		FieldByName("SavePath").String()
 
-	byteReader := bytes.NewReader(ctx.Body())
-	multipartReader := multipart.NewReader(byteReader, strings.TrimLeft(contentTypes[1], "boundary="))
-	for p, err := multipartReader.NextPart(); err != io.EOF; p, err = multipartReader.NextPart() {
-		if p.FormName() == "file" {
-			fileData, _ := io.ReadAll(p)
-			fileSystem := storage.
-				New(&storage.Config{
-					LimitSize:        limitSize,
-					LimitType:        limitType.([]string),
-					LimitImageWidth:  limitImageWidth,
-					LimitImageHeight: limitImageHeight,
-					Driver:           driver,
-					CheckFileExist:   true,
-					OSSConfig:        ossConfig.(*storage.OSSConfig),
-					MinioConfig:      minioConfig.(*storage.MinioConfig),
-				}).
-				Reader(&storage.File{
-					Header:  p.Header,
-					Name:    p.FileName(),
-					Content: fileData,
-				})
-
-			// 上传前回调
-			getFileSystem, fileInfo, err := ctx.Template.(interface {
-				BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
-			}).BeforeHandle(ctx, fileSystem)
-			if err != nil {
-				return ctx.JSONError(err.Error())
-			}
-			if fileInfo != nil {
-				return ctx.Template.(interface {
-					AfterHandle(ctx *builder.Context, result *storage.FileInfo) error
-				}).AfterHandle(ctx, fileInfo)
-			}
-
-			result, err = getFileSystem.
-				WithImageWH().
-				RandName().
-				Path(savePath).
-				Save()
-			if err != nil {
-				return ctx.JSONError(err.Error())
-			}
+	// 逐个读取表单分段，文件内容以数据流形式直接写入存储驱动
+	multipartReader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		return ctx.JSONError("Content-Type must use multipart/form-data")
+	}
+	for {
+		part, err := multipartReader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ctx.JSONError(err.Error())
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		fileSystem := storage.
+			New(&storage.Config{
+				LimitSize:        limitSize,
+				LimitType:        limitType.([]string),
+				LimitImageWidth:  limitImageWidth,
+				LimitImageHeight: limitImageHeight,
+				Driver:           driver,
+				CheckFileExist:   true,
+				OSSConfig:        ossConfig.(*storage.OSSConfig),
+				MinioConfig:      minioConfig.(*storage.MinioConfig),
+				S3Config:         s3Config.(*storage.S3Config),
+				Scanners:         scanners.([]storage.Scanner),
+				QuarantinePath:   quarantinePath,
+			}).
+			WithContext(ctx.Context()).
+			Reader(&storage.File{
+				Header: part.Header,
+				Name:   part.FileName(),
+				Reader: part,
+			})
+
+		// 按剩余存储空间限制文件大小
+		err = p.limitQuota(ctx, fileSystem)
+		if err != nil {
+			return ctx.JSONError(ctx.TError(err))
+		}
+
+		// 上传前回调
+		getFileSystem, fileInfo, err := ctx.Template.(interface {
+			BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
+		}).BeforeHandle(ctx, fileSystem)
+		if err != nil {
+			return ctx.JSONError(err.Error())
+		}
+		if fileInfo != nil {
+			return ctx.Template.(interface {
+				AfterHandle(ctx *builder.Context, result *storage.FileInfo) error
+			}).AfterHandle(ctx, fileInfo)
+		}
+
+		result, err = getFileSystem.
+			WithImageWH().
+			RandName().
+			Path(savePath).
+			Save()
+		if err != nil {
+			return ctx.JSONError(ctx.TError(err))
 		}
 	}
 
-	if err != nil {
-		return ctx.JSONError(err.Error())
+	if result == nil {
+		return ctx.JSONError("参数错误")
 	}
 
 	return ctx.Template.(interface {
@@ -263,6 +317,21 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 		Elem().
 		FieldByName("MinioConfig").Interface()
 
+	s3Config := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("S3Config").Interface()
+
+	scanners := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("Scanners").Interface()
+
+	quarantinePath := reflect.
+		ValueOf(ctx.Template).
+		Elem().
+		FieldByName("QuarantinePath").String()
+
 	fileSystem := storage.
 		New(&storage.Config{
 			LimitSize:        limitSize,
@@ -273,11 +342,21 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 			CheckFileExist:   true,
 			OSSConfig:        ossConfig.(*storage.OSSConfig),
 			MinioConfig:      minioConfig.(*storage.MinioConfig),
+			S3Config:         s3Config.(*storage.S3Config),
+			Scanners:         scanners.([]storage.Scanner),
+			QuarantinePath:   quarantinePath,
 		}).
+		WithContext(ctx.Context()).
 		Reader(&storage.File{
 			Content: fileData,
 		})
 
+	// 按剩余存储空间限制文件大小
+	err = p.limitQuota(ctx, fileSystem)
+	if err != nil {
+		return ctx.JSONError(ctx.TError(err))
+	}
+
 	// 上传前回调
 	getFileSystem, fileInfo, err := ctx.Template.(interface {
 		BeforeHandle(ctx *builder.Context, fileSystem *storage.FileSystem) (*storage.FileSystem, *storage.FileInfo, error)
@@ -298,7 +377,7 @@ func (p *Template) HandleFromBase64(ctx *builder.Context) error {
 		Save()
 
 	if err != nil {
-		return ctx.JSONError(err.Error())
+		return ctx.JSONError(ctx.TError(err))
 	}
 
 	return ctx.Template.(interface {
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"mime/multipart"
@@ -17,6 +18,7 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/labstack/echo/v4"
 	"github.com/mitchellh/mapstructure"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/tag"
 )
 
@@ -29,6 +31,7 @@ type Context struct {
 	Writer      http.ResponseWriter    // ResponseWriter
 	Template    interface{}            // 资源模板实例
 	fullPath    string                 // 路由
+	locale      string                 // 语言
 	Params      map[string]string      // URL param
 	Querys      map[string]interface{} // URL querys
 }
@@ -492,6 +495,20 @@ func (p *Context) Query(params ...interface{}) interface{} {
 	return p.Querys[params[0].(string)]
 }
 
+// SetQuery sets the value of the URL query.
+func (p *Context) SetQuery(key string, value interface{}) *Context {
+	if p.Querys == nil {
+		p.parseQuerys()
+	}
+	if p.Querys == nil {
+		p.Querys = map[string]interface{}{}
+	}
+
+	p.Querys[key] = value
+
+	return p
+}
+
 // AllQuerys returns all query arguments from RequestURI.
 func (p *Context) AllQuerys() map[string]interface{} {
 	if p.Querys == nil {
@@ -518,6 +535,62 @@ func (p *Context) RouterPathToUrl(routerPath string) string {
 	return strings.ReplaceAll(routerPath, ":resource", name)
 }
 
+// 获取当前请求的上下文，客户端断开连接或服务关闭时取消
+func (p *Context) Context() context.Context {
+	if p.Request == nil {
+		return context.Background()
+	}
+
+	return p.Request.Context()
+}
+
+// 设置当前请求的上下文，适配其他框架时用于传递框架请求的上下文，例如上游中间件创建的span
+func (p *Context) SetContext(ctx context.Context) *Context {
+	p.setRequestContext(ctx)
+
+	return p
+}
+
+// 获取当前请求的语言，优先级：URL参数 > 手动设置的语言（如管理员偏好） > Accept-Language > 默认语言
+func (p *Context) Locale() string {
+	if p.Request != nil {
+		for _, key := range []string{"locale", "lang"} {
+			if locale := i18n.Match(p.Request.URL.Query().Get(key)); locale != "" {
+				return locale
+			}
+		}
+	}
+
+	if p.locale != "" {
+		return p.locale
+	}
+
+	if p.Request != nil {
+		if locale := i18n.Match(i18n.ParseAcceptLanguage(p.Request.Header.Get("Accept-Language"))...); locale != "" {
+			return locale
+		}
+	}
+
+	return i18n.Default().GetFallback()
+}
+
+// 设置当前请求的语言
+func (p *Context) SetLocale(locale string) *Context {
+	p.locale = i18n.Match(locale)
+
+	return p
+}
+
+// 翻译，例如：ctx.T("message.success")
+func (p *Context) T(key string, args ...interface{}) string {
+	return i18n.T(p.Locale(), key, args...)
+}
+
+// 翻译错误信息
+func (p *Context) TError(err error) string {
+	return i18n.TranslateError(p.Locale(), err)
+}
+
 // 获取Header中的token
 func (p *Context) Token() string {
 	authorization := p.Header("Authorization")
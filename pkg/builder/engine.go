@@ -1,18 +1,27 @@
 package builder
 
 import (
+	"context"
+	"errors"
 	"io"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/labstack/echo/v4"
 	"github.com/quarkcloudio/quark-go/v2/pkg/dal"
-	"github.com/quarkcloudio/quark-go/v2/pkg/gopkg"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+	"github.com/quarkcloudio/quark-go/v2/web"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
@@ -36,6 +45,11 @@ type Engine struct {
 	providers   []interface{}              // 服务列表
 	urlPaths    []*UrlPath                 // 请求路径列表
 	routePaths  []*RouteMapping            // 路由路径列表
+	routeOnce   sync.Once                  // 保证路由映射只处理一次
+
+	requestStats    *RequestStats                   // 请求统计
+	logger          *slog.Logger                    // 日志记录器
+	tracingShutdown func(ctx context.Context) error // 关闭链路追踪，导出剩余的链路
 }
 
 type RouteMapping struct {
@@ -66,10 +80,32 @@ type Config struct {
 	DBConfig    *DBConfig             // 数据库配置
 	RedisConfig *RedisConfig          // Redis配置
 	CookieStore *sessions.CookieStore // Cookie存储，用于保存Session
-	StaticPath  string                // 静态文件目录
+	StaticPath  string                // 静态文件目录，目录中的文件会覆盖内置资源中的同名文件
+	StaticFS    fs.FS                 // 内置静态文件，默认为内置的WEB资源
+	PrivatePath string                // 私有文件目录，目录中的文件只能通过带签名的地址访问，默认./storage
 	Providers   []interface{}         // 服务列表
+	Locale      string                // 默认语言，例如：zh-CN、en-US
+	LocalePath  string                // 自定义语言包目录，目录下为JSON格式的语言文件，例如：en-US.json
+	JwtExpire   time.Duration         // JWT认证token的有效期，默认24小时
+	Storage     *storage.Config       // 默认存储配置，上传模板默认使用其中的驱动、OSS、Minio、S3配置及文件可见性
+
+	ShutdownTimeout time.Duration // 优雅关闭时等待处理中请求的超时时间，默认10秒
+	MaxBodySize     int64         // 请求体大小上限，超过时返回413，默认为DefaultMaxBodySize，小于0时不限制
+	MetricsPath     string        // Prometheus指标接口路径，例如：/metrics，为空或"-"时不开启
+	MetricsToken    string        // 访问指标接口的令牌，设置后请求需要携带Authorization: Bearer <token>；指标中包含路由、资源名称及登录次数，未设置时需要在网关或防火墙限制访问
+
+	HealthPath         string        // 存活检查接口路径，默认/healthz，为"-"时不开启
+	ReadyPath          string        // 就绪检查接口路径，默认/readyz，为"-"时不开启
+	HealthCheckTimeout time.Duration // 就绪检查中单项检查的超时时间，默认3秒
+	DegradedStart      bool          // 启动时Redis不可用不再panic，服务以降级模式启动，就绪检查返回未就绪，Redis恢复后自动重连
+
+	Logger  *slog.Logger             // 日志记录器，默认为slog.Default()
+	Tracing *telemetry.TracingConfig // 链路追踪配置，为nil时不开启
 }
 
+// 默认请求体大小上限，文件上传默认限制2GB，另加1MB的表单开销
+const DefaultMaxBodySize int64 = 2<<30 + 1<<20
+
 // 定义路由组
 type Group struct {
 	engine    *Engine
@@ -94,18 +130,43 @@ func New(config *Config) *Engine {
 	// 隐藏banner
 	e.HideBanner = true
 
+	// 初始化日志，数据库、存储等模块共用该日志记录器
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	telemetry.SetLogger(logger)
+
+	// 开启链路追踪，需要在初始化数据库前设置，查询的span才能关联到请求
+	var tracingShutdown func(ctx context.Context) error
+	if config.Tracing != nil {
+		shutdown, err := telemetry.InitTracing(config.Tracing)
+		if err != nil {
+			panic(err)
+		}
+		tracingShutdown = shutdown
+	}
+
 	// 初始化数据库
 	if config.DBConfig != nil {
 		dal.InitDB(config.DBConfig.Dialector, config.DBConfig.Opts)
 	}
 
-	// 初始化Redis
+	// 初始化Redis，降级模式下连接失败时只记录日志
 	if config.RedisConfig != nil {
-		dal.InitRedis(&redis.Options{
+		options := &redis.Options{
 			Addr:     config.RedisConfig.Host + ":" + config.RedisConfig.Port,
 			Password: config.RedisConfig.Password,
 			DB:       config.RedisConfig.Database,
-		})
+		}
+		if config.DegradedStart {
+			err := dal.ConnectRedis(options)
+			if err != nil {
+				logger.Warn("redis is unavailable, starting in degraded mode", "addr", options.Addr, "error", err)
+			}
+		} else {
+			dal.InitRedis(options)
+		}
 	}
 
 	cookieStore := sessions.NewCookieStore([]byte(config.AppKey))
@@ -117,10 +178,41 @@ func New(config *Config) *Engine {
 
 	// 定义结构体
 	engine := &Engine{
-		echo:        e,
-		providers:   config.Providers,
-		config:      config,
-		cookieStore: cookieStore,
+		echo:            e,
+		providers:       config.Providers,
+		config:          config,
+		cookieStore:     cookieStore,
+		requestStats:    NewRequestStats(),
+		logger:          logger,
+		tracingShutdown: tracingShutdown,
+	}
+
+	// 记录请求ID、链路、吞吐量、耗时及访问日志
+	e.Use(engine.requestHandler)
+
+	// 默认私有文件目录，不在静态文件目录中，只能通过带签名的地址访问
+	if config.PrivatePath == "" {
+		config.PrivatePath = "./storage"
+	}
+
+	// Prometheus指标、存活检查、就绪检查及私有文件接口，指标接口需要手动开启
+	if config.HealthPath == "" {
+		config.HealthPath = "/healthz"
+	}
+	if config.ReadyPath == "" {
+		config.ReadyPath = "/readyz"
+	}
+	for _, v := range engine.GetHandlerMappings() {
+		if v.Prefix {
+			e.GET(v.Path+"*", echo.WrapHandler(v.Handler))
+			e.HEAD(v.Path+"*", echo.WrapHandler(v.Handler))
+			continue
+		}
+		e.GET(v.Path, echo.WrapHandler(v.Handler))
+	}
+	engine.registerDefaultHealthChecks()
+	if engine.metricsEnabled() && config.MetricsToken == "" {
+		logger.Warn("metrics endpoint is enabled without a token, restrict access to it", "path", config.MetricsPath)
 	}
 
 	// 默认WEB资源目录
@@ -128,9 +220,27 @@ func New(config *Config) *Engine {
 		config.StaticPath = "./web"
 	}
 
-	// 下载静态文件
-	if !file.IsExist(config.StaticPath) {
-		err := gopkg.New(PkgName, Version).Save("web", config.StaticPath)
+	// 默认内置WEB资源
+	if config.StaticFS == nil {
+		config.StaticFS = web.FS
+	}
+
+	// 限制请求体大小，在路由处理前执行
+	if config.MaxBodySize == 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+	if config.MaxBodySize > 0 {
+		e.Pre(engine.bodyLimit)
+	}
+
+	// 设置默认语言
+	if config.Locale != "" {
+		i18n.SetFallback(config.Locale)
+	}
+
+	// 加载自定义语言包
+	if config.LocalePath != "" {
+		err := i18n.LoadDir(config.LocalePath)
 		if err != nil {
 			panic(err)
 		}
@@ -148,6 +258,15 @@ func GetConfig() *Config {
 	return AppConfig
 }
 
+// 获取JWT认证token的有效期，未配置时默认24小时
+func GetJwtExpire() time.Duration {
+	if AppConfig != nil && AppConfig.JwtExpire > 0 {
+		return AppConfig.JwtExpire
+	}
+
+	return 24 * time.Hour
+}
+
 // 获取当前配置
 func (p *Engine) GetConfig() *Config {
 	return p.config
@@ -200,8 +319,14 @@ func (p *Engine) initPaths() {
 	if p.urlPaths != nil && p.routePaths != nil {
 		return
 	}
+
+	// 启动时没有请求，使用空请求创建上下文
+	ctx := p.newStartupContext()
 	for _, provider := range p.providers {
 
+		// 模版初始化，实例初始化后才能确定是否开启REST接口等配置
+		p.initProvider(ctx, provider)
+
 		// 初始化路由
 		provider.(interface {
 			RouteInit() interface{}
@@ -231,7 +356,7 @@ func (p *Engine) initPaths() {
 					// 获取行为
 					actions := provider.(interface {
 						Actions(ctx *Context) []interface{}
-					}).Actions(&Context{})
+					}).Actions(ctx)
 
 					// 解析行为
 					for _, av := range actions {
@@ -239,7 +364,7 @@ func (p *Engine) initPaths() {
 						// 模版初始化
 						av.(interface {
 							TemplateInit(ctx *Context) interface{}
-						}).TemplateInit(&Context{})
+						}).TemplateInit(ctx)
 
 						// uri唯一标识
 						uriKey := av.(interface {
@@ -273,10 +398,26 @@ func (p *Engine) initPaths() {
 						}
 					}
 				}
-				urlPaths = append(urlPaths, &UrlPath{
-					Method: v.Method,
-					Url:    url,
-				})
+
+				// 处理仪表盘卡片，每个卡片单独生成请求路径，用于卡片的权限控制
+				if strings.Contains(url, ":metric") {
+					if dashboard, ok := provider.(interface {
+						Cards(ctx *Context) []interface{}
+						GetMetricUriKey(metric interface{}) string
+					}); ok {
+						for _, card := range p.startupCards(ctx, provider, dashboard.Cards) {
+							urlPaths = append(urlPaths, &UrlPath{
+								Method: v.Method,
+								Url:    strings.Replace(url, ":metric", dashboard.GetMetricUriKey(card), -1),
+							})
+						}
+					}
+				} else {
+					urlPaths = append(urlPaths, &UrlPath{
+						Method: v.Method,
+						Url:    url,
+					})
+				}
 			}
 
 			if !hasRoutePath(routePaths, v.Method, v.Path) {
@@ -289,6 +430,42 @@ func (p *Engine) initPaths() {
 	p.routePaths = routePaths
 }
 
+// 创建启动时使用的上下文
+func (p *Engine) newStartupContext() *Context {
+	return p.NewContext(NewResponse(io.Discard), NewRequest("GET", "/", nil))
+}
+
+// 启动时初始化模板，用户的初始化方法依赖请求数据时只记录日志，不影响启动
+func (p *Engine) initProvider(ctx *Context, provider interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Warn("failed to init provider at startup", "provider", reflect.TypeOf(provider).String(), "panic", r)
+		}
+	}()
+
+	// 模版参数初始化
+	provider.(interface {
+		TemplateInit(ctx *Context) interface{}
+	}).TemplateInit(ctx)
+
+	// 实例初始化
+	provider.(interface {
+		Init(ctx *Context) interface{}
+	}).Init(ctx)
+}
+
+// 启动时获取仪表盘的卡片，用户的Cards方法依赖请求数据时只记录日志，这些卡片只能通过卡片接口的通配路由授权
+func (p *Engine) startupCards(ctx *Context, provider interface{}, cards func(ctx *Context) []interface{}) (result []interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Warn("failed to get dashboard cards at startup", "provider", reflect.TypeOf(provider).String(), "panic", r)
+			result = nil
+		}
+	}()
+
+	return cards(ctx)
+}
+
 // 判断是否存在RoutePath
 func hasRoutePath(routePaths []*RouteMapping, method string, path string) bool {
 	var has bool
@@ -342,9 +519,9 @@ func (p *Engine) handleParser(ctx *Context) error {
 		return ctx.String(200, "unable to find resource instance")
 	}
 
-	// 执行挂载的方法
+	// 执行挂载的方法，同一路径可以根据请求方法挂载不同的方法
 	for _, v := range p.routePaths {
-		if v.Path == ctx.FullPath() {
+		if v.Path == ctx.FullPath() && (v.Method == "Any" || v.Method == ctx.Method()) {
 
 			// 反射实例值
 			value := reflect.ValueOf(templateInstance)
@@ -373,11 +550,20 @@ func (p *Engine) handleParser(ctx *Context) error {
 				continue
 			}
 
-			// 反射执行结果
+			// 反射执行结果，记录模板方法的span
+			parentCtx := ctx.Context()
+			spanCtx, span := telemetry.StartSpan(parentCtx, "template "+funcName,
+				attribute.String("template", value.Type().String()),
+				attribute.String("template.method", funcName),
+				attribute.String("http.route", v.Path),
+			)
+			ctx.setRequestContext(spanCtx)
 			result = method.Call([]reflect.Value{
 				reflect.ValueOf(ctx),
 			})
+			ctx.setRequestContext(parentCtx)
 			if len(result) != 1 {
+				span.End()
 				continue
 			}
 
@@ -385,16 +571,24 @@ func (p *Engine) handleParser(ctx *Context) error {
 			if v, ok := result[0].Interface().(error); ok {
 				err = v
 			}
+			telemetry.EndSpan(span, err)
 		}
 	}
 
 	return err
 }
 
-// 渲染
-func (p *Engine) Render(ctx *Context) error {
+// 渲染，用于适配其他框架，同时记录请求ID、链路、统计、指标及访问日志，链路从适配框架传入的请求头中恢复
+func (p *Engine) Render(ctx *Context) (err error) {
+	start := time.Now()
+	request, span := p.beginRequest(ctx.Request, ctx.Writer.Header(), ctx.FullPath())
+	ctx.setRequest(request)
+	defer func() {
+		p.endRequest(request, ctx.FullPath(), responseStatus(ctx.EchoContext, err), time.Since(start), span, err)
+	}()
+
 	// 初始化模板
-	err := ctx.InitTemplate(ctx)
+	err = ctx.InitTemplate(ctx)
 	if err != nil {
 		return err
 	}
@@ -452,6 +646,19 @@ func (p *Engine) routeMappingParser() {
 	}
 }
 
+// 限制请求体大小，已知长度超限时直接拒绝，未知长度时读取超限后返回错误
+func (p *Engine) bodyLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request()
+		if request.ContentLength > p.config.MaxBodySize {
+			return echo.ErrStatusRequestEntityTooLarge
+		}
+		request.Body = http.MaxBytesReader(c.Response(), request.Body, p.config.MaxBodySize)
+
+		return next(c)
+	}
+}
+
 // 获取Echo框架实例
 func (p *Engine) Echo() *echo.Echo {
 	return p.echo
@@ -480,11 +687,6 @@ func (p *Engine) echoHandle(path string, handle Handle, c echo.Context) error {
 	return err
 }
 
-// 加载静态文件
-func (p *Engine) Static(pathPrefix string, fsRoot string) {
-	p.echo.Static(pathPrefix, fsRoot)
-}
-
 // GET请求
 func (p *Engine) GET(path string, handle Handle) error {
 	p.echo.GET(path, func(c echo.Context) error {
@@ -679,9 +881,71 @@ func (p *Group) Group(path string, handlers ...Handle) *Group {
 
 // Run Server
 func (p *Engine) Run(addr string) {
+	err := p.RunWithContext(context.Background(), addr)
+	if err != nil {
+		p.echo.Logger.Fatal(err)
+	}
+}
+
+// 启动服务，ctx取消后优雅关闭，等待处理中的请求完成后返回
+func (p *Engine) RunWithContext(ctx context.Context, addr string) error {
+	return p.serve(ctx, func() error {
+		return p.echo.Start(addr)
+	})
+}
+
+// 启动HTTPS服务，调用Shutdown后返回
+func (p *Engine) RunTLS(addr string, certFile string, keyFile string) error {
+	return p.serve(context.Background(), func() error {
+		return p.echo.StartTLS(addr, certFile, keyFile)
+	})
+}
+
+// 优雅关闭服务，不再接收新请求，并等待处理中的请求完成，超过ctx期限时强制关闭，之后导出剩余的链路
+func (p *Engine) Shutdown(ctx context.Context) error {
+	err := p.echo.Shutdown(ctx)
+	if p.tracingShutdown != nil {
+		err = errors.Join(err, p.tracingShutdown(ctx))
+	}
+
+	return err
+}
+
+// 启动服务并监听ctx，服务正常关闭时返回nil
+func (p *Engine) serve(ctx context.Context, start func() error) error {
 	// 处理模版上的路由映射关系
-	p.routeMappingParser()
+	p.routeOnce.Do(p.routeMappingParser)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- start()
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := p.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// 启动服务
-	p.echo.Logger.Fatal(p.echo.Start(addr))
+	err := p.Shutdown(shutdownCtx)
+	if err != nil {
+		return err
+	}
+
+	err = <-errChan
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
 }
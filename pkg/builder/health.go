@@ -0,0 +1,242 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/dal/db"
+	redisclient "github.com/quarkcloudio/quark-go/v2/pkg/dal/redis"
+)
+
+// 健康检查状态
+const (
+	HealthStatusOk          = "ok"          // 正常
+	HealthStatusError       = "error"       // 单项检查失败
+	HealthStatusUnavailable = "unavailable" // 存在失败的检查，服务未就绪
+)
+
+// 单项检查的默认超时时间
+const DefaultHealthCheckTimeout = 3 * time.Second
+
+// 就绪检查方法，返回错误时表示依赖不可用，ctx在超时后取消
+type HealthCheck func(ctx context.Context) error
+
+// 单项检查结果
+type HealthCheckResult struct {
+	Status   string `json:"status"`          // 检查状态，ok、error
+	Error    string `json:"error,omitempty"` // 错误信息，就绪检查接口不返回，只记录在日志中
+	Duration int64  `json:"duration"`        // 检查耗时，单位毫秒
+}
+
+// 健康检查报告
+type HealthReport struct {
+	Status string                        `json:"status"`           // 整体状态，ok、unavailable
+	Checks map[string]*HealthCheckResult `json:"checks,omitempty"` // 各项检查结果
+}
+
+// 内置的HTTP接口，用于适配其他框架时注册到对应的路由
+type HandlerMapping struct {
+	Path    string
+	Handler http.Handler
+	Prefix  bool // 为true时Path为路径前缀，需要注册GET、HEAD方法并匹配前缀下的所有路径
+}
+
+var (
+	healthChecksMu sync.RWMutex
+	healthChecks   = map[string]HealthCheck{}
+)
+
+// 注册就绪检查，同名检查会被覆盖，例如：
+//
+//	builder.RegisterHealthCheck("mq", func(ctx context.Context) error {
+//		return mq.Ping(ctx)
+//	})
+func RegisterHealthCheck(name string, check HealthCheck) {
+	healthChecksMu.Lock()
+	defer healthChecksMu.Unlock()
+
+	healthChecks[name] = check
+}
+
+// 注册就绪检查
+func (p *Engine) RegisterHealthCheck(name string, check HealthCheck) {
+	RegisterHealthCheck(name, check)
+}
+
+// 获取已注册的就绪检查名称
+func HealthCheckNames() []string {
+	healthChecksMu.RLock()
+	defer healthChecksMu.RUnlock()
+
+	names := []string{}
+	for k := range healthChecks {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// 注册内置的数据库及Redis检查
+func (p *Engine) registerDefaultHealthChecks() {
+	if p.config.DBConfig != nil {
+		RegisterHealthCheck("database", func(ctx context.Context) error {
+			if db.Client == nil {
+				return errors.New("database is not initialized")
+			}
+			sqlDB, err := db.Client.DB()
+			if err != nil {
+				return err
+			}
+
+			return sqlDB.PingContext(ctx)
+		})
+	}
+
+	if p.config.RedisConfig != nil {
+		RegisterHealthCheck("redis", func(ctx context.Context) error {
+			if redisclient.Client == nil {
+				return errors.New("redis is not initialized")
+			}
+
+			return redisclient.Client.Ping(ctx).Err()
+		})
+	}
+}
+
+// 并发执行所有就绪检查，每项检查的超时时间为Config.HealthCheckTimeout
+func (p *Engine) CheckReady(ctx context.Context) *HealthReport {
+	healthChecksMu.RLock()
+	checks := map[string]HealthCheck{}
+	for k, v := range healthChecks {
+		checks[k] = v
+	}
+	healthChecksMu.RUnlock()
+
+	timeout := p.config.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	report := &HealthReport{Status: HealthStatusOk, Checks: map[string]*HealthCheckResult{}}
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := runHealthCheck(checkCtx, check)
+			result := &HealthCheckResult{Status: HealthStatusOk, Duration: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = HealthStatusError
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = HealthStatusUnavailable
+			}
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// 执行单项检查，检查方法未响应ctx的取消时，超时后直接返回超时错误
+func runHealthCheck(ctx context.Context, check HealthCheck) (err error) {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- errors.New("health check panic")
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 存活检查接口，服务能够响应请求即返回200，不检查依赖
+func (p *Engine) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, http.StatusOK, &HealthReport{Status: HealthStatusOk})
+	})
+}
+
+// 就绪检查接口，所有检查通过时返回200，否则返回503及各项检查的状态
+//
+// 接口通常不需要认证，错误信息可能包含数据库地址、迁移名称等内部信息，只记录在日志中，不返回给调用方
+func (p *Engine) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := p.CheckReady(r.Context())
+
+		status := http.StatusOK
+		if report.Status != HealthStatusOk {
+			status = http.StatusServiceUnavailable
+		}
+		for name, result := range report.Checks {
+			if result.Error != "" {
+				p.logger.WarnContext(r.Context(), "health check failed", "check", name, "error", result.Error)
+				result.Error = ""
+			}
+		}
+		writeHealthReport(w, status, report)
+	})
+}
+
+// 输出检查报告
+func writeHealthReport(w http.ResponseWriter, status int, report *HealthReport) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+// 获取开启的内置接口：Prometheus指标、存活检查、就绪检查及私有文件，适配其他框架时将其注册到对应的路由
+func (p *Engine) GetHandlerMappings() []*HandlerMapping {
+	mappings := []*HandlerMapping{}
+	if p.metricsEnabled() {
+		mappings = append(mappings, &HandlerMapping{Path: p.config.MetricsPath, Handler: p.MetricsHandler()})
+	}
+	if p.config.HealthPath != "-" {
+		mappings = append(mappings, &HandlerMapping{Path: p.config.HealthPath, Handler: p.HealthHandler()})
+	}
+	if p.config.ReadyPath != "-" {
+		mappings = append(mappings, &HandlerMapping{Path: p.config.ReadyPath, Handler: p.ReadyHandler()})
+	}
+	mappings = append(mappings, &HandlerMapping{Path: PrivatePathPrefix, Handler: p.PrivateHandler(), Prefix: true})
+
+	return mappings
+}
+
+// 判断是否为内置接口的路由，探针及指标采集的请求频繁，访问日志使用Debug级别
+func (p *Engine) isHandlerMappingRoute(route string) bool {
+	for _, v := range p.GetHandlerMappings() {
+		if !v.Prefix && v.Path == route {
+			return true
+		}
+	}
+
+	return false
+}
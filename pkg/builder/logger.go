@@ -0,0 +1,151 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-basic/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 请求ID的请求头，请求中未携带时自动生成，并在响应头中返回
+const RequestIdHeader = "X-Request-Id"
+
+// 请求ID的最大长度，超过时重新生成，避免将过长的请求头写入日志
+const maxRequestIdLength = 128
+
+// 获取日志记录器
+func (p *Engine) Logger() *slog.Logger {
+	return p.logger
+}
+
+// 获取当前请求的ID
+func (p *Context) RequestId() string {
+	if p.Request == nil {
+		return ""
+	}
+
+	return p.Request.Header.Get(RequestIdHeader)
+}
+
+// 获取当前请求的日志记录器，附带请求ID、链路ID、管理员ID、资源名称及行为标识
+//
+//	ctx.Logger().Error("import failed", "error", err)
+func (p *Context) Logger() *slog.Logger {
+	logger := telemetry.Logger()
+	if p.Engine != nil {
+		logger = p.Engine.Logger()
+	}
+	if p.Request == nil {
+		return logger
+	}
+
+	attrs := []interface{}{slog.String(telemetry.RequestIdKey, p.RequestId())}
+	if traceId := telemetry.TraceId(p.Context()); traceId != "" {
+		attrs = append(attrs, slog.String(telemetry.TraceIdKey, traceId))
+	}
+	if p.Engine != nil && p.Token() != "" {
+		if claims, err := p.JwtAuthUserMap(); err == nil && claims["id"] != nil {
+			attrs = append(attrs, slog.String(telemetry.AdminIdKey, fmt.Sprint(claims["id"])))
+		}
+	}
+	if resource := p.ResourceName(); resource != "" {
+		attrs = append(attrs, slog.String(telemetry.ResourceKey, resource))
+	}
+	if uriKey := p.Param("uriKey"); uriKey != "" {
+		attrs = append(attrs, slog.String(telemetry.UriKeyKey, uriKey))
+	}
+
+	return logger.With(attrs...)
+}
+
+// 替换当前请求，同时更新Echo框架上下文中的请求
+func (p *Context) setRequest(request *http.Request) {
+	p.Request = request
+	if p.EchoContext != nil {
+		p.EchoContext.SetRequest(request)
+	}
+}
+
+// 替换当前请求的上下文，用于传递span
+func (p *Context) setRequestContext(ctx context.Context) {
+	if p.Request != nil {
+		p.setRequest(p.Request.WithContext(ctx))
+	}
+}
+
+// 请求开始，生成请求ID，并从请求头中恢复上游的链路，开始请求的span
+func (p *Engine) beginRequest(request *http.Request, responseHeader http.Header, route string) (*http.Request, trace.Span) {
+	p.requestStats.Begin()
+
+	requestId := request.Header.Get(RequestIdHeader)
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		requestId = uuid.New()
+		request.Header.Set(RequestIdHeader, requestId)
+	}
+	responseHeader.Set(RequestIdHeader, requestId)
+
+	ctx, span := telemetry.StartServerSpan(request.Context(), request.Header, request.Method+" "+route,
+		attribute.String("http.method", request.Method),
+		attribute.String("http.route", route),
+		attribute.String("http.target", request.URL.Path),
+		attribute.String(telemetry.RequestIdKey, requestId),
+	)
+
+	return request.WithContext(ctx), span
+}
+
+// 请求结束，记录请求统计、Prometheus指标、span及访问日志
+func (p *Engine) endRequest(request *http.Request, route string, status int, duration time.Duration, span trace.Span, err error) {
+	p.requestStats.End(status, duration)
+	telemetry.ObserveRequest(request.Method, route, status, duration)
+
+	if err == nil && status >= http.StatusInternalServerError {
+		err = errors.New(http.StatusText(status))
+	}
+	span.SetAttributes(attribute.Int("http.status_code", status))
+	telemetry.EndSpan(span, err)
+
+	level := slog.LevelInfo
+	if p.isHandlerMappingRoute(route) {
+		level = slog.LevelDebug
+	} else if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String(telemetry.RequestIdKey, request.Header.Get(RequestIdHeader)),
+		slog.String("method", request.Method),
+		slog.String("route", route),
+		slog.String("path", request.URL.Path),
+		slog.Int("status", status),
+		slog.Duration("duration", duration),
+	}
+	if traceId := telemetry.TraceId(request.Context()); traceId != "" {
+		attrs = append(attrs, slog.String(telemetry.TraceIdKey, traceId))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	p.logger.LogAttrs(request.Context(), level, "request", attrs...)
+}
+
+// 记录请求的ID、链路、统计及访问日志，route为匹配的路由
+func (p *Engine) requestHandler(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		request, span := p.beginRequest(c.Request(), c.Response().Header(), c.Path())
+		c.SetRequest(request)
+
+		err := next(c)
+		p.endRequest(request, c.Path(), responseStatus(c, err), time.Since(start), span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,140 @@
+package builder
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 带签名地址的默认有效期
+const DefaultSignExpire = time.Hour
+
+// 私有文件的访问路径前缀
+const PrivatePathPrefix = "/private/"
+
+// 签名错误
+var (
+	ErrSignatureInvalid = i18n.NewError("message.signature_invalid")
+	ErrSignatureExpired = i18n.NewError("message.signature_expired")
+)
+
+// 使用应用Key计算路径的签名
+func signature(urlPath string, expires string) string {
+	key := ""
+	if AppConfig != nil {
+		key = AppConfig.AppKey
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(urlPath + "\n" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 使用应用Key计算内容的签名，与VerifySignature配合使用，用于验证由服务端签发的数据，expires为过期时间的Unix时间戳
+func Sign(value string, expires string) string {
+	return signature(value, expires)
+}
+
+// 生成带签名的临时访问地址，urlPath为站内路径，可以包含查询参数，签名只包含路径部分
+//
+//	url := builder.SignURL("/private/failImports/demo.xlsx", time.Hour)
+func SignURL(urlPath string, expire time.Duration) string {
+	if expire <= 0 {
+		expire = DefaultSignExpire
+	}
+
+	query := ""
+	if index := strings.Index(urlPath, "?"); index >= 0 {
+		urlPath, query = urlPath[:index], urlPath[index+1:]
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expire).Unix(), 10)
+	values := url.Values{}
+	values.Set("expires", expires)
+	values.Set("signature", signature(urlPath, expires))
+	if query != "" {
+		query = query + "&"
+	}
+
+	return (&url.URL{Path: urlPath}).EscapedPath() + "?" + query + values.Encode()
+}
+
+// 验证路径的签名，urlPath为不包含查询参数的路径
+func VerifySignature(urlPath string, expires string, sign string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature(urlPath, expires)), []byte(sign)) {
+		return ErrSignatureInvalid
+	}
+	if time.Now().Unix() > expiresAt {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// 验证当前请求地址的签名
+func (p *Context) VerifySignature() error {
+	return VerifySignature(p.Path(), p.QueryParam("expires"), p.QueryParam("signature"))
+}
+
+// 生成私有文件的临时访问地址，name为私有文件目录中的相对路径
+//
+//	url := builder.PrivateURL("failImports/demo.xlsx", time.Hour)
+func PrivateURL(name string, expire time.Duration) string {
+	return SignURL(PrivatePathPrefix+strings.TrimPrefix(name, "/"), expire)
+}
+
+// 获取私有文件处理器，访问需要带有效的签名，请求路径为PrivatePathPrefix加上私有文件目录中的相对路径
+func (p *Engine) PrivateHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := p.NewContext(w, r)
+		err := ctx.VerifySignature()
+		if err != nil {
+			ctx.String(http.StatusForbidden, ctx.TError(err))
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(r.URL.Path, PrivatePathPrefix)), "/")
+		if !fs.ValidPath(name) || name == "." {
+			http.NotFound(w, r)
+			return
+		}
+
+		f, err := os.DirFS(p.config.PrivatePath).Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "private, no-store")
+		http.ServeContent(w, r, info.Name(), info.ModTime(), f.(io.ReadSeeker))
+	})
+}
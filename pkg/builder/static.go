@@ -0,0 +1,355 @@
+package builder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+)
+
+// 可压缩的文件类型
+var staticCompressibleExts = map[string]bool{
+	".html": true,
+	".htm":  true,
+	".css":  true,
+	".js":   true,
+	".mjs":  true,
+	".json": true,
+	".map":  true,
+	".svg":  true,
+	".txt":  true,
+	".xml":  true,
+	".ico":  true,
+	".wasm": true,
+}
+
+const (
+	// 小于该大小的文件不压缩
+	staticCompressMinSize = 1024
+
+	// 大于该大小的文件不压缩
+	staticCompressMaxSize = 8 << 20
+
+	// 非HTML文件的缓存时间
+	staticCacheControl = "public, max-age=3600"
+)
+
+// 多层文件系统，依次从每层中查找文件，用于本地目录覆盖内置资源
+type layeredFS []fs.FS
+
+func (p layeredFS) Open(name string) (fs.File, error) {
+	var err error = fs.ErrNotExist
+	for _, v := range p {
+		var f fs.File
+		f, err = v.Open(name)
+		if err == nil {
+			return f, nil
+		}
+	}
+
+	return nil, err
+}
+
+// 静态文件缓存
+type staticEntry struct {
+	size        int64
+	modTime     time.Time
+	etag        string
+	contentType string
+	mu          sync.Mutex
+	variants    map[string][]byte // 编码 => 压缩后的内容
+}
+
+// 静态文件处理器
+type StaticHandler struct {
+	fsys  fs.FS
+	cache sync.Map // 文件路径 => *staticEntry
+}
+
+// 创建静态文件处理器
+func NewStaticHandler(fsys fs.FS) *StaticHandler {
+	return &StaticHandler{fsys: fsys}
+}
+
+// 实现http.Handler接口，请求路径即为文件路径，可配合http.StripPrefix使用
+func (p *StaticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := p.Serve(w, r, r.URL.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// 输出文件，文件不存在时返回fs.ErrNotExist
+func (p *StaticHandler) Serve(w http.ResponseWriter, r *http.Request, name string) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return fs.ErrNotExist
+	}
+
+	name = strings.Trim(path.Clean("/"+name), "/")
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(p.fsys, name)
+	if err != nil {
+		return err
+	}
+
+	// 目录输出index.html，与http.FileServer一致，不以/结尾时重定向
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			target := path.Base(r.URL.Path) + "/"
+			if r.URL.RawQuery != "" {
+				target = target + "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return nil
+		}
+
+		name = path.Join(name, "index.html")
+		info, err = fs.Stat(p.fsys, name)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fs.ErrNotExist
+		}
+	}
+
+	entry, err := p.entry(name, info)
+	if err != nil {
+		return err
+	}
+
+	header := w.Header()
+	ext := strings.ToLower(path.Ext(name))
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = entry.contentType
+	}
+	header.Set("Content-Type", contentType)
+	if ext == ".html" || ext == ".htm" {
+		header.Set("Cache-Control", "no-cache")
+	} else {
+		header.Set("Cache-Control", staticCacheControl)
+	}
+
+	// 优先输出压缩后的内容
+	if staticCompressibleExts[ext] {
+		header.Add("Vary", "Accept-Encoding")
+
+		encoding, content := p.variant(name, entry, r.Header.Get("Accept-Encoding"))
+		if encoding != "" {
+			header.Set("Content-Encoding", encoding)
+			header.Set("ETag", `"`+entry.etag+"-"+encoding+`"`)
+			http.ServeContent(w, r, name, info.ModTime(), bytes.NewReader(content))
+			return nil
+		}
+	}
+
+	header.Set("ETag", `"`+entry.etag+`"`)
+
+	f, err := p.fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// 内置文件及本地文件均支持Seek，否则读取全部内容
+	if seeker, ok := f.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, name, info.ModTime(), seeker)
+		return nil
+	}
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	http.ServeContent(w, r, name, info.ModTime(), bytes.NewReader(content))
+
+	return nil
+}
+
+// 获取文件缓存，文件大小或修改时间变化时重新计算
+func (p *StaticHandler) entry(name string, info fs.FileInfo) (*staticEntry, error) {
+	if v, ok := p.cache.Load(name); ok {
+		entry := v.(*staticEntry)
+		if entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+			return entry, nil
+		}
+	}
+
+	f, err := p.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// 读取文件头用于识别文件类型
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	hash := sha256.New()
+	hash.Write(head)
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+
+	entry := &staticEntry{
+		size:        info.Size(),
+		modTime:     info.ModTime(),
+		etag:        hex.EncodeToString(hash.Sum(nil))[:20],
+		contentType: http.DetectContentType(head),
+		variants:    map[string][]byte{},
+	}
+	p.cache.Store(name, entry)
+
+	return entry, nil
+}
+
+// 根据Accept-Encoding获取压缩后的内容，优先使用预压缩的.br、.gz文件
+func (p *StaticHandler) variant(name string, entry *staticEntry, acceptEncoding string) (string, []byte) {
+	accepts := map[string]bool{}
+	for _, v := range strings.Split(acceptEncoding, ",") {
+		v = strings.TrimSpace(strings.Split(v, ";")[0])
+		accepts[v] = true
+	}
+
+	for _, encoding := range []string{"br", "gzip"} {
+		if !accepts[encoding] {
+			continue
+		}
+
+		entry.mu.Lock()
+		content, ok := entry.variants[encoding]
+		if !ok {
+			content = p.compress(name, entry, encoding)
+			entry.variants[encoding] = content
+		}
+		entry.mu.Unlock()
+
+		if content != nil {
+			return encoding, content
+		}
+	}
+
+	return "", nil
+}
+
+// 压缩文件，无法压缩或压缩无收益时返回nil
+func (p *StaticHandler) compress(name string, entry *staticEntry, encoding string) []byte {
+	suffix := ".gz"
+	if encoding == "br" {
+		suffix = ".br"
+	}
+
+	// 预压缩文件
+	if content, err := fs.ReadFile(p.fsys, name+suffix); err == nil {
+		return content
+	}
+
+	if entry.size < staticCompressMinSize || entry.size > staticCompressMaxSize {
+		return nil
+	}
+
+	content, err := fs.ReadFile(p.fsys, name)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		buf    bytes.Buffer
+		writer io.WriteCloser
+	)
+	if encoding == "br" {
+		writer = brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+	} else {
+		writer, _ = gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	}
+	if _, err := writer.Write(content); err != nil {
+		return nil
+	}
+	if err := writer.Close(); err != nil {
+		return nil
+	}
+	if buf.Len() >= len(content) {
+		return nil
+	}
+
+	return buf.Bytes()
+}
+
+// 获取静态文件目录对应的文件系统，本地目录优先，其次为内置资源
+func (p *Engine) staticFS(fsRoot string) fs.FS {
+	layers := layeredFS{os.DirFS(fsRoot)}
+
+	if p.config.StaticFS == nil {
+		return layers
+	}
+
+	// 将本地目录映射为内置资源中的目录，例如：./web/app => app
+	rel, err := filepath.Rel(p.config.StaticPath, fsRoot)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return layers
+	}
+
+	sub, err := fs.Sub(p.config.StaticFS, filepath.ToSlash(rel))
+	if err != nil {
+		return layers
+	}
+
+	return append(layers, sub)
+}
+
+// 加载静态文件，本地目录中的文件会覆盖内置资源中的同名文件
+func (p *Engine) Static(pathPrefix string, fsRoot string) {
+	p.StaticFS(pathPrefix, p.staticFS(fsRoot))
+}
+
+// 加载文件系统中的静态文件
+func (p *Engine) StaticFS(pathPrefix string, fsys fs.FS) {
+	handler := NewStaticHandler(fsys)
+	echoHandler := func(c echo.Context) error {
+		err := handler.Serve(c.Response(), c.Request(), c.Param("*"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return echo.ErrNotFound
+		}
+
+		return err
+	}
+
+	pathPrefix = strings.TrimSuffix(pathPrefix, "/")
+	if pathPrefix != "" {
+		p.echo.GET(pathPrefix, echoHandler)
+		p.echo.HEAD(pathPrefix, echoHandler)
+	}
+	p.echo.GET(pathPrefix+"/*", echoHandler)
+	p.echo.HEAD(pathPrefix+"/*", echoHandler)
+}
+
+// 获取静态文件处理器，用于适配其他框架，本地目录中的文件会覆盖内置资源中的同名文件
+func (p *Engine) StaticHandler(fsRoot string) *StaticHandler {
+	return NewStaticHandler(p.staticFS(fsRoot))
+}
@@ -0,0 +1,195 @@
+package builder
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+)
+
+// 请求耗时统计的区间上限，单位毫秒，超过最后一个区间的请求计入+Inf区间
+var RequestDurationBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// 吞吐量统计的时间窗口，单位秒
+const requestRateWindow = 60
+
+// 请求统计
+type RequestStats struct {
+	mu          sync.Mutex
+	startedAt   time.Time
+	total       uint64
+	inFlight    int64
+	statuses    map[string]uint64
+	durationSum time.Duration
+	durationMax time.Duration
+	buckets     []uint64
+	seconds     [requestRateWindow]uint64
+	secondAt    [requestRateWindow]int64
+}
+
+// 请求耗时区间
+type RequestDurationBucket struct {
+	Le    float64 // 区间上限，单位毫秒，为0时表示+Inf
+	Count uint64  // 区间内的请求数，不累计之前的区间
+}
+
+// 请求统计的快照
+type RequestStatsSnapshot struct {
+	StartedAt   time.Time               // 开始统计的时间
+	Total       uint64                  // 请求总数
+	InFlight    int64                   // 处理中的请求数
+	Statuses    map[string]uint64       // 按状态码分类的请求数，例如：2xx、4xx
+	DurationSum time.Duration           // 请求总耗时
+	DurationMax time.Duration           // 最大耗时
+	Buckets     []RequestDurationBucket // 请求耗时分布
+	Rate        float64                 // 最近一分钟平均每秒的请求数
+}
+
+// 创建请求统计
+func NewRequestStats() *RequestStats {
+	return &RequestStats{
+		startedAt: time.Now(),
+		statuses:  map[string]uint64{},
+		buckets:   make([]uint64, len(RequestDurationBuckets)+1),
+	}
+}
+
+// 获取请求统计
+func (p *Engine) GetRequestStats() *RequestStats {
+	return p.requestStats
+}
+
+// 开始处理请求
+func (p *RequestStats) Begin() {
+	p.mu.Lock()
+	p.inFlight++
+	p.mu.Unlock()
+}
+
+// 请求处理完成，记录状态码及耗时
+func (p *RequestStats) End(status int, duration time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inFlight--
+	p.total++
+	p.statuses[strconv.Itoa(status/100)+"xx"]++
+	p.durationSum += duration
+	if duration > p.durationMax {
+		p.durationMax = duration
+	}
+
+	ms := float64(duration) / float64(time.Millisecond)
+	index := len(RequestDurationBuckets)
+	for k, le := range RequestDurationBuckets {
+		if ms <= le {
+			index = k
+			break
+		}
+	}
+	p.buckets[index]++
+
+	// 按秒记录请求数，用于计算吞吐量
+	now := time.Now().Unix()
+	slot := now % requestRateWindow
+	if p.secondAt[slot] != now {
+		p.secondAt[slot] = now
+		p.seconds[slot] = 0
+	}
+	p.seconds[slot]++
+}
+
+// 获取统计快照
+func (p *RequestStats) Snapshot() *RequestStatsSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshot := &RequestStatsSnapshot{
+		StartedAt:   p.startedAt,
+		Total:       p.total,
+		InFlight:    p.inFlight,
+		Statuses:    map[string]uint64{},
+		DurationSum: p.durationSum,
+		DurationMax: p.durationMax,
+	}
+	for k, v := range p.statuses {
+		snapshot.Statuses[k] = v
+	}
+	for k, v := range p.buckets {
+		le := float64(0)
+		if k < len(RequestDurationBuckets) {
+			le = RequestDurationBuckets[k]
+		}
+		snapshot.Buckets = append(snapshot.Buckets, RequestDurationBucket{Le: le, Count: v})
+	}
+
+	// 最近一分钟的吞吐量，启动不足一分钟时按已运行的时间计算
+	now := time.Now().Unix()
+	count := uint64(0)
+	for k, v := range p.seconds {
+		if now-p.secondAt[k] < requestRateWindow {
+			count += v
+		}
+	}
+	window := time.Since(p.startedAt).Seconds()
+	if window > requestRateWindow {
+		window = requestRateWindow
+	}
+	if window < 1 {
+		window = 1
+	}
+	snapshot.Rate = float64(count) / window
+
+	return snapshot
+}
+
+// 平均耗时
+func (p *RequestStatsSnapshot) Average() time.Duration {
+	if p.Total == 0 {
+		return 0
+	}
+
+	return p.DurationSum / time.Duration(p.Total)
+}
+
+// 获取响应的状态码，返回错误时状态码由错误处理方法写入，这里按错误推算
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code
+	}
+
+	return http.StatusInternalServerError
+}
+
+// 是否开启指标接口
+func (p *Engine) metricsEnabled() bool {
+	return p.config.MetricsPath != "" && p.config.MetricsPath != "-"
+}
+
+// Prometheus文本格式的指标接口，适配其他框架时可以将该接口注册到对应的路由；设置了MetricsToken时校验请求的令牌
+func (p *Engine) MetricsHandler() http.Handler {
+	handler := telemetry.Handler()
+	if p.config.MetricsToken == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + p.config.MetricsToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
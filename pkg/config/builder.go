@@ -0,0 +1,161 @@
+package config
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/sessions"
+	"github.com/quarkcloudio/quark-go/v2/pkg/builder"
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+	"gorm.io/gorm"
+)
+
+// 转换为builder的配置，例如：
+//
+//	cfg := config.MustLoad("config.yaml")
+//	builderConfig, err := cfg.BuilderConfig(providers)
+//	b := builder.New(builderConfig)
+//	b.Run(cfg.Addr)
+func (p *Config) BuilderConfig(providers []interface{}) (*builder.Config, error) {
+	dialector, err := p.Database.Dialector()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &builder.Config{
+		AppKey: p.AppKey,
+		DBConfig: &builder.DBConfig{
+			Dialector: dialector,
+			Opts:      &gorm.Config{},
+		},
+		CookieStore:     p.cookieStore(),
+		StaticPath:      p.StaticPath,
+		PrivatePath:     p.PrivatePath,
+		Providers:       providers,
+		Locale:          p.Locale,
+		LocalePath:      p.LocalePath,
+		JwtExpire:       p.JWT.Expire.Duration(),
+		Storage:         p.storageConfig(),
+		ShutdownTimeout: p.ShutdownTimeout.Duration(),
+		MaxBodySize:     p.MaxBodySize,
+		MetricsPath:     p.MetricsPath,
+		MetricsToken:    p.MetricsToken,
+		HealthPath:      p.HealthPath,
+		ReadyPath:       p.ReadyPath,
+		DegradedStart:   p.DegradedStart,
+		Logger:          p.logger(),
+	}
+
+	if p.Redis.Host != "" {
+		config.RedisConfig = &builder.RedisConfig{
+			Host:     p.Redis.Host,
+			Port:     p.Redis.Port,
+			Password: p.Redis.Password,
+			Database: p.Redis.Database,
+		}
+	}
+
+	if p.Tracing.Exporter != "" {
+		config.Tracing = &telemetry.TracingConfig{
+			Exporter:    p.Tracing.Exporter,
+			Endpoint:    p.Tracing.Endpoint,
+			Headers:     p.Tracing.Headers,
+			Insecure:    p.Tracing.Insecure,
+			ServiceName: p.Tracing.ServiceName,
+			SampleRatio: p.Tracing.SampleRatio,
+		}
+	}
+
+	return config, nil
+}
+
+// 创建Cookie存储
+func (p *Config) cookieStore() *sessions.CookieStore {
+	sameSite := http.SameSiteLaxMode
+	switch strings.ToLower(p.Session.SameSite) {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	cookieStore := sessions.NewCookieStore([]byte(p.AppKey))
+	cookieStore.Options = &sessions.Options{
+		Path:     p.Session.Path,
+		Domain:   p.Session.Domain,
+		MaxAge:   int(p.Session.MaxAge.Duration().Seconds()),
+		Secure:   p.Session.Secure != nil && *p.Session.Secure,
+		HttpOnly: p.Session.HttpOnly == nil || *p.Session.HttpOnly,
+		SameSite: sameSite,
+	}
+	cookieStore.MaxAge(cookieStore.Options.MaxAge)
+
+	return cookieStore
+}
+
+// 创建默认存储配置，未设置驱动时返回nil
+func (p *Config) storageConfig() *storage.Config {
+	if p.Storage.Driver == "" && p.Storage.Visibility == "" {
+		return nil
+	}
+
+	config := &storage.Config{
+		Driver:     p.Storage.Driver,
+		Visibility: p.Storage.Visibility,
+	}
+	if p.Storage.OSS.Endpoint != "" {
+		config.OSSConfig = &storage.OSSConfig{
+			Endpoint:        p.Storage.OSS.Endpoint,
+			AccessKeyID:     p.Storage.OSS.AccessKeyID,
+			AccessKeySecret: p.Storage.OSS.AccessKeySecret,
+			BucketName:      p.Storage.OSS.BucketName,
+			Domain:          p.Storage.OSS.Domain,
+		}
+	}
+	if p.Storage.Minio.Endpoint != "" {
+		config.MinioConfig = &storage.MinioConfig{
+			Endpoint:        p.Storage.Minio.Endpoint,
+			AccessKeyID:     p.Storage.Minio.AccessKeyID,
+			SecretAccessKey: p.Storage.Minio.SecretAccessKey,
+			UseSSL:          p.Storage.Minio.UseSSL,
+			BucketName:      p.Storage.Minio.BucketName,
+			Domain:          p.Storage.Minio.Domain,
+		}
+	}
+	if p.Storage.S3.BucketName != "" {
+		config.S3Config = &storage.S3Config{
+			Endpoint:        p.Storage.S3.Endpoint,
+			Region:          p.Storage.S3.Region,
+			AccessKeyID:     p.Storage.S3.AccessKeyID,
+			SecretAccessKey: p.Storage.S3.SecretAccessKey,
+			BucketName:      p.Storage.S3.BucketName,
+			UsePathStyle:    p.Storage.S3.UsePathStyle,
+			Domain:          p.Storage.S3.Domain,
+		}
+	}
+
+	return config
+}
+
+// 创建日志记录器，输出到标准输出
+func (p *Config) logger() *slog.Logger {
+	level := slog.LevelInfo
+	switch strings.ToLower(p.Log.Level) {
+	case "debug":
+		level = slog.LevelDebug
+	case "warn":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	if strings.ToLower(p.Log.Format) == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stdout, options))
+	}
+
+	return slog.New(slog.NewTextHandler(os.Stdout, options))
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"time"
+)
+
+// 运行环境
+const (
+	ProfileDev  = "dev"  // 开发环境
+	ProfileTest = "test" // 测试环境
+	ProfileProd = "prod" // 生产环境
+)
+
+// 应用配置，可以从YAML、TOML、JSON格式的文件中加载，并使用环境变量覆盖
+//
+// 环境变量的名称为前缀加上配置的路径，例如：QUARK_APP_KEY、QUARK_DATABASE_DSN、QUARK_REDIS_HOST
+type Config struct {
+	Profile         string   `json:"profile" yaml:"profile" toml:"profile"`                            // 运行环境：dev、test、prod，默认dev
+	Addr            string   `json:"addr" yaml:"addr" toml:"addr"`                                     // 监听地址，默认:3000
+	AppKey          string   `json:"app_key" yaml:"app_key" toml:"app_key"`                            // 应用加密Key，用于JWT认证、Session及签名地址，生产环境需要至少32位的随机字符串
+	Locale          string   `json:"locale" yaml:"locale" toml:"locale"`                               // 默认语言，例如：zh-CN、en-US
+	LocalePath      string   `json:"locale_path" yaml:"locale_path" toml:"locale_path"`                // 自定义语言包目录
+	StaticPath      string   `json:"static_path" yaml:"static_path" toml:"static_path"`                // 静态文件目录，默认./web
+	PrivatePath     string   `json:"private_path" yaml:"private_path" toml:"private_path"`             // 私有文件目录，默认./storage
+	MaxBodySize     int64    `json:"max_body_size" yaml:"max_body_size" toml:"max_body_size"`          // 请求体大小上限，单位字节
+	ShutdownTimeout Duration `json:"shutdown_timeout" yaml:"shutdown_timeout" toml:"shutdown_timeout"` // 优雅关闭的超时时间，例如：10s
+	MetricsPath     string   `json:"metrics_path" yaml:"metrics_path" toml:"metrics_path"`             // Prometheus指标接口路径，为空时不开启
+	MetricsToken    string   `json:"metrics_token" yaml:"metrics_token" toml:"metrics_token"`          // 访问指标接口的令牌，未设置时需要在网关限制访问
+	HealthPath      string   `json:"health_path" yaml:"health_path" toml:"health_path"`                // 存活检查接口路径，为"-"时不开启
+	ReadyPath       string   `json:"ready_path" yaml:"ready_path" toml:"ready_path"`                   // 就绪检查接口路径，为"-"时不开启
+	DegradedStart   bool     `json:"degraded_start" yaml:"degraded_start" toml:"degraded_start"`       // Redis不可用时以降级模式启动
+
+	Database DatabaseConfig `json:"database" yaml:"database" toml:"database"` // 数据库配置
+	Redis    RedisConfig    `json:"redis" yaml:"redis" toml:"redis"`          // Redis配置，Host为空时不开启
+	Storage  StorageConfig  `json:"storage" yaml:"storage" toml:"storage"`    // 默认存储配置
+	Session  SessionConfig  `json:"session" yaml:"session" toml:"session"`    // Session配置
+	JWT      JWTConfig      `json:"jwt" yaml:"jwt" toml:"jwt"`                // JWT配置
+	Log      LogConfig      `json:"log" yaml:"log" toml:"log"`                // 日志配置
+	Tracing  TracingConfig  `json:"tracing" yaml:"tracing" toml:"tracing"`    // 链路追踪配置，Exporter为空时不开启
+}
+
+// 数据库配置，设置DSN时忽略其他连接参数
+type DatabaseConfig struct {
+	Driver   string `json:"driver" yaml:"driver" toml:"driver"`       // 数据库类型：mysql、sqlite、postgres，可以通过RegisterDialect扩展
+	DSN      string `json:"dsn" yaml:"dsn" toml:"dsn"`                // 连接字符串
+	Host     string `json:"host" yaml:"host" toml:"host"`             // 地址
+	Port     string `json:"port" yaml:"port" toml:"port"`             // 端口，mysql默认3306，postgres默认5432
+	User     string `json:"user" yaml:"user" toml:"user"`             // 用户名
+	Password string `json:"password" yaml:"password" toml:"password"` // 密码
+	Name     string `json:"name" yaml:"name" toml:"name"`             // 数据库名称，sqlite为数据库文件路径
+	Charset  string `json:"charset" yaml:"charset" toml:"charset"`    // mysql字符集，默认utf8mb4
+	SSLMode  string `json:"ssl_mode" yaml:"ssl_mode" toml:"ssl_mode"` // postgres的sslmode，默认disable
+	TimeZone string `json:"timezone" yaml:"timezone" toml:"timezone"` // 时区，mysql默认Local，例如：Asia/Shanghai
+}
+
+// Redis配置
+type RedisConfig struct {
+	Host     string `json:"host" yaml:"host" toml:"host"`             // 地址
+	Port     string `json:"port" yaml:"port" toml:"port"`             // 端口，默认6379
+	Password string `json:"password" yaml:"password" toml:"password"` // 密码
+	Database int    `json:"database" yaml:"database" toml:"database"` // 数据库
+}
+
+// 默认存储配置
+type StorageConfig struct {
+	Driver     string      `json:"driver" yaml:"driver" toml:"driver"`             // 存储驱动：local、oss、minio、s3、memory，默认local
+	Visibility string      `json:"visibility" yaml:"visibility" toml:"visibility"` // 文件可见性：public、private，默认public
+	OSS        OSSConfig   `json:"oss" yaml:"oss" toml:"oss"`                      // OSS配置
+	Minio      MinioConfig `json:"minio" yaml:"minio" toml:"minio"`                // Minio配置
+	S3         S3Config    `json:"s3" yaml:"s3" toml:"s3"`                         // S3配置
+}
+
+// OSS配置
+type OSSConfig struct {
+	Endpoint        string `json:"endpoint" yaml:"endpoint" toml:"endpoint"`
+	AccessKeyID     string `json:"access_key_id" yaml:"access_key_id" toml:"access_key_id"`
+	AccessKeySecret string `json:"access_key_secret" yaml:"access_key_secret" toml:"access_key_secret"`
+	BucketName      string `json:"bucket_name" yaml:"bucket_name" toml:"bucket_name"`
+	Domain          string `json:"domain" yaml:"domain" toml:"domain"`
+}
+
+// Minio配置
+type MinioConfig struct {
+	Endpoint        string `json:"endpoint" yaml:"endpoint" toml:"endpoint"`
+	AccessKeyID     string `json:"access_key_id" yaml:"access_key_id" toml:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key" yaml:"secret_access_key" toml:"secret_access_key"`
+	UseSSL          bool   `json:"use_ssl" yaml:"use_ssl" toml:"use_ssl"`
+	BucketName      string `json:"bucket_name" yaml:"bucket_name" toml:"bucket_name"`
+	Domain          string `json:"domain" yaml:"domain" toml:"domain"`
+}
+
+// S3配置
+type S3Config struct {
+	Endpoint        string `json:"endpoint" yaml:"endpoint" toml:"endpoint"`
+	Region          string `json:"region" yaml:"region" toml:"region"`
+	AccessKeyID     string `json:"access_key_id" yaml:"access_key_id" toml:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key" yaml:"secret_access_key" toml:"secret_access_key"`
+	BucketName      string `json:"bucket_name" yaml:"bucket_name" toml:"bucket_name"`
+	UsePathStyle    bool   `json:"use_path_style" yaml:"use_path_style" toml:"use_path_style"`
+	Domain          string `json:"domain" yaml:"domain" toml:"domain"`
+}
+
+// Session配置，Session使用AppKey加密保存在Cookie中
+type SessionConfig struct {
+	Path     string   `json:"path" yaml:"path" toml:"path"`                // Cookie路径，默认/
+	Domain   string   `json:"domain" yaml:"domain" toml:"domain"`          // Cookie域名
+	MaxAge   Duration `json:"max_age" yaml:"max_age" toml:"max_age"`       // 有效期，默认30天
+	Secure   *bool    `json:"secure" yaml:"secure" toml:"secure"`          // 只通过HTTPS发送，生产环境默认开启
+	HttpOnly *bool    `json:"http_only" yaml:"http_only" toml:"http_only"` // 禁止脚本读取，默认开启
+	SameSite string   `json:"same_site" yaml:"same_site" toml:"same_site"` // lax、strict、none，默认lax
+}
+
+// JWT配置，使用AppKey签名
+type JWTConfig struct {
+	Expire Duration `json:"expire" yaml:"expire" toml:"expire"` // token有效期，默认24h
+}
+
+// 日志配置
+type LogConfig struct {
+	Level  string `json:"level" yaml:"level" toml:"level"`    // 日志级别：debug、info、warn、error，开发环境默认debug，其他环境默认info
+	Format string `json:"format" yaml:"format" toml:"format"` // 日志格式：text、json，开发环境默认text，其他环境默认json
+}
+
+// 链路追踪配置
+type TracingConfig struct {
+	Exporter    string            `json:"exporter" yaml:"exporter" toml:"exporter"`             // 导出方式：stdout、otlp、otlpgrpc，为空时不开启
+	Endpoint    string            `json:"endpoint" yaml:"endpoint" toml:"endpoint"`             // 采集器地址
+	Headers     map[string]string `json:"headers" yaml:"headers" toml:"headers"`                // 导出时附加的请求头
+	Insecure    bool              `json:"insecure" yaml:"insecure" toml:"insecure"`             // 不使用TLS连接采集器
+	ServiceName string            `json:"service_name" yaml:"service_name" toml:"service_name"` // 服务名称
+	SampleRatio float64           `json:"sample_ratio" yaml:"sample_ratio" toml:"sample_ratio"` // 采样比例
+}
+
+// 时间间隔，配置文件及环境变量中使用字符串表示，例如：30s、10m、24h
+type Duration time.Duration
+
+// 解析时间间隔
+func (p *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*p = Duration(duration)
+
+	return nil
+}
+
+// 输出时间间隔
+func (p Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(p).String()), nil
+}
+
+// 转换为time.Duration
+func (p Duration) Duration() time.Duration {
+	return time.Duration(p)
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 数据库类型
+const (
+	DialectMysql    = "mysql"
+	DialectSqlite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
+// 根据连接字符串创建gorm的Dialector
+type DialectFactory func(dsn string) gorm.Dialector
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]DialectFactory{
+		DialectMysql:    mysql.Open,
+		DialectSqlite:   sqlite.Open,
+		DialectPostgres: postgres.Open,
+	}
+)
+
+// 注册数据库类型，同名类型会被覆盖，例如：
+//
+//	config.RegisterDialect("sqlserver", sqlserver.Open)
+func RegisterDialect(name string, factory DialectFactory) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	dialects[name] = factory
+}
+
+// 获取已注册的数据库类型
+func Dialects() []string {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	names := []string{}
+	for k := range dialects {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// 获取连接字符串，未设置DSN时根据连接参数生成
+func (p *DatabaseConfig) GetDSN() string {
+	if p.DSN != "" {
+		return p.DSN
+	}
+
+	switch p.Driver {
+	case DialectMysql:
+		loc := p.TimeZone
+		if loc == "" {
+			loc = "Local"
+		}
+		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=%s",
+			p.User, p.Password, p.Host, p.Port, p.Name, p.Charset, url.QueryEscape(loc))
+	case DialectPostgres:
+		items := []string{
+			"host=" + p.Host,
+			"port=" + p.Port,
+			"user=" + p.User,
+			"password=" + p.Password,
+			"dbname=" + p.Name,
+			"sslmode=" + p.SSLMode,
+		}
+		if p.TimeZone != "" {
+			items = append(items, "TimeZone="+p.TimeZone)
+		}
+		return strings.Join(items, " ")
+	}
+
+	return p.Name
+}
+
+// 创建gorm的Dialector
+func (p *DatabaseConfig) Dialector() (gorm.Dialector, error) {
+	dialectsMu.RLock()
+	factory, ok := dialects[p.Driver]
+	dialectsMu.RUnlock()
+	if !ok {
+		return nil, errors.New("config: unknown database driver " + p.Driver)
+	}
+
+	return factory(p.GetDSN()), nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"gopkg.in/yaml.v3"
+)
+
+// 环境变量前缀
+var EnvPrefix = "QUARK"
+
+// 加载配置，根据文件扩展名识别格式：.yaml、.yml、.toml、.json，path为空时只从环境变量加载
+//
+// 加载顺序：配置文件、运行环境对应的配置文件（例如：config.prod.yaml）、环境变量，后加载的值覆盖之前的值；
+// 运行环境由环境变量QUARK_PROFILE或配置文件中的profile指定，默认dev
+func Load(path string) (*Config, error) {
+	config := &Config{}
+	if path != "" {
+		err := decodeFile(path, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	profile := config.Profile
+	if env := os.Getenv(EnvPrefix + "_PROFILE"); env != "" {
+		profile = env
+	}
+	if profile == "" {
+		profile = ProfileDev
+	}
+
+	// 加载运行环境对应的配置文件
+	if path != "" {
+		ext := filepath.Ext(path)
+		profilePath := strings.TrimSuffix(path, ext) + "." + profile + ext
+		if file.IsExist(profilePath) {
+			err := decodeFile(profilePath, config)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := applyEnv(EnvPrefix, reflect.ValueOf(config).Elem())
+	if err != nil {
+		return nil, err
+	}
+	config.Profile = profile
+	config.setDefaults()
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// 加载配置，失败时panic
+func MustLoad(path string) *Config {
+	config, err := Load(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return config
+}
+
+// 解析配置文件
+func decodeFile(path string, config *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, config)
+	case ".toml":
+		err = toml.Unmarshal(content, config)
+	case ".json":
+		err = json.Unmarshal(content, config)
+	default:
+		return errors.New("config: unsupported file format " + path)
+	}
+	if err != nil {
+		return fmt.Errorf("config: parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// 使用环境变量覆盖配置，变量名称为前缀加上yaml标签的大写，层级之间使用下划线连接，例如：QUARK_DATABASE_HOST
+func applyEnv(prefix string, value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + "_" + strings.ToUpper(name)
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Struct {
+			err := applyEnv(key, fieldValue)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		env, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		err := setValue(fieldValue, env)
+		if err != nil {
+			return fmt.Errorf("config: invalid %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// 将环境变量的值写入配置项
+func setValue(value reflect.Value, env string) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(env))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(env)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(env)
+		if err != nil {
+			return err
+		}
+		value.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(env, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(v)
+	case reflect.Map:
+		// 键值对使用逗号分隔，例如：QUARK_TRACING_HEADERS=Authorization=Bearer xxx,X-Scope=quark
+		items := map[string]string{}
+		for _, item := range strings.Split(env, ",") {
+			kv := strings.SplitN(item, "=", 2)
+			if len(kv) != 2 {
+				return errors.New("expect key=value pairs")
+			}
+			items[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return errors.New("unsupported type " + value.Type().String())
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试使用的强AppKey
+const testAppKey = "k9Xv2LmQ7rT4wZ8pB1nC6yH3jD5fG0sA"
+
+// 在临时目录写入配置文件，返回文件路径
+func writeConfig(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadEnvPrecedence(t *testing.T) {
+	base := `
+app_key: file-key
+addr: ":3000"
+database:
+  driver: mysql
+  host: 127.0.0.1
+  user: root
+  password: file-password
+  name: quarkgo
+`
+	prod := `
+app_key: ` + testAppKey + `
+addr: ":8080"
+database:
+  password: prod-password
+`
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		profile  string
+		appKey   string
+		addr     string
+		password string
+	}{
+		{"file only", nil, ProfileDev, "file-key", ":3000", "file-password"},
+		{"env overrides file", map[string]string{"QUARK_APP_KEY": "env-key", "QUARK_DATABASE_PASSWORD": "env-password"}, ProfileDev, "env-key", ":3000", "env-password"},
+		{"profile file overrides file", map[string]string{"QUARK_PROFILE": ProfileProd}, ProfileProd, testAppKey, ":8080", "prod-password"},
+		{"env overrides profile file", map[string]string{"QUARK_PROFILE": ProfileProd, "QUARK_ADDR": ":9090", "QUARK_DATABASE_PASSWORD": "env-password"}, ProfileProd, testAppKey, ":9090", "env-password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := writeConfig(t, dir, "config.yaml", base)
+			writeConfig(t, dir, "config.prod.yaml", prod)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			config, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if config.Profile != tt.profile || config.AppKey != tt.appKey || config.Addr != tt.addr || config.Database.Password != tt.password {
+				t.Fatalf("Load: got %s %s %s %s, want %s %s %s %s",
+					config.Profile, config.AppKey, config.Addr, config.Database.Password,
+					tt.profile, tt.appKey, tt.addr, tt.password)
+			}
+		})
+	}
+}
+
+func TestLoadEnvOnly(t *testing.T) {
+	t.Setenv("QUARK_APP_KEY", "env-key")
+	t.Setenv("QUARK_DATABASE_DRIVER", DialectSqlite)
+	t.Setenv("QUARK_DATABASE_NAME", "./data.db")
+	t.Setenv("QUARK_REDIS_DATABASE", "2")
+
+	config, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if config.Database.Driver != DialectSqlite || config.Database.Name != "./data.db" || config.Redis.Database != 2 {
+		t.Fatalf("Load: got %+v", config.Database)
+	}
+
+	t.Setenv("QUARK_REDIS_DATABASE", "two")
+	_, err = Load("")
+	if err == nil {
+		t.Fatalf("Load: want error for invalid QUARK_REDIS_DATABASE")
+	}
+}
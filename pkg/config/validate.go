@@ -0,0 +1,227 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/storage"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+)
+
+// 生产环境AppKey的最小长度
+const MinAppKeyLength = 32
+
+// 常见的弱Key，生产环境禁止使用
+var weakAppKeys = []string{
+	"123456",
+	"12345678",
+	"123456789",
+	"password",
+	"secret",
+	"changeme",
+	"appkey",
+	"app_key",
+	"quark",
+	"quarkgo",
+	"your-app-key",
+}
+
+// 设置默认值
+func (p *Config) setDefaults() {
+	if p.Addr == "" {
+		p.Addr = ":3000"
+	}
+
+	switch p.Database.Driver {
+	case DialectMysql:
+		if p.Database.Port == "" {
+			p.Database.Port = "3306"
+		}
+		if p.Database.Charset == "" {
+			p.Database.Charset = "utf8mb4"
+		}
+	case DialectPostgres:
+		if p.Database.Port == "" {
+			p.Database.Port = "5432"
+		}
+		if p.Database.SSLMode == "" {
+			p.Database.SSLMode = "disable"
+		}
+	}
+
+	if p.Redis.Host != "" && p.Redis.Port == "" {
+		p.Redis.Port = "6379"
+	}
+
+	if p.Session.Path == "" {
+		p.Session.Path = "/"
+	}
+	if p.Session.MaxAge == 0 {
+		p.Session.MaxAge = Duration(30 * 24 * time.Hour)
+	}
+	if p.Session.Secure == nil {
+		secure := p.Profile == ProfileProd
+		p.Session.Secure = &secure
+	}
+	if p.Session.HttpOnly == nil {
+		httpOnly := true
+		p.Session.HttpOnly = &httpOnly
+	}
+	if p.Session.SameSite == "" {
+		p.Session.SameSite = "lax"
+	}
+
+	if p.Log.Level == "" {
+		p.Log.Level = "info"
+		if p.Profile == ProfileDev {
+			p.Log.Level = "debug"
+		}
+	}
+	if p.Log.Format == "" {
+		p.Log.Format = "json"
+		if p.Profile == ProfileDev {
+			p.Log.Format = "text"
+		}
+	}
+}
+
+// 校验配置，返回所有不合法的配置项
+func (p *Config) Validate() error {
+	errs := []error{}
+	invalid := func(key string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("config: %s %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if !inArray(p.Profile, []string{ProfileDev, ProfileTest, ProfileProd}) {
+		invalid("profile", "must be one of dev, test, prod")
+	}
+
+	if p.AppKey == "" {
+		invalid("app_key", "is required")
+	} else if p.Profile == ProfileProd {
+		if err := CheckAppKey(p.AppKey); err != nil {
+			invalid("app_key", "%s", err.Error())
+		}
+	}
+
+	// 数据库
+	database := p.Database
+	if database.Driver == "" {
+		invalid("database.driver", "is required")
+	} else if !inArray(database.Driver, Dialects()) {
+		invalid("database.driver", "must be one of %s", strings.Join(Dialects(), ", "))
+	} else if database.DSN == "" {
+		if database.Name == "" {
+			invalid("database.name", "is required")
+		}
+		if database.Driver != DialectSqlite {
+			if database.Host == "" {
+				invalid("database.host", "is required")
+			}
+			if database.User == "" {
+				invalid("database.user", "is required")
+			}
+			if _, err := strconv.Atoi(database.Port); database.Port != "" && err != nil {
+				invalid("database.port", "must be a number")
+			}
+		}
+	}
+
+	// Redis
+	if p.Redis.Host != "" {
+		if _, err := strconv.Atoi(p.Redis.Port); err != nil {
+			invalid("redis.port", "must be a number")
+		}
+		if p.Redis.Database < 0 {
+			invalid("redis.database", "must not be negative")
+		}
+	}
+
+	// 存储
+	store := p.Storage
+	if store.Driver != "" && !inArray(store.Driver, storage.Drivers()) {
+		invalid("storage.driver", "must be one of %s", strings.Join(storage.Drivers(), ", "))
+	}
+	if store.Visibility != "" && !inArray(store.Visibility, []string{storage.VisibilityPublic, storage.VisibilityPrivate}) {
+		invalid("storage.visibility", "must be one of public, private")
+	}
+	switch store.Driver {
+	case storage.OssDriver:
+		if store.OSS.Endpoint == "" || store.OSS.AccessKeyID == "" || store.OSS.AccessKeySecret == "" || store.OSS.BucketName == "" {
+			invalid("storage.oss", "requires endpoint, access_key_id, access_key_secret and bucket_name")
+		}
+	case storage.MinioDriver:
+		if store.Minio.Endpoint == "" || store.Minio.AccessKeyID == "" || store.Minio.SecretAccessKey == "" || store.Minio.BucketName == "" {
+			invalid("storage.minio", "requires endpoint, access_key_id, secret_access_key and bucket_name")
+		}
+	case storage.S3Driver:
+		if store.S3.BucketName == "" {
+			invalid("storage.s3.bucket_name", "is required")
+		}
+	}
+
+	// Session
+	if !inArray(strings.ToLower(p.Session.SameSite), []string{"lax", "strict", "none"}) {
+		invalid("session.same_site", "must be one of lax, strict, none")
+	}
+	if p.Session.MaxAge < 0 {
+		invalid("session.max_age", "must not be negative")
+	}
+	if p.JWT.Expire < 0 {
+		invalid("jwt.expire", "must not be negative")
+	}
+
+	// 日志及链路追踪
+	if !inArray(strings.ToLower(p.Log.Level), []string{"debug", "info", "warn", "error"}) {
+		invalid("log.level", "must be one of debug, info, warn, error")
+	}
+	if !inArray(strings.ToLower(p.Log.Format), []string{"text", "json"}) {
+		invalid("log.format", "must be one of text, json")
+	}
+	if p.Tracing.Exporter != "" && !inArray(p.Tracing.Exporter, []string{telemetry.TraceStdout, telemetry.TraceOTLP, telemetry.TraceOTLPGRPC}) {
+		invalid("tracing.exporter", "must be one of stdout, otlp, otlpgrpc")
+	}
+	if p.Tracing.SampleRatio < 0 || p.Tracing.SampleRatio > 1 {
+		invalid("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	return errors.Join(errs...)
+}
+
+// 检查AppKey的强度，要求至少32位、不是常见的弱Key且包含足够多的不同字符
+func CheckAppKey(appKey string) error {
+	if len(appKey) < MinAppKeyLength {
+		return fmt.Errorf("must be at least %d characters", MinAppKeyLength)
+	}
+
+	lower := strings.ToLower(appKey)
+	for _, v := range weakAppKeys {
+		if strings.Contains(lower, v) {
+			return errors.New("must not contain a common weak key")
+		}
+	}
+
+	chars := map[rune]bool{}
+	for _, v := range appKey {
+		chars[v] = true
+	}
+	if len(chars) < 10 {
+		return errors.New("must contain at least 10 distinct characters")
+	}
+
+	return nil
+}
+
+// 判断值是否在列表中
+func inArray(value string, items []string) bool {
+	for _, v := range items {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
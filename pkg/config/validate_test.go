@@ -0,0 +1,81 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// 满足校验的最小配置
+func newValidConfig(profile string, appKey string) *Config {
+	config := &Config{
+		Profile: profile,
+		AppKey:  appKey,
+		Database: DatabaseConfig{
+			Driver: DialectSqlite,
+			Name:   "./data.db",
+		},
+	}
+	config.setDefaults()
+
+	return config
+}
+
+func TestValidateAppKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		appKey  string
+		err     string
+	}{
+		{"empty", ProfileDev, "", "app_key is required"},
+		{"weak key in dev", ProfileDev, "123456", ""},
+		{"strong key in prod", ProfileProd, testAppKey, ""},
+		{"weak key in prod", ProfileProd, "123456", "must be at least 32 characters"},
+		{"placeholder in prod", ProfileProd, "your-app-key-your-app-key-your-app-key", "must not contain a common weak key"},
+		{"changeme in prod", ProfileProd, "changeme" + testAppKey, "must not contain a common weak key"},
+		{"repeated chars in prod", ProfileProd, strings.Repeat("ab", 16), "must contain at least 10 distinct characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newValidConfig(tt.profile, tt.appKey).Validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Validate: got %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *Config)
+		err    string
+	}{
+		{"unknown profile", func(config *Config) { config.Profile = "staging" }, "profile must be one of"},
+		{"missing database driver", func(config *Config) { config.Database.Driver = "" }, "database.driver is required"},
+		{"missing mysql host", func(config *Config) {
+			config.Database = DatabaseConfig{Driver: DialectMysql, Name: "quarkgo", User: "root"}
+		}, "database.host is required"},
+		{"unknown storage driver", func(config *Config) { config.Storage.Driver = "ftp" }, "storage.driver must be one of"},
+		{"oss without keys", func(config *Config) { config.Storage.Driver = "oss" }, "storage.oss requires"},
+		{"invalid same site", func(config *Config) { config.Session.SameSite = "always" }, "session.same_site must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newValidConfig(ProfileDev, "dev-key")
+			tt.modify(config)
+			err := config.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Validate: got %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"time"
 
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +17,12 @@ func Init(dialector gorm.Dialector, opts gorm.Option) {
 		panic(err)
 	}
 
+	// 记录查询耗时
+	err = Client.Use(&telemetry.GormPlugin{})
+	if err != nil {
+		panic(err)
+	}
+
 	sqlDB, err := Client.DB()
 	if err != nil {
 		panic(err)
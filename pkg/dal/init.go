@@ -16,3 +16,8 @@ func InitDB(dialector gorm.Dialector, opts gorm.Option) {
 func InitRedis(options *redis.Options) {
 	redisclient.Init(options)
 }
+
+// 连接Redis，连接失败时返回错误，客户端仍然可用，Redis恢复后自动重连
+func ConnectRedis(options *redis.Options) error {
+	return redisclient.Connect(options)
+}
@@ -0,0 +1,113 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-basic/uuid"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+	"gorm.io/gorm"
+)
+
+// 迁移锁的配置
+var (
+	LockWaitTimeout     = 5 * time.Minute // 等待其他实例释放锁的超时时间
+	LockStaleTimeout    = 5 * time.Minute // 锁的过期时间，持有锁的实例异常退出、停止刷新锁后，超过该时间的锁会被清除
+	LockRefreshInterval = time.Minute     // 持有锁期间刷新锁时间的间隔，需要小于LockStaleTimeout，迁移耗时较长时锁不会过期
+	LockRetryDelay      = time.Second     // 获取锁失败后的重试间隔
+)
+
+// 迁移锁，表中最多只有一条Id为1的记录，利用主键的唯一性保证同一时间只有一个实例执行迁移
+type SchemaMigrationLock struct {
+	Id       int       `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	Owner    string    `json:"owner" gorm:"size:255"`
+	LockedAt time.Time `json:"locked_at"`
+}
+
+// 迁移锁表名
+func (SchemaMigrationLock) TableName() string {
+	return "schema_migration_locks"
+}
+
+// 持有迁移锁执行方法，执行期间定时刷新锁的时间
+func withLock(db *gorm.DB, fn func() error) (err error) {
+	owner, err := lock(db)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		refresh(db, owner, stop)
+	}()
+	defer func() {
+		close(stop)
+		<-stopped
+		if unlockErr := unlock(db, owner); unlockErr != nil {
+			err = errors.Join(err, unlockErr)
+		}
+	}()
+
+	return fn()
+}
+
+// 获取迁移锁，返回锁的持有者标识
+func lock(db *gorm.DB) (string, error) {
+	err := db.AutoMigrate(&SchemaMigrationLock{})
+	if err != nil {
+		return "", err
+	}
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), uuid.New())
+	deadline := time.Now().Add(LockWaitTimeout)
+	for {
+		// 清除过期的锁
+		err = db.Where("locked_at < ?", time.Now().Add(-LockStaleTimeout)).Delete(&SchemaMigrationLock{}).Error
+		if err != nil {
+			return "", err
+		}
+
+		err = db.Create(&SchemaMigrationLock{Id: 1, Owner: owner, LockedAt: time.Now()}).Error
+		if err == nil {
+			return owner, nil
+		}
+		if time.Now().After(deadline) {
+			return "", errors.New("migrate: wait for lock timeout, another instance may be running migrations")
+		}
+
+		telemetry.Logger().Info("waiting for migration lock")
+		time.Sleep(LockRetryDelay)
+	}
+}
+
+// 定时刷新锁的时间，直到stop关闭
+func refresh(db *gorm.DB, owner string, stop <-chan struct{}) {
+	ticker := time.NewTicker(LockRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			result := db.Model(&SchemaMigrationLock{}).
+				Where("id = ? AND owner = ?", 1, owner).
+				Update("locked_at", time.Now())
+			if result.Error != nil {
+				telemetry.Logger().Warn("failed to refresh migration lock", "error", result.Error)
+			} else if result.RowsAffected == 0 {
+				telemetry.Logger().Error("migration lock was lost, another instance may be running migrations")
+			}
+		}
+	}
+}
+
+// 释放迁移锁
+func unlock(db *gorm.DB, owner string) error {
+	return db.Where("id = ? AND owner = ?", 1, owner).Delete(&SchemaMigrationLock{}).Error
+}
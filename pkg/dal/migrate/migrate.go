@@ -0,0 +1,241 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+	"gorm.io/gorm"
+)
+
+// 迁移方法，执行失败时回滚该迁移
+type MigrateFunc func(tx *gorm.DB) error
+
+// 数据库迁移，按版本号的字典序执行，版本号建议使用时间，例如：20261019000001
+//
+// 数据填充也作为迁移执行，填充方法需要是幂等的：数据已存在时跳过，以便兼容已安装的数据库
+type Migration struct {
+	Version     string      // 版本号，全局唯一
+	Description string      // 描述
+	Up          MigrateFunc // 执行迁移
+	Down        MigrateFunc // 回滚迁移，为nil时不支持回滚
+}
+
+// 已执行的迁移记录
+type SchemaMigration struct {
+	Version     string    `json:"version" gorm:"size:100;primaryKey"`
+	Description string    `json:"description" gorm:"size:255"`
+	AppliedAt   time.Time `json:"applied_at"`
+}
+
+// 迁移记录表名
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// 迁移状态
+type Status struct {
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[string]*Migration{}
+)
+
+// 注册迁移，同一版本号的迁移会被覆盖，迁移中使用发布时定义的结构体而不是会变化的数据模型，例如：
+//
+//	type post20261019000001 struct {
+//		Id    int    `gorm:"autoIncrement"`
+//		Title string `gorm:"size:255;not null"`
+//	}
+//
+//	migrate.Register(&migrate.Migration{
+//		Version:     "20261019000001",
+//		Description: "create posts table",
+//		Up: func(tx *gorm.DB) error {
+//			return tx.Table("posts").AutoMigrate(&post20261019000001{})
+//		},
+//		Down: func(tx *gorm.DB) error {
+//			return tx.Migrator().DropTable("posts")
+//		},
+//	})
+func Register(items ...*Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+
+	for _, v := range items {
+		migrations[v.Version] = v
+	}
+}
+
+// 获取已注册的迁移，按版本号排序
+func Migrations() []*Migration {
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+
+	items := []*Migration{}
+	for _, v := range migrations {
+		items = append(items, v)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Version < items[j].Version
+	})
+
+	return items
+}
+
+// 创建迁移记录表
+func ensureTable(db *gorm.DB) error {
+	return db.AutoMigrate(&SchemaMigration{})
+}
+
+// 获取已执行的迁移记录
+func applied(db *gorm.DB) (map[string]*SchemaMigration, error) {
+	records := []*SchemaMigration{}
+	err := db.Order("version").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*SchemaMigration{}
+	for _, v := range records {
+		result[v.Version] = v
+	}
+
+	return result, nil
+}
+
+// 执行所有未执行的迁移，执行期间持有迁移锁，多个实例同时启动时只有一个实例执行迁移
+func Up(db *gorm.DB) error {
+	return withLock(db, func() error {
+		err := ensureTable(db)
+		if err != nil {
+			return err
+		}
+
+		records, err := applied(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range Migrations() {
+			if records[migration.Version] != nil {
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if migration.Up != nil {
+					if err := migration.Up(tx); err != nil {
+						return err
+					}
+				}
+
+				return tx.Create(&SchemaMigration{
+					Version:     migration.Version,
+					Description: migration.Description,
+					AppliedAt:   time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migrate: %s %s: %w", migration.Version, migration.Description, err)
+			}
+
+			telemetry.Logger().Info("migration applied", "version", migration.Version, "description", migration.Description)
+		}
+
+		return nil
+	})
+}
+
+// 按执行顺序倒序回滚最近的steps个迁移
+func Down(db *gorm.DB, steps int) error {
+	return withLock(db, func() error {
+		err := ensureTable(db)
+		if err != nil {
+			return err
+		}
+
+		records, err := applied(db)
+		if err != nil {
+			return err
+		}
+
+		items := Migrations()
+		for i := len(items) - 1; i >= 0 && steps > 0; i-- {
+			migration := items[i]
+			if records[migration.Version] == nil {
+				continue
+			}
+			if migration.Down == nil {
+				return errors.New("migrate: " + migration.Version + " does not support rollback")
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+
+				return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migrate: rollback %s %s: %w", migration.Version, migration.Description, err)
+			}
+
+			telemetry.Logger().Info("migration rolled back", "version", migration.Version, "description", migration.Description)
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// 获取所有迁移的执行状态
+func GetStatus(db *gorm.DB) ([]*Status, error) {
+	records := map[string]*SchemaMigration{}
+	if db.Migrator().HasTable(&SchemaMigration{}) {
+		var err error
+		records, err = applied(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := []*Status{}
+	for _, migration := range Migrations() {
+		status := &Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if record := records[migration.Version]; record != nil {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+		}
+		items = append(items, status)
+	}
+
+	return items, nil
+}
+
+// 获取未执行的迁移版本号
+func Pending(db *gorm.DB) ([]string, error) {
+	items, err := GetStatus(db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, v := range items {
+		if !v.Applied {
+			versions = append(versions, v.Version)
+		}
+	}
+
+	return versions, nil
+}
@@ -9,8 +9,14 @@ import (
 var Client *redis.Client
 
 func Init(options *redis.Options) {
-	Client = redis.NewClient(options)
-	if err := Client.Ping(context.Background()).Err(); err != nil {
+	if err := Connect(options); err != nil {
 		panic(err)
 	}
 }
+
+// 创建客户端并检查连接，连接失败时返回错误，客户端仍然可用，Redis恢复后自动重连
+func Connect(options *redis.Options) error {
+	Client = redis.NewClient(options)
+
+	return Client.Ping(context.Background()).Err()
+}
@@ -0,0 +1,260 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 语言包
+type Bundle struct {
+	mu       sync.RWMutex
+	fallback string                       // 默认语言
+	messages map[string]map[string]string // 翻译目录，语言 => 键 => 文本
+}
+
+// 初始化语言包
+func NewBundle(fallback string) *Bundle {
+	return &Bundle{
+		fallback: fallback,
+		messages: map[string]map[string]string{},
+	}
+}
+
+// 设置默认语言
+func (p *Bundle) SetFallback(locale string) *Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fallback = locale
+
+	return p
+}
+
+// 获取默认语言
+func (p *Bundle) GetFallback() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.fallback
+}
+
+// 添加翻译，已存在的键会被覆盖
+func (p *Bundle) AddMessages(locale string, messages map[string]string) *Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.messages[locale]; !ok {
+		p.messages[locale] = map[string]string{}
+	}
+	for k, v := range messages {
+		p.messages[locale][k] = v
+	}
+
+	return p
+}
+
+// 加载JSON格式的语言文件，文件名即为语言，例如：en-US.json
+func (p *Bundle) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return p.load(filepath.Base(path), data)
+}
+
+// 加载目录下所有JSON格式的语言文件
+func (p *Bundle) LoadDir(dir string) error {
+	return p.LoadFS(os.DirFS(dir), ".")
+}
+
+// 从文件系统中加载所有JSON格式的语言文件
+func (p *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, filepath.ToSlash(filepath.Join(dir, "*.json")))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range files {
+		data, err := fs.ReadFile(fsys, v)
+		if err != nil {
+			return err
+		}
+
+		err = p.load(filepath.Base(v), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 解析语言文件内容
+func (p *Bundle) load(name string, data []byte) error {
+	messages := map[string]string{}
+	err := json.Unmarshal(data, &messages)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	p.AddMessages(strings.TrimSuffix(name, filepath.Ext(name)), messages)
+
+	return nil
+}
+
+// 获取已加载的语言列表
+func (p *Bundle) Locales() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	locales := []string{}
+	for k := range p.messages {
+		locales = append(locales, k)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+// 匹配已加载的语言，例如：en、en_us 均可匹配 en-US，未匹配时返回空字符串
+func (p *Bundle) Match(locales ...string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, locale := range locales {
+		locale = normalize(locale)
+		if locale == "" {
+			continue
+		}
+
+		// 完全匹配
+		for k := range p.messages {
+			if strings.EqualFold(k, locale) {
+				return k
+			}
+		}
+
+		// 匹配主语言
+		base := strings.Split(locale, "-")[0]
+		matched := ""
+		for k := range p.messages {
+			if strings.EqualFold(strings.Split(k, "-")[0], base) && (matched == "" || k < matched) {
+				matched = k
+			}
+		}
+		if matched != "" {
+			return matched
+		}
+	}
+
+	return ""
+}
+
+// 查找翻译，依次查找指定语言、默认语言
+func (p *Bundle) Lookup(locale string, key string) (string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, v := range []string{locale, p.fallback} {
+		if messages, ok := p.messages[v]; ok {
+			if message, ok := messages[key]; ok {
+				return message, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// 根据文本反查键，例如：根据“基本”在前缀为config.group.的键中查找
+func (p *Bundle) KeyOf(locale string, prefix string, message string) (string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for k, v := range p.messages[locale] {
+		if strings.HasPrefix(k, prefix) && v == message {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+// 翻译内置数据，仅当数据未被修改（与默认语言包中的文本一致）时翻译，否则返回原文本
+func (p *Bundle) TranslateSeed(locale string, key string, message string) string {
+	seeded, ok := p.Lookup(DefaultLocale, key)
+	if !ok || seeded != message {
+		return message
+	}
+
+	return p.Translate(locale, key)
+}
+
+// 翻译，未找到翻译时返回键本身；携带参数时按照fmt.Sprintf格式化
+func (p *Bundle) Translate(locale string, key string, args ...interface{}) string {
+	message, ok := p.Lookup(locale, key)
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+// 解析Accept-Language，按权重从高到低返回语言列表
+func ParseAcceptLanguage(header string) []string {
+	type item struct {
+		locale string
+		weight float64
+	}
+
+	items := []item{}
+	for _, v := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(v), ";")
+		locale := strings.TrimSpace(parts[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		items = append(items, item{locale, weight})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].weight > items[j].weight
+	})
+
+	locales := []string{}
+	for _, v := range items {
+		locales = append(locales, v.locale)
+	}
+
+	return locales
+}
+
+// 统一语言格式，例如：en_us => en-us
+func normalize(locale string) string {
+	return strings.Replace(strings.TrimSpace(locale), "_", "-", -1)
+}
@@ -0,0 +1,36 @@
+package i18n
+
+import "errors"
+
+// 可翻译的错误
+type Error struct {
+	Key  string        // 翻译键
+	Args []interface{} // 格式化参数
+}
+
+// 创建可翻译的错误
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{
+		Key:  key,
+		Args: args,
+	}
+}
+
+// 使用默认语言输出错误信息
+func (p *Error) Error() string {
+	return T("", p.Key, p.Args...)
+}
+
+// 翻译错误信息，非可翻译的错误直接返回错误信息
+func TranslateError(locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var localeError *Error
+	if errors.As(err, &localeError) {
+		return T(locale, localeError.Key, localeError.Args...)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"embed"
+)
+
+// 默认语言
+const DefaultLocale = "zh-CN"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// 内置语言包
+var defaultBundle = NewBundle(DefaultLocale)
+
+func init() {
+	err := defaultBundle.LoadFS(locales, "locales")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// 获取内置语言包
+func Default() *Bundle {
+	return defaultBundle
+}
+
+// 设置默认语言
+func SetFallback(locale string) {
+	defaultBundle.SetFallback(locale)
+}
+
+// 添加翻译，可覆盖内置翻译
+func AddMessages(locale string, messages map[string]string) {
+	defaultBundle.AddMessages(locale, messages)
+}
+
+// 加载JSON格式的语言文件，文件名即为语言，例如：en-US.json
+func LoadFile(path string) error {
+	return defaultBundle.LoadFile(path)
+}
+
+// 加载目录下所有JSON格式的语言文件
+func LoadDir(dir string) error {
+	return defaultBundle.LoadDir(dir)
+}
+
+// 获取已加载的语言列表
+func Locales() []string {
+	return defaultBundle.Locales()
+}
+
+// 匹配已加载的语言
+func Match(locales ...string) string {
+	return defaultBundle.Match(locales...)
+}
+
+// 查找翻译
+func Lookup(locale string, key string) (string, bool) {
+	return defaultBundle.Lookup(locale, key)
+}
+
+// 根据文本反查键
+func KeyOf(locale string, prefix string, message string) (string, bool) {
+	return defaultBundle.KeyOf(locale, prefix, message)
+}
+
+// 翻译内置数据，仅当数据未被修改时翻译
+func TranslateSeed(locale string, key string, message string) string {
+	return defaultBundle.TranslateSeed(locale, key, message)
+}
+
+// 翻译，例如：i18n.T("en-US", "message.success")
+func T(locale string, key string, args ...interface{}) string {
+	return defaultBundle.Translate(locale, key, args...)
+}
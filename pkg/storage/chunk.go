@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 默认分片大小
+const DefaultChunkSize int64 = 5 << 20
+
+// 最大分片数量
+const maxChunkCount = 10000
+
+// 会话信息文件名称
+const chunkSessionFile = "session.json"
+
+// 上传会话ID格式
+var chunkSessionIdPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// 分片上传会话
+type ChunkSession struct {
+	Id         string    `json:"id"`         // 会话ID
+	Owner      string    `json:"owner"`      // 所属用户
+	Name       string    `json:"name"`       // 文件名称
+	Size       int64     `json:"size"`       // 文件大小
+	ChunkSize  int64     `json:"chunkSize"`  // 分片大小
+	ChunkCount int       `json:"chunkCount"` // 分片数量
+	Uploaded   []int     `json:"uploaded"`   // 已上传的分片序号，从0开始
+	CreatedAt  time.Time `json:"createdAt"`  // 创建时间
+}
+
+// 获取分片应有的大小，最后一个分片可能小于分片大小
+func (p *ChunkSession) ChunkLength(index int) int64 {
+	if index == p.ChunkCount-1 {
+		return p.Size - int64(p.ChunkCount-1)*p.ChunkSize
+	}
+
+	return p.ChunkSize
+}
+
+// 判断是否所有分片都已上传
+func (p *ChunkSession) Completed() bool {
+	return len(p.Uploaded) == p.ChunkCount
+}
+
+// 分片存储，未完成的分片保存在本地目录，所有分片上传完成后再合并写入存储驱动
+type ChunkStore struct {
+	path     string
+	expire   time.Duration
+	mu       sync.Mutex
+	lastGC   time.Time
+	gcPeriod time.Duration
+}
+
+// 创建分片存储，expire为未完成会话的保留时间，超过后会被清理
+func NewChunkStore(path string, expire time.Duration) *ChunkStore {
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "quark-chunks")
+	}
+	if expire <= 0 {
+		expire = 24 * time.Hour
+	}
+
+	return &ChunkStore{
+		path:     path,
+		expire:   expire,
+		gcPeriod: time.Hour,
+	}
+}
+
+// 会话目录
+func (p *ChunkStore) sessionPath(id string) string {
+	return filepath.Join(p.path, id)
+}
+
+// 分片文件路径
+func (p *ChunkStore) chunkPath(id string, index int) string {
+	return filepath.Join(p.sessionPath(id), strconv.Itoa(index)+".part")
+}
+
+// 创建上传会话，fingerprint不为空时相同用户、文件和指纹会复用未完成的会话，用于断点续传
+func (p *ChunkStore) Create(owner string, name string, size int64, chunkSize int64, fingerprint string) (*ChunkSession, error) {
+	p.tryGC()
+
+	if size <= 0 {
+		return nil, i18n.NewError("storage.chunk_size_invalid")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunkCount := int((size + chunkSize - 1) / chunkSize)
+	if chunkCount > maxChunkCount {
+		return nil, i18n.NewError("storage.chunk_size_invalid")
+	}
+
+	id, err := p.sessionId(owner, name, size, chunkSize, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	// 复用未过期的会话
+	if session, err := p.Get(id); err == nil {
+		return session, nil
+	}
+
+	session := &ChunkSession{
+		Id:         id,
+		Owner:      owner,
+		Name:       name,
+		Size:       size,
+		ChunkSize:  chunkSize,
+		ChunkCount: chunkCount,
+		Uploaded:   []int{},
+		CreatedAt:  time.Now(),
+	}
+
+	err = os.MkdirAll(p.sessionPath(id), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, os.WriteFile(filepath.Join(p.sessionPath(id), chunkSessionFile), content, 0600)
+}
+
+// 生成会话ID，有指纹时根据文件信息生成，否则随机生成
+func (p *ChunkStore) sessionId(owner string, name string, size int64, chunkSize int64, fingerprint string) (string, error) {
+	if fingerprint != "" {
+		sum := sha256.Sum256([]byte(strings.Join([]string{
+			owner,
+			name,
+			strconv.FormatInt(size, 10),
+			strconv.FormatInt(chunkSize, 10),
+			fingerprint,
+		}, "\x00")))
+
+		return hex.EncodeToString(sum[:16]), nil
+	}
+
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// 获取上传会话及已上传的分片
+func (p *ChunkStore) Get(id string) (*ChunkSession, error) {
+	if !chunkSessionIdPattern.MatchString(id) {
+		return nil, i18n.NewError("storage.chunk_session_not_found")
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.sessionPath(id), chunkSessionFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, i18n.NewError("storage.chunk_session_not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session := &ChunkSession{}
+	err = json.Unmarshal(content, session)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Uploaded = []int{}
+	for i := 0; i < session.ChunkCount; i++ {
+		if _, err := os.Stat(p.chunkPath(id, i)); err == nil {
+			session.Uploaded = append(session.Uploaded, i)
+		}
+	}
+
+	return session, nil
+}
+
+// 写入分片，checksum为分片内容的sha256十六进制值，不为空时校验分片内容
+func (p *ChunkStore) WriteChunk(session *ChunkSession, index int, reader io.Reader, checksum string) error {
+	if index < 0 || index >= session.ChunkCount {
+		return i18n.NewError("storage.chunk_index_invalid", index)
+	}
+
+	length := session.ChunkLength(index)
+	f, err := os.CreateTemp(p.sessionPath(session.Id), ".chunk-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	// 多读取一个字节用于判断分片是否超出大小
+	sha256New := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, sha256New), io.LimitReader(reader, length+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if n != length {
+		return i18n.NewError("storage.chunk_size_invalid")
+	}
+	if checksum != "" && !strings.EqualFold(checksum, hex.EncodeToString(sha256New.Sum(nil))) {
+		return i18n.NewError("storage.chunk_checksum_mismatch", index)
+	}
+
+	err = os.Rename(f.Name(), p.chunkPath(session.Id, index))
+	if err != nil {
+		return err
+	}
+
+	// 更新会话的活跃时间
+	now := time.Now()
+
+	return os.Chtimes(filepath.Join(p.sessionPath(session.Id), chunkSessionFile), now, now)
+}
+
+// 按顺序读取所有分片，分片未全部上传时返回错误
+func (p *ChunkStore) Reader(session *ChunkSession) (io.ReadCloser, error) {
+	if !session.Completed() {
+		return nil, i18n.NewError("storage.chunk_incomplete", session.ChunkCount-len(session.Uploaded))
+	}
+
+	return &chunkReader{store: p, session: session}, nil
+}
+
+// 删除上传会话及分片
+func (p *ChunkStore) Remove(id string) error {
+	if !chunkSessionIdPattern.MatchString(id) {
+		return i18n.NewError("storage.chunk_session_not_found")
+	}
+
+	return os.RemoveAll(p.sessionPath(id))
+}
+
+// 清理超过保留时间未更新的会话，返回清理的数量
+func (p *ChunkStore) GC() (int, error) {
+	entries, err := os.ReadDir(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	deadline := time.Now().Add(-p.expire)
+	for _, v := range entries {
+		if !v.IsDir() || !chunkSessionIdPattern.MatchString(v.Name()) {
+			continue
+		}
+
+		// 会话文件不存在时以目录时间为准
+		info, err := os.Stat(filepath.Join(p.sessionPath(v.Name()), chunkSessionFile))
+		if err != nil {
+			info, err = v.Info()
+			if err != nil {
+				continue
+			}
+		}
+		if info.ModTime().After(deadline) {
+			continue
+		}
+
+		if err := os.RemoveAll(p.sessionPath(v.Name())); err == nil {
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// 距离上次清理超过清理周期时执行清理
+func (p *ChunkStore) tryGC() {
+	p.mu.Lock()
+	if time.Since(p.lastGC) < p.gcPeriod {
+		p.mu.Unlock()
+		return
+	}
+	p.lastGC = time.Now()
+	p.mu.Unlock()
+
+	go p.GC()
+}
+
+// 按顺序读取分片，每次只打开一个分片文件
+type chunkReader struct {
+	store   *ChunkStore
+	session *ChunkSession
+	index   int
+	current *os.File
+}
+
+// 读取数据
+func (p *chunkReader) Read(b []byte) (int, error) {
+	for {
+		if p.current == nil {
+			if p.index >= p.session.ChunkCount {
+				return 0, io.EOF
+			}
+
+			f, err := os.Open(p.store.chunkPath(p.session.Id, p.index))
+			if err != nil {
+				return 0, err
+			}
+			p.current = f
+			p.index++
+		}
+
+		n, err := p.current.Read(b)
+		if err == io.EOF {
+			p.current.Close()
+			p.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		return n, err
+	}
+}
+
+// 关闭当前打开的分片
+func (p *chunkReader) Close() error {
+	if p.current == nil {
+		return nil
+	}
+
+	err := p.current.Close()
+	p.current = nil
+
+	return err
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
+)
+
+// 浏览器直传的上传策略
+type UploadPolicy struct {
+	Key         string        // 保存路径，只允许上传到该路径
+	ContentType string        // 文件类型，上传时必须与该类型一致
+	Size        int64         // 文件大小，PUT方式上传时必须与该大小一致
+	MaxSize     int64         // 允许的最大文件大小，为0时不限制
+	Expire      time.Duration // 有效期
+}
+
+// 浏览器直传的签名结果
+type PresignedUpload struct {
+	Method    string            `json:"method"`    // 请求方式：POST、PUT
+	Url       string            `json:"url"`       // 上传地址
+	Fields    map[string]string `json:"fields"`    // POST方式的表单字段，文件字段file需要放在最后
+	Headers   map[string]string `json:"headers"`   // PUT方式需要携带的请求头
+	Key       string            `json:"key"`       // 保存路径
+	ExpiresAt time.Time         `json:"expiresAt"` // 过期时间
+}
+
+// 支持浏览器直传的驱动，例如：OSS、Minio、S3
+type UploadPresigner interface {
+
+	// 根据上传策略生成浏览器直传的签名
+	PresignUpload(ctx context.Context, policy *UploadPolicy) (*PresignedUpload, error)
+}
+
+// 生成浏览器直传的签名，当前驱动不支持时返回错误
+func (p *FileSystem) PresignUpload(policy *UploadPolicy) (*PresignedUpload, error) {
+	driver, err := p.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	presigner, ok := driver.(UploadPresigner)
+	if !ok {
+		return nil, i18n.NewError("storage.direct_upload_unsupported")
+	}
+
+	return presigner.PresignUpload(p.context(), policy)
+}
+
+// 校验浏览器直传到暂存路径中的文件，检查大小、类型、图片宽高及哈希值并执行扫描，通过后移动到保存路径，size、hash为申请上传时提交的值，hash为空时不比较
+//
+// key为直传的暂存路径，保存路径为SavePath加上key中的文件名；暂存的文件在校验后删除，未通过扫描的文件与Save一致，返回隔离文件的信息及错误
+func (p *FileSystem) Verify(key string, name string, size int64, hash string) (fileInfo *FileInfo, err error) {
+	endSpan := p.startSpan("storage.verify", p.Config.Driver)
+	defer func() {
+		endSpan(err)
+	}()
+
+	err = p.context().Err()
+	if err != nil {
+		return fileInfo, err
+	}
+
+	if p.Config.SavePath == "" {
+		return fileInfo, i18n.NewError("storage.path_required")
+	}
+	p.Config.SaveName = path.Base(key)
+	saveKey := p.Config.SavePath + p.Config.SaveName
+	if saveKey == key {
+		return fileInfo, i18n.NewError("storage.file_exists", key)
+	}
+
+	driver, err := p.GetDriver()
+	if err != nil {
+		return fileInfo, err
+	}
+
+	object, err := driver.Stat(p.context(), key)
+	if err == ErrNotExist {
+		return fileInfo, i18n.NewError("storage.direct_upload_missing")
+	}
+	if err != nil {
+		return fileInfo, err
+	}
+
+	// 上传请求可能已取消，暂存的文件不再跟随请求上下文删除
+	defer driver.Delete(context.Background(), key)
+
+	reader, err := driver.Get(p.context(), key)
+	if err != nil {
+		return fileInfo, err
+	}
+	defer reader.Close()
+
+	p.Reader(&File{
+		Name:        name,
+		Size:        object.Size,
+		ContentType: object.ContentType,
+		Reader:      reader,
+	})
+
+	err = p.verifyObject(size, hash)
+	if err != nil {
+		return fileInfo, err
+	}
+
+	// 已保存过相同内容的文件时只删除暂存的文件
+	found, err := p.dedup()
+	if err != nil {
+		return fileInfo, err
+	}
+	if found {
+		return p.fileInfo(p.existing.Path, p.existing.Url), err
+	}
+
+	open := func() (io.ReadCloser, error) {
+		return driver.Get(p.context(), key)
+	}
+	err = p.scan(open)
+	if p.File.ScanStatus == ScanInfected {
+		if quarantineErr := p.quarantine(driver, open); quarantineErr != nil {
+			return fileInfo, quarantineErr
+		}
+
+		// 未通过扫描时返回隔离文件的信息，用于记录扫描结果
+		return p.fileInfo(p.File.QuarantineKey, ""), err
+	}
+	if err != nil {
+		return fileInfo, err
+	}
+
+	saved, err := open()
+	if err != nil {
+		return fileInfo, err
+	}
+	defer saved.Close()
+
+	err = driver.Put(p.context(), saveKey, saved, p.File.Size, p.File.ContentType)
+	if err != nil {
+		return fileInfo, err
+	}
+	telemetry.AddUploadBytes(p.Config.Driver, object.Size)
+
+	return p.fileInfo(saveKey, driver.URL(saveKey)), err
+}
+
+// 检查直传文件的合法性，并计算哈希值
+func (p *FileSystem) verifyObject(size int64, hash string) error {
+	if size > 0 && p.File.Size != size {
+		return i18n.NewError("storage.direct_upload_mismatch")
+	}
+
+	// 不信任浏览器上传时设置的文件类型，根据文件头检测
+	p.File.ContentType = p.sniffContentType()
+
+	fileExt := ContentTypeList[p.File.ContentType]
+	if fileExt == "" {
+		return i18n.NewError("storage.ext_unknown")
+	}
+	p.File.Ext = fileExt
+
+	err := p.CheckFile()
+	if err != nil {
+		return err
+	}
+	p.WithImageWH()
+
+	sizeReader := &sizeReader{reader: p.reader(), limit: p.limitSize()}
+	sha256New := sha256.New()
+	_, err = io.Copy(sha256New, sizeReader)
+	if sizeReader.exceeded {
+		return p.sizeError()
+	}
+	if err != nil {
+		return err
+	}
+
+	p.File.Hash = hex.EncodeToString(sha256New.Sum(nil))
+	if sizeReader.size != p.File.Size {
+		return i18n.NewError("storage.direct_upload_mismatch")
+	}
+	if hash != "" && !strings.EqualFold(hash, p.File.Hash) {
+		return i18n.NewError("storage.direct_upload_mismatch")
+	}
+
+	return nil
+}
+
+// 根据文件头检测文件类型，http.DetectContentType的结果不在允许的类型中时使用更细分的检测结果，例如：docx文件会被检测为application/zip
+func (p *FileSystem) sniffContentType() string {
+	head := p.head()
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err == nil && p.isAllowedType(contentType) {
+		return contentType
+	}
+
+	return mimetype.Detect(head).String()
+}
+
+// 判断文件类型是否在允许的类型中，未限制类型时全部允许
+func (p *FileSystem) isAllowedType(contentType string) bool {
+	if len(p.Config.LimitType) == 0 {
+		return true
+	}
+	for _, v := range p.Config.LimitType {
+		if v == contentType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func newDirectUploadFileSystem(t *testing.T) (*S3Storage, *FileSystem) {
+	_, driver := newTestS3Storage(t)
+	fileSystem := New(&Config{
+		Driver:    S3Driver,
+		LimitType: []string{"image/png"},
+		S3Config:  driver.config,
+	}).Path("files/")
+
+	return driver, fileSystem
+}
+
+func TestVerifySniffsContentType(t *testing.T) {
+	driver, fileSystem := newDirectUploadFileSystem(t)
+
+	// 浏览器声明为图片，实际内容为HTML
+	content := []byte("<html><script>alert(1)</script></html>")
+	err := driver.Put(context.Background(), "uploads/fake.png", bytes.NewReader(content), int64(len(content)), "image/png")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	_, err = fileSystem.Verify("uploads/fake.png", "fake.png", int64(len(content)), "")
+	if err == nil {
+		t.Fatalf("Verify: want error for content that is not an allowed type")
+	}
+
+	exists, err := driver.Exists(context.Background(), "uploads/fake.png")
+	if err != nil || exists {
+		t.Fatalf("Verify: rejected object was not deleted: %v, %v", exists, err)
+	}
+}
+
+func TestVerifyAllowedType(t *testing.T) {
+	driver, fileSystem := newDirectUploadFileSystem(t)
+
+	buffer := &bytes.Buffer{}
+	err := png.Encode(buffer, image.NewRGBA(image.Rect(0, 0, 2, 3)))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	content := buffer.Bytes()
+
+	// 浏览器声明的类型不影响检测结果
+	err = driver.Put(context.Background(), "uploads/demo.png", bytes.NewReader(content), int64(len(content)), "application/octet-stream")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	fileInfo, err := fileSystem.Verify("uploads/demo.png", "demo.png", int64(len(content)), "")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if fileInfo.ContentType != "image/png" || fileInfo.Ext != "png" {
+		t.Fatalf("Verify: got %s %s, want image/png png", fileInfo.ContentType, fileInfo.Ext)
+	}
+	if fileInfo.Width != 2 || fileInfo.Height != 3 {
+		t.Fatalf("Verify: got %dx%d, want 2x3", fileInfo.Width, fileInfo.Height)
+	}
+
+	// 校验通过后从暂存路径移动到保存路径
+	if fileInfo.Path != "files/demo.png" {
+		t.Fatalf("Verify: got path %s, want files/demo.png", fileInfo.Path)
+	}
+	exists, err := driver.Exists(context.Background(), "files/demo.png")
+	if err != nil || !exists {
+		t.Fatalf("Verify: saved object is missing: %v, %v", exists, err)
+	}
+	exists, err = driver.Exists(context.Background(), "uploads/demo.png")
+	if err != nil || exists {
+		t.Fatalf("Verify: staged object was not deleted: %v, %v", exists, err)
+	}
+}
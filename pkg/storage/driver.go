@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 文件不存在
+var ErrNotExist = errors.New("storage: object does not exist")
+
+// 文件可见性
+const (
+	VisibilityPublic  = "public"  // 公开，可以通过文件地址直接访问
+	VisibilityPrivate = "private" // 私有，需要通过认证路由或带签名的临时地址访问
+)
+
+// 存储驱动，key为文件的完整保存路径，例如：./web/app/storage/images/20230101/demo.png
+type Driver interface {
+
+	// 保存文件，size未知时传-1
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+
+	// 读取文件，使用后需要关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// 删除文件
+	Delete(ctx context.Context, key string) error
+
+	// 判断文件是否存在
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// 获取文件信息，文件不存在时返回ErrNotExist
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// 获取文件访问地址
+	URL(key string) string
+
+	// 列出前缀下的所有文件
+	List(ctx context.Context, prefix string) ([]*ObjectInfo, error)
+}
+
+// 支持生成临时访问地址的驱动，例如：OSS、Minio、S3
+type Presigner interface {
+
+	// 生成带签名的临时访问地址
+	PresignURL(ctx context.Context, key string, expire time.Duration) (string, error)
+}
+
+// 文件对象信息
+type ObjectInfo struct {
+	Key          string    `json:"key"`          // 保存路径
+	Size         int64     `json:"size"`         // 文件大小
+	ContentType  string    `json:"contentType"`  // 文件类型
+	LastModified time.Time `json:"lastModified"` // 修改时间
+}
+
+// 驱动构造方法，根据配置创建驱动
+type DriverFactory func(config *Config) (Driver, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DriverFactory{}
+)
+
+func init() {
+	RegisterDriver(LocalDriver, func(config *Config) (Driver, error) {
+		return NewLocalStorage(), nil
+	})
+	RegisterDriver(OssDriver, func(config *Config) (Driver, error) {
+		driver, err := NewOSSStorage(config.OSSConfig)
+		if err != nil {
+			return nil, err
+		}
+		driver.private = config.Visibility == VisibilityPrivate
+
+		return driver, nil
+	})
+	RegisterDriver(MinioDriver, func(config *Config) (Driver, error) {
+		return NewMinioStorage(config.MinioConfig)
+	})
+	RegisterDriver(S3Driver, func(config *Config) (Driver, error) {
+		return NewS3Storage(config.S3Config)
+	})
+	RegisterDriver(MemoryDriver, func(config *Config) (Driver, error) {
+		return defaultMemoryStorage, nil
+	})
+}
+
+// 注册驱动，同名驱动会被覆盖
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	drivers[name] = factory
+}
+
+// 获取已注册的驱动名称
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := []string{}
+	for k := range drivers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// 根据名称创建驱动
+func GetDriver(name string, config *Config) (Driver, error) {
+	driversMu.RLock()
+	factory, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, i18n.NewError("storage.driver_unknown")
+	}
+
+	if config == nil {
+		config = &Config{}
+	}
+
+	return factory(config)
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+// 驱动需要满足的约定，keyPrefix为测试使用的保存路径前缀
+func testDriverContract(t *testing.T, driver Driver, keyPrefix string) {
+	ctx := context.Background()
+	key := keyPrefix + "files/demo.txt"
+	content := []byte("hello storage driver")
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := driver.Get(ctx, keyPrefix+"files/missing.txt")
+		if !errors.Is(err, ErrNotExist) {
+			t.Fatalf("Get missing: got %v, want ErrNotExist", err)
+		}
+		_, err = driver.Stat(ctx, keyPrefix+"files/missing.txt")
+		if !errors.Is(err, ErrNotExist) {
+			t.Fatalf("Stat missing: got %v, want ErrNotExist", err)
+		}
+		exists, err := driver.Exists(ctx, keyPrefix+"files/missing.txt")
+		if err != nil || exists {
+			t.Fatalf("Exists missing: got %v, %v", exists, err)
+		}
+		err = driver.Delete(ctx, keyPrefix+"files/missing.txt")
+		if err != nil {
+			t.Fatalf("Delete missing: %v", err)
+		}
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		err := driver.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "text/plain")
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		reader, err := driver.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		defer reader.Close()
+		got, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Fatalf("Get: got %q, want %q", got, content)
+		}
+
+		info, err := driver.Stat(ctx, key)
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if info.Size != int64(len(content)) {
+			t.Fatalf("Stat size: got %d, want %d", info.Size, len(content))
+		}
+
+		exists, err := driver.Exists(ctx, key)
+		if err != nil || !exists {
+			t.Fatalf("Exists: got %v, %v", exists, err)
+		}
+	})
+
+	t.Run("put unknown size", func(t *testing.T) {
+		err := driver.Put(ctx, keyPrefix+"files/stream.txt", bytes.NewReader(content), -1, "")
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		info, err := driver.Stat(ctx, keyPrefix+"files/stream.txt")
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if info.Size != int64(len(content)) {
+			t.Fatalf("Stat size: got %d, want %d", info.Size, len(content))
+		}
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		err := driver.Put(ctx, key, bytes.NewReader([]byte("new")), 3, "text/plain")
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		info, err := driver.Stat(ctx, key)
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if info.Size != 3 {
+			t.Fatalf("Stat size: got %d, want 3", info.Size)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		err := driver.Put(ctx, keyPrefix+"other/skip.txt", bytes.NewReader(content), int64(len(content)), "text/plain")
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		objects, err := driver.List(ctx, keyPrefix+"files/")
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		keys := map[string]bool{}
+		for _, v := range objects {
+			keys[v.Key] = true
+		}
+		if len(keys) != 2 || !keys[key] || !keys[keyPrefix+"files/stream.txt"] {
+			t.Fatalf("List: got %v", keys)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := driver.Delete(ctx, key)
+		if err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		exists, err := driver.Exists(ctx, key)
+		if err != nil || exists {
+			t.Fatalf("Exists after delete: got %v, %v", exists, err)
+		}
+		_, err = driver.Get(ctx, key)
+		if !errors.Is(err, ErrNotExist) {
+			t.Fatalf("Get after delete: got %v, want ErrNotExist", err)
+		}
+	})
+}
+
+func TestMemoryStorage(t *testing.T) {
+	testDriverContract(t, NewMemoryStorage(), "storage/")
+}
+
+func TestLocalStorage(t *testing.T) {
+	testDriverContract(t, NewLocalStorage(), filepath.ToSlash(t.TempDir())+"/")
+}
+
+func TestGetDriver(t *testing.T) {
+	driver, err := GetDriver(MemoryDriver, nil)
+	if err != nil {
+		t.Fatalf("GetDriver: %v", err)
+	}
+	if driver != DefaultMemoryStorage() {
+		t.Fatalf("GetDriver: memory driver is not the default memory storage")
+	}
+
+	_, err = GetDriver("unknown", nil)
+	if err == nil {
+		t.Fatalf("GetDriver: want error for unknown driver")
+	}
+}
@@ -0,0 +1,521 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 图片缩放方式
+const (
+	ImageFit  = "fit"  // 等比缩放到指定宽高范围内，不放大
+	ImageFill = "fill" // 等比缩放后居中裁剪，填满指定宽高
+)
+
+// 水印位置
+const (
+	WatermarkTopLeft     = "top-left"
+	WatermarkTopRight    = "top-right"
+	WatermarkBottomLeft  = "bottom-left"
+	WatermarkBottomRight = "bottom-right"
+	WatermarkCenter      = "center"
+)
+
+// 默认图片质量
+const DefaultImageQuality = 85
+
+// 图片规格保存路径的分隔符，规格文件保存在原图路径后，例如：abc.png@thumb.webp
+const imageVariantSeparator = "@"
+
+// 图片编码方法，quality为图片质量，1-100
+type ImageEncoder func(w io.Writer, img image.Image, quality int) error
+
+var (
+	imageEncodersMu sync.RWMutex
+	imageEncoders   = map[string]ImageEncoder{
+		"jpg": func(w io.Writer, img image.Image, quality int) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		},
+		"png": func(w io.Writer, img image.Image, quality int) error {
+			return png.Encode(w, img)
+		},
+		"gif": func(w io.Writer, img image.Image, quality int) error {
+			return gif.Encode(w, img, nil)
+		},
+	}
+)
+
+// 图片格式对应的文件类型
+var imageContentTypes = map[string]string{
+	"jpg":  "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+	"webp": "image/webp",
+}
+
+// 注册图片编码，同名格式会被覆盖，标准库不支持WebP编码，需要注册后才能输出WebP格式
+//
+//	storage.RegisterImageEncoder("webp", func(w io.Writer, img image.Image, quality int) error {
+//		return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+//	})
+func RegisterImageEncoder(format string, encoder ImageEncoder) {
+	imageEncodersMu.Lock()
+	defer imageEncodersMu.Unlock()
+
+	imageEncoders[imageFormat(format)] = encoder
+}
+
+// 获取图片编码
+func getImageEncoder(format string) (ImageEncoder, error) {
+	imageEncodersMu.RLock()
+	defer imageEncodersMu.RUnlock()
+
+	encoder, ok := imageEncoders[imageFormat(format)]
+	if !ok {
+		return nil, i18n.NewError("storage.image_format_unsupported", format)
+	}
+
+	return encoder, nil
+}
+
+// 统一图片格式名称
+func imageFormat(format string) string {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	if format == "jpeg" {
+		return "jpg"
+	}
+
+	return format
+}
+
+// 图片水印，Text与Image二选一
+type Watermark struct {
+	Text     string      // 文字水印，内置点阵字体仅支持ASCII字符
+	Color    color.Color // 文字颜色，默认白色
+	FontSize int         // 文字高度，单位像素，默认为图片高度的1/20
+	Image    []byte      // 图片水印内容
+	Position string      // 水印位置，默认右下角
+	Opacity  float64     // 不透明度，0-1，默认0.6
+	Margin   int         // 水印与图片边缘的距离，单位像素，默认10
+}
+
+// 图片规格，例如缩略图
+type ImageVariant struct {
+	Name      string     // 规格名称，例如：thumb
+	Width     int        // 宽度，为0时根据高度等比缩放
+	Height    int        // 高度，为0时根据宽度等比缩放
+	Mode      string     // 缩放方式：fit、fill，默认fit
+	Format    string     // 输出格式：jpg、png、gif、webp，默认与原图一致
+	Quality   int        // 图片质量，1-100，默认85
+	Watermark *Watermark // 水印
+	OnUpload  bool       // 是否在上传时生成，默认在首次访问时生成
+}
+
+// 获取规格的输出格式，ext为原图扩展名
+func (p *ImageVariant) GetFormat(ext string) string {
+	if p.Format != "" {
+		return imageFormat(p.Format)
+	}
+
+	return imageFormat(ext)
+}
+
+// 获取规格文件的保存路径
+func (p *ImageVariant) Key(originPath string) string {
+	return originPath + imageVariantSeparator + p.Name + "." + p.GetFormat(path.Ext(originPath))
+}
+
+// 获取规格文件的文件类型
+func (p *ImageVariant) ContentType(originPath string) string {
+	return imageContentTypes[p.GetFormat(path.Ext(originPath))]
+}
+
+// 根据规格处理图片，返回处理后的图片内容；原图的EXIF方向会被校正，输出的图片不包含EXIF信息
+func (p *ImageVariant) Process(content []byte) ([]byte, error) {
+	img, format, err := DecodeImage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder, err := getImageEncoder(p.GetFormat(format))
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.Mode {
+	case ImageFill:
+		img = FillImage(img, p.Width, p.Height)
+	default:
+		img = FitImage(img, p.Width, p.Height)
+	}
+
+	if p.Watermark != nil {
+		img, err = p.Watermark.Draw(img)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quality := p.Quality
+	if quality <= 0 || quality > 100 {
+		quality = DefaultImageQuality
+	}
+
+	buf := &bytes.Buffer{}
+	err = encoder(buf, img, quality)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// 根据规格文件的保存路径获取原图路径
+func ImageVariantOrigin(key string) (string, bool) {
+	index := strings.LastIndex(key, imageVariantSeparator)
+	if index <= 0 || strings.Contains(key[index:], "/") {
+		return key, false
+	}
+
+	return key[:index], true
+}
+
+// 解码图片，并根据EXIF信息校正方向，返回图片及格式
+func DecodeImage(content []byte) (image.Image, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "jpeg" {
+		img = orientImage(img, exifOrientation(content))
+	}
+
+	return img, imageFormat(format), nil
+}
+
+// 编码图片，format为jpg、png、gif或已注册的格式
+func EncodeImage(w io.Writer, img image.Image, format string, quality int) error {
+	encoder, err := getImageEncoder(format)
+	if err != nil {
+		return err
+	}
+	if quality <= 0 || quality > 100 {
+		quality = DefaultImageQuality
+	}
+
+	return encoder(w, img, quality)
+}
+
+// 裁剪图片
+func CropImage(img image.Image, rect image.Rectangle) image.Image {
+	bounds := img.Bounds()
+	rect = rect.Add(bounds.Min).Intersect(bounds)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+
+	return dst
+}
+
+// 等比缩放到指定宽高范围内，图片小于指定宽高时不放大
+func FitImage(img image.Image, width int, height int) image.Image {
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	if srcW == 0 || srcH == 0 || (width <= 0 && height <= 0) {
+		return img
+	}
+
+	scale := math.Inf(1)
+	if width > 0 {
+		scale = float64(width) / float64(srcW)
+	}
+	if height > 0 {
+		scale = math.Min(scale, float64(height)/float64(srcH))
+	}
+	if scale >= 1 {
+		return img
+	}
+
+	return ResizeImage(img, scaleSize(srcW, scale), scaleSize(srcH, scale))
+}
+
+// 等比缩放后居中裁剪，填满指定宽高
+func FillImage(img image.Image, width int, height int) image.Image {
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	if width <= 0 || height <= 0 {
+		return FitImage(img, width, height)
+	}
+	if srcW == 0 || srcH == 0 {
+		return img
+	}
+
+	scale := math.Max(float64(width)/float64(srcW), float64(height)/float64(srcH))
+	resized := ResizeImage(img, maxInt(scaleSize(srcW, scale), width), maxInt(scaleSize(srcH, scale), height))
+
+	x := (resized.Bounds().Dx() - width) / 2
+	y := (resized.Bounds().Dy() - height) / 2
+
+	return CropImage(resized, image.Rect(x, y, x+width, y+height))
+}
+
+// 缩放后的尺寸，最小为1
+func scaleSize(size int, scale float64) int {
+	return maxInt(int(math.Round(float64(size)*scale)), 1)
+}
+
+// 最大值
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// 缩放图片到指定宽高，使用线性插值，缩小时按比例扩大采样范围以避免锯齿
+func ResizeImage(img image.Image, width int, height int) image.Image {
+	src := toNRGBA(img)
+	if width <= 0 || height <= 0 || (width == src.Rect.Dx() && height == src.Rect.Dy()) {
+		return src
+	}
+
+	// 先水平后垂直两次采样
+	tmp := resampleX(src, width)
+
+	return resampleY(tmp, height)
+}
+
+// 转换为NRGBA格式，坐标从0开始
+func toNRGBA(img image.Image) *image.NRGBA {
+	if dst, ok := img.(*image.NRGBA); ok && dst.Rect.Min == (image.Point{}) {
+		return dst
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+
+	return dst
+}
+
+// 采样权重
+type resampleWeight struct {
+	start   int
+	weights []float64
+}
+
+// 计算采样权重
+func resampleWeights(srcSize int, dstSize int) []resampleWeight {
+	scale := float64(srcSize) / float64(dstSize)
+	support := math.Max(scale, 1)
+
+	result := make([]resampleWeight, dstSize)
+	for i := range result {
+		center := (float64(i) + 0.5) * scale
+		start := int(math.Floor(center - support))
+		end := int(math.Ceil(center + support))
+		if start < 0 {
+			start = 0
+		}
+		if end > srcSize {
+			end = srcSize
+		}
+
+		sum := 0.0
+		weights := make([]float64, 0, end-start)
+		for j := start; j < end; j++ {
+			w := 1 - math.Abs(float64(j)+0.5-center)/support
+			if w < 0 {
+				w = 0
+			}
+			weights = append(weights, w)
+			sum += w
+		}
+		if sum > 0 {
+			for k := range weights {
+				weights[k] /= sum
+			}
+		}
+
+		result[i] = resampleWeight{start: start, weights: weights}
+	}
+
+	return result
+}
+
+// 水平采样
+func resampleX(src *image.NRGBA, width int) *image.NRGBA {
+	height := src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	weights := resampleWeights(src.Rect.Dx(), width)
+
+	for y := 0; y < height; y++ {
+		for x, w := range weights {
+			var r, g, b, a float64
+			for k, weight := range w.weights {
+				i := src.PixOffset(w.start+k, y)
+				alpha := float64(src.Pix[i+3]) * weight
+				r += float64(src.Pix[i]) * alpha
+				g += float64(src.Pix[i+1]) * alpha
+				b += float64(src.Pix[i+2]) * alpha
+				a += alpha
+			}
+			setResampled(dst, dst.PixOffset(x, y), r, g, b, a)
+		}
+	}
+
+	return dst
+}
+
+// 垂直采样
+func resampleY(src *image.NRGBA, height int) *image.NRGBA {
+	width := src.Rect.Dx()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	weights := resampleWeights(src.Rect.Dy(), height)
+
+	for x := 0; x < width; x++ {
+		for y, w := range weights {
+			var r, g, b, a float64
+			for k, weight := range w.weights {
+				i := src.PixOffset(x, w.start+k)
+				alpha := float64(src.Pix[i+3]) * weight
+				r += float64(src.Pix[i]) * alpha
+				g += float64(src.Pix[i+1]) * alpha
+				b += float64(src.Pix[i+2]) * alpha
+				a += alpha
+			}
+			setResampled(dst, dst.PixOffset(x, y), r, g, b, a)
+		}
+	}
+
+	return dst
+}
+
+// 写入采样结果，颜色按透明度加权，避免透明像素的颜色渗入
+func setResampled(dst *image.NRGBA, i int, r float64, g float64, b float64, a float64) {
+	if a <= 0 {
+		dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = 0, 0, 0, 0
+		return
+	}
+
+	dst.Pix[i] = clampUint8(r / a)
+	dst.Pix[i+1] = clampUint8(g / a)
+	dst.Pix[i+2] = clampUint8(b / a)
+	dst.Pix[i+3] = clampUint8(a)
+}
+
+// 转换为0-255的整数
+func clampUint8(v float64) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+
+	return uint8(v)
+}
+
+// 添加水印
+func (p *Watermark) Draw(img image.Image) (image.Image, error) {
+	var (
+		mark image.Image
+		err  error
+	)
+
+	dst := toNRGBA(img)
+	if p.Image != nil {
+		mark, _, err = image.Decode(bytes.NewReader(p.Image))
+		if err != nil {
+			return nil, err
+		}
+	} else if p.Text != "" {
+		fontSize := p.FontSize
+		if fontSize <= 0 {
+			fontSize = dst.Rect.Dy() / 20
+		}
+
+		textColor := p.Color
+		if textColor == nil {
+			textColor = color.White
+		}
+		mark = drawText(p.Text, fontSize, textColor)
+	} else {
+		return dst, nil
+	}
+
+	opacity := p.Opacity
+	if opacity <= 0 || opacity > 1 {
+		opacity = 0.6
+	}
+	margin := p.Margin
+	if margin <= 0 {
+		margin = 10
+	}
+
+	// 计算水印位置
+	bounds := dst.Bounds()
+	size := mark.Bounds().Size()
+	var point image.Point
+	switch p.Position {
+	case WatermarkTopLeft:
+		point = image.Pt(margin, margin)
+	case WatermarkTopRight:
+		point = image.Pt(bounds.Dx()-size.X-margin, margin)
+	case WatermarkBottomLeft:
+		point = image.Pt(margin, bounds.Dy()-size.Y-margin)
+	case WatermarkCenter:
+		point = image.Pt((bounds.Dx()-size.X)/2, (bounds.Dy()-size.Y)/2)
+	default:
+		point = image.Pt(bounds.Dx()-size.X-margin, bounds.Dy()-size.Y-margin)
+	}
+
+	draw.DrawMask(
+		dst,
+		image.Rectangle{Min: point, Max: point.Add(size)},
+		mark,
+		mark.Bounds().Min,
+		image.NewUniform(color.Alpha{A: uint8(opacity * 255)}),
+		image.Point{},
+		draw.Over,
+	)
+
+	return dst, nil
+}
+
+// 使用内置点阵字体绘制文字，非ASCII字符显示为?
+func drawText(text string, fontSize int, textColor color.Color) image.Image {
+	scale := maxInt(fontSize/fontGlyphHeight, 1)
+	chars := []rune(text)
+
+	// 字符间距为1个点
+	dst := image.NewNRGBA(image.Rect(0, 0, len(chars)*(fontGlyphWidth+1)*scale, fontGlyphHeight*scale))
+	src := image.NewUniform(textColor)
+	for i, char := range chars {
+		glyph := fontGlyph(char)
+		for col, bits := range glyph {
+			for row := 0; row < fontGlyphHeight; row++ {
+				if bits&(1<<uint(row)) == 0 {
+					continue
+				}
+				x := (i*(fontGlyphWidth+1) + col) * scale
+				y := row * scale
+				draw.Draw(dst, image.Rect(x, y, x+scale, y+scale), src, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// 获取JPEG图片EXIF信息中的方向，无法获取时返回1
+func exifOrientation(content []byte) int {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != 0xD8 {
+		return 1
+	}
+
+	// 查找APP1段
+	offset := 2
+	for offset+4 <= len(content) {
+		if content[offset] != 0xFF {
+			return 1
+		}
+		marker := content[offset+1]
+		length := int(binary.BigEndian.Uint16(content[offset+2:]))
+		if length < 2 || offset+2+length > len(content) {
+			return 1
+		}
+
+		// 图像数据开始，后面不会再有EXIF信息
+		if marker == 0xDA {
+			return 1
+		}
+		segment := content[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		offset += 2 + length
+	}
+
+	return 1
+}
+
+// 从TIFF结构的第一个IFD中读取方向
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != 0x0112 {
+			continue
+		}
+
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+
+		return orientation
+	}
+
+	return 1
+}
+
+// 根据EXIF方向旋转或翻转图片
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	src := toNRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	// 方向5-8需要交换宽高
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // 水平翻转
+				sx, sy = w-1-x, y
+			case 3: // 旋转180度
+				sx, sy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				sx, sy = x, h-1-y
+			case 5: // 沿左上到右下的对角线翻转
+				sx, sy = y, x
+			case 6: // 顺时针旋转90度
+				sx, sy = y, h-1-x
+			case 7: // 沿右上到左下的对角线翻转
+				sx, sy = w-1-y, h-1-x
+			case 8: // 逆时针旋转90度
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
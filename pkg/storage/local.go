@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 本地存储，key即为本地文件路径
+type LocalStorage struct{}
+
+// 创建本地存储
+func NewLocalStorage() *LocalStorage {
+	return &LocalStorage{}
+}
+
+// 保存文件，先写入同目录下的临时文件，完成后再重命名，上传中断时不会留下不完整的文件
+func (p *LocalStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	dir := filepath.Dir(key)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(f.Name(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), key)
+}
+
+// 读取文件
+func (p *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+
+	return f, err
+}
+
+// 删除文件
+func (p *LocalStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// 判断文件是否存在
+func (p *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := p.Stat(ctx, key)
+	if errors.Is(err, ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// 获取文件信息
+func (p *LocalStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := os.Stat(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, ErrNotExist
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  mime.TypeByExtension(filepath.Ext(key)),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// 获取文件访问地址，本地文件返回保存路径
+func (p *LocalStorage) URL(key string) string {
+	return key
+}
+
+// 列出前缀下的所有文件
+func (p *LocalStorage) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	objects := []*ObjectInfo{}
+
+	// 前缀可以是目录或文件名前缀
+	root := prefix
+	if info, err := os.Stat(prefix); err != nil || !info.IsDir() {
+		root = filepath.Dir(prefix)
+	}
+
+	cleanPrefix := ""
+	if prefix != "" {
+		cleanPrefix = filepath.ToSlash(filepath.Clean(prefix))
+		if strings.HasSuffix(prefix, "/") {
+			cleanPrefix = cleanPrefix + "/"
+		}
+	}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasPrefix(filepath.ToSlash(path), cleanPrefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          filepath.ToSlash(path),
+			Size:         info.Size(),
+			ContentType:  mime.TypeByExtension(filepath.Ext(path)),
+			LastModified: info.ModTime(),
+		})
+
+		return nil
+	})
+
+	return objects, err
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 内置的内存存储，驱动名为memory
+var defaultMemoryStorage = NewMemoryStorage()
+
+// 内存中的文件
+type memoryObject struct {
+	content      []byte
+	contentType  string
+	lastModified time.Time
+}
+
+// 内存存储，数据不会持久化，一般用于测试
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string]*memoryObject
+}
+
+// 创建内存存储
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		objects: map[string]*memoryObject{},
+	}
+}
+
+// 获取内置的内存存储
+func DefaultMemoryStorage() *MemoryStorage {
+	return defaultMemoryStorage
+}
+
+// 保存文件
+func (p *MemoryStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.objects[key] = &memoryObject{
+		content:      content,
+		contentType:  contentType,
+		lastModified: time.Now(),
+	}
+
+	return nil
+}
+
+// 读取文件
+func (p *MemoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	object, ok := p.objects[key]
+	if !ok {
+		return nil, ErrNotExist
+	}
+
+	return io.NopCloser(bytes.NewReader(object.content)), nil
+}
+
+// 删除文件
+func (p *MemoryStorage) Delete(ctx context.Context, key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.objects, key)
+
+	return nil
+}
+
+// 判断文件是否存在
+func (p *MemoryStorage) Exists(ctx context.Context, key string) (bool, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.objects[key]
+
+	return ok, nil
+}
+
+// 获取文件信息
+func (p *MemoryStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	object, ok := p.objects[key]
+	if !ok {
+		return nil, ErrNotExist
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         int64(len(object.content)),
+		ContentType:  object.contentType,
+		LastModified: object.lastModified,
+	}, nil
+}
+
+// 获取文件访问地址，内存文件返回保存路径
+func (p *MemoryStorage) URL(key string) string {
+	return key
+}
+
+// 列出前缀下的所有文件
+func (p *MemoryStorage) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	objects := []*ObjectInfo{}
+	for k, v := range p.objects {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          k,
+			Size:         int64(len(v.content)),
+			ContentType:  v.contentType,
+			LastModified: v.lastModified,
+		})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+}
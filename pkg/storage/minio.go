@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// Minio存储
+type MinioStorage struct {
+	config *MinioConfig
+	client *minio.Client
+}
+
+// 创建Minio存储
+func NewMinioStorage(config *MinioConfig) (*MinioStorage, error) {
+	if config == nil {
+		return nil, i18n.NewError("storage.minio_not_configured")
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
+		Secure: config.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MinioStorage{
+		config: config,
+		client: client,
+	}, nil
+}
+
+// 判断是否为文件不存在错误
+func (p *MinioStorage) isNotExist(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return minio.ToErrorResponse(err).StatusCode == http.StatusNotFound
+}
+
+// 保存文件，大小未知时按分片上传
+func (p *MinioStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	options := minio.PutObjectOptions{ContentType: contentType}
+	if size < 0 {
+		options.PartSize = partSize
+	}
+
+	_, err := p.client.PutObject(ctx, p.config.BucketName, key, reader, size, options)
+
+	return err
+}
+
+// 读取文件
+func (p *MinioStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	_, err := p.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.GetObject(ctx, p.config.BucketName, key, minio.GetObjectOptions{})
+}
+
+// 删除文件
+func (p *MinioStorage) Delete(ctx context.Context, key string) error {
+	return p.client.RemoveObject(ctx, p.config.BucketName, key, minio.RemoveObjectOptions{})
+}
+
+// 判断文件是否存在
+func (p *MinioStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := p.Stat(ctx, key)
+	if err == ErrNotExist {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// 获取文件信息
+func (p *MinioStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := p.client.StatObject(ctx, p.config.BucketName, key, minio.StatObjectOptions{})
+	if p.isNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// 获取文件访问地址
+func (p *MinioStorage) URL(key string) string {
+	return "//" + p.config.Domain + "/" + key
+}
+
+// 生成带签名的临时访问地址
+func (p *MinioStorage) PresignURL(ctx context.Context, key string, expire time.Duration) (string, error) {
+	u, err := p.client.PresignedGetObject(ctx, p.config.BucketName, key, expire, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// 生成浏览器直传的签名，使用PostPolicy表单上传，限制保存路径、文件类型及大小
+func (p *MinioStorage) PresignUpload(ctx context.Context, policy *UploadPolicy) (*PresignedUpload, error) {
+	expiresAt := time.Now().Add(policy.Expire)
+
+	postPolicy := minio.NewPostPolicy()
+	postPolicy.SetBucket(p.config.BucketName)
+	postPolicy.SetKey(policy.Key)
+	postPolicy.SetExpires(expiresAt)
+	postPolicy.SetContentType(policy.ContentType)
+	if policy.MaxSize > 0 {
+		postPolicy.SetContentLengthRange(0, policy.MaxSize)
+	}
+
+	u, formData, err := p.client.PresignedPostPolicy(ctx, postPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedUpload{
+		Method:    http.MethodPost,
+		Url:       u.String(),
+		Fields:    formData,
+		Key:       policy.Key,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// 列出前缀下的所有文件
+func (p *MinioStorage) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	objects := []*ObjectInfo{}
+	for v := range p.client.ListObjects(ctx, p.config.BucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if v.Err != nil {
+			return objects, v.Err
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          v.Key,
+			Size:         v.Size,
+			ContentType:  v.ContentType,
+			LastModified: v.LastModified,
+		})
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 阿里云OSS存储，SDK不支持上下文，ctx仅在请求前检查是否已取消
+type OSSStorage struct {
+	config  *OSSConfig
+	bucket  *oss.Bucket
+	private bool // 是否保存为私有文件
+}
+
+// 创建阿里云OSS存储
+func NewOSSStorage(config *OSSConfig) (*OSSStorage, error) {
+	if config == nil {
+		return nil, i18n.NewError("storage.oss_not_configured")
+	}
+
+	client, err := oss.New(config.Endpoint, config.AccessKeyID, config.AccessKeySecret)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := client.Bucket(config.BucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OSSStorage{
+		config: config,
+		bucket: bucket,
+	}, nil
+}
+
+// 判断是否为文件不存在错误
+func (p *OSSStorage) isNotExist(err error) bool {
+	var serviceError oss.ServiceError
+	if errors.As(err, &serviceError) {
+		return serviceError.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+// 保存文件
+func (p *OSSStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	acl := oss.ACLPublicRead
+	if p.private {
+		acl = oss.ACLPrivate
+	}
+
+	options := []oss.Option{oss.ObjectACL(acl)}
+	if contentType != "" {
+		options = append(options, oss.ContentType(contentType))
+	}
+
+	return p.bucket.PutObject(key, reader, options...)
+}
+
+// 读取文件
+func (p *OSSStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	body, err := p.bucket.GetObject(key)
+	if p.isNotExist(err) {
+		return nil, ErrNotExist
+	}
+
+	return body, err
+}
+
+// 删除文件
+func (p *OSSStorage) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return p.bucket.DeleteObject(key)
+}
+
+// 判断文件是否存在
+func (p *OSSStorage) Exists(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return p.bucket.IsObjectExist(key)
+}
+
+// 获取文件信息
+func (p *OSSStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	header, err := p.bucket.GetObjectDetailedMeta(key)
+	if p.isNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	size, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	lastModified, _ := time.Parse(http.TimeFormat, header.Get("Last-Modified"))
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         size,
+		ContentType:  header.Get("Content-Type"),
+		LastModified: lastModified,
+	}, nil
+}
+
+// 获取文件访问地址
+func (p *OSSStorage) URL(key string) string {
+	if p.config.Domain != "" {
+		return "//" + p.config.Domain + "/" + key
+	}
+
+	return "//" + p.config.BucketName + "." + p.config.Endpoint + "/" + key
+}
+
+// 生成带签名的临时访问地址
+func (p *OSSStorage) PresignURL(ctx context.Context, key string, expire time.Duration) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return p.bucket.SignURL(key, oss.HTTPGet, int64(expire/time.Second))
+}
+
+// 生成浏览器直传的签名，使用PostObject表单上传，限制保存路径、文件类型及大小
+func (p *OSSStorage) PresignUpload(ctx context.Context, policy *UploadPolicy) (*PresignedUpload, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	acl := string(oss.ACLPublicRead)
+	if p.private {
+		acl = string(oss.ACLPrivate)
+	}
+
+	expiresAt := time.Now().Add(policy.Expire)
+	conditions := []interface{}{
+		map[string]string{"bucket": p.config.BucketName},
+		[]interface{}{"eq", "$key", policy.Key},
+		[]interface{}{"eq", "$Content-Type", policy.ContentType},
+		map[string]string{"x-oss-object-acl": acl},
+	}
+	if policy.MaxSize > 0 {
+		conditions = append(conditions, []interface{}{"content-length-range", 0, policy.MaxSize})
+	}
+
+	policyJson, err := json.Marshal(map[string]interface{}{
+		"expiration": expiresAt.UTC().Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedPolicy := base64.StdEncoding.EncodeToString(policyJson)
+	mac := hmac.New(sha1.New, []byte(p.config.AccessKeySecret))
+	mac.Write([]byte(encodedPolicy))
+
+	// 上传地址为Bucket域名，未指定协议时使用https
+	scheme, endpoint := "https", p.config.Endpoint
+	if index := strings.Index(endpoint, "://"); index >= 0 {
+		scheme, endpoint = endpoint[:index], endpoint[index+3:]
+	}
+
+	return &PresignedUpload{
+		Method: http.MethodPost,
+		Url:    scheme + "://" + p.config.BucketName + "." + strings.TrimSuffix(endpoint, "/"),
+		Fields: map[string]string{
+			"key":                   policy.Key,
+			"OSSAccessKeyId":        p.config.AccessKeyID,
+			"policy":                encodedPolicy,
+			"Signature":             base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+			"Content-Type":          policy.ContentType,
+			"x-oss-object-acl":      acl,
+			"success_action_status": "200",
+		},
+		Key:       policy.Key,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// 列出前缀下的所有文件
+func (p *OSSStorage) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	objects := []*ObjectInfo{}
+	token := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return objects, err
+		}
+
+		result, err := p.bucket.ListObjectsV2(oss.Prefix(prefix), oss.ContinuationToken(token))
+		if err != nil {
+			return objects, err
+		}
+		for _, v := range result.Objects {
+			objects = append(objects, &ObjectInfo{
+				Key:          v.Key,
+				Size:         v.Size,
+				LastModified: v.LastModified,
+			})
+		}
+		if !result.IsTruncated {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return objects, nil
+}
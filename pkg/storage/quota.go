@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+)
+
+// 存储空间不足的错误，remaining为剩余空间，单位为字节
+func QuotaError(remaining int64) error {
+	return i18n.NewError("storage.quota_exceeded", file.FormatSize(remaining))
+}
+
+// 按剩余存储空间限制文件大小，remaining单位为字节，小于0时不限制；没有剩余空间时返回存储空间不足的错误
+//
+// 文件大小取限制文件大小与剩余空间的较小值，由剩余空间引起的超出限制返回存储空间不足的错误
+func (p *FileSystem) LimitQuota(remaining int64) error {
+	p.quota = 0
+	if remaining < 0 {
+		return nil
+	}
+	if remaining == 0 {
+		return QuotaError(remaining)
+	}
+
+	p.quota = remaining
+
+	return nil
+}
+
+// 判断文件大小的限制是否来自剩余存储空间
+func (p *FileSystem) quotaLimited() bool {
+	return p.quota > 0 && (p.Config.LimitSize == 0 || p.quota < p.Config.LimitSize)
+}
+
+// 获取文件大小的限制，为0时不限制
+func (p *FileSystem) limitSize() int64 {
+	if p.quotaLimited() {
+		return p.quota
+	}
+
+	return p.Config.LimitSize
+}
+
+// 文件大小超出限制的错误
+func (p *FileSystem) sizeError() error {
+	if p.quotaLimited() {
+		return QuotaError(p.quota)
+	}
+
+	return i18n.NewError("storage.size_exceeded")
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+func TestLimitQuota(t *testing.T) {
+	tests := []struct {
+		name      string
+		limitSize int64
+		remaining int64
+		key       string
+	}{
+		{"unlimited", 0, -1, ""},
+		{"no space left", 0, 0, "storage.quota_exceeded"},
+		{"quota is tighter", 100, 5, "storage.quota_exceeded"},
+		{"limit size is tighter", 5, 100, "storage.size_exceeded"},
+		{"enough space", 0, 100, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileSystem := New(&Config{Driver: MemoryDriver, LimitSize: tt.limitSize}).
+				Reader(&File{
+					Name:        "demo.txt",
+					ContentType: "text/plain",
+					Reader:      strings.NewReader("hello quota"),
+				}).
+				Path("quota/files/").
+				Name("demo.txt")
+
+			err := fileSystem.LimitQuota(tt.remaining)
+			if err == nil {
+				_, err = fileSystem.Save()
+			}
+
+			var localeError *i18n.Error
+			if tt.key == "" {
+				if err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &localeError) || localeError.Key != tt.key {
+				t.Fatalf("Save: got error %v, want %s", err, tt.key)
+			}
+		})
+	}
+}
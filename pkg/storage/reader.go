@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"io"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 读取文件头的大小，用于检测文件类型及读取图片宽高
+const headSize = 1 << 20
+
+// 上传时的分片大小，未知大小的数据流按分片上传，每次最多缓存一个分片
+const partSize = 16 << 20
+
+// 数据流在保存前无法计算哈希值
+var ErrHashUnavailable = i18n.NewError("storage.hash_unavailable")
+
+// 统计读取的字节数，超过限制大小时返回错误
+type sizeReader struct {
+	reader   io.Reader
+	limit    int64
+	size     int64
+	exceeded bool
+}
+
+// 读取数据
+func (p *sizeReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.size += int64(n)
+	if p.limit > 0 && p.size > p.limit {
+		p.exceeded = true
+		return n, i18n.NewError("storage.size_exceeded")
+	}
+
+	return n, err
+}
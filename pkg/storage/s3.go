@@ -0,0 +1,329 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// S3兼容存储，支持AWS S3及其他兼容S3协议的对象存储
+type S3Storage struct {
+	config *S3Config
+	client *s3.Client
+}
+
+// 创建S3兼容存储
+func NewS3Storage(config *S3Config) (*S3Storage, error) {
+	if config == nil {
+		return nil, i18n.NewError("storage.s3_not_configured")
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	options := s3.Options{
+		Region:       region,
+		Credentials:  credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+		UsePathStyle: config.UsePathStyle,
+	}
+	if config.Endpoint != "" {
+		options.EndpointResolver = s3.EndpointResolverFromURL(config.Endpoint)
+	}
+
+	return &S3Storage{
+		config: config,
+		client: s3.New(options),
+	}, nil
+}
+
+// 判断是否为文件不存在错误
+func (p *S3Storage) isNotExist(err error) bool {
+	var responseError interface{ HTTPStatusCode() int }
+	if errors.As(err, &responseError) {
+		return responseError.HTTPStatusCode() == http.StatusNotFound
+	}
+
+	return false
+}
+
+// 保存文件，超过一个分片大小时使用分片上传，内存中最多缓存一个分片
+func (p *S3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	bufferSize := partSize
+	if size >= 0 && size < partSize {
+		bufferSize = int(size) + 1
+	}
+
+	buffer := make([]byte, bufferSize)
+	n, err := io.ReadFull(reader, buffer)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return p.putObject(ctx, key, buffer[:n], contentType)
+	}
+	if err != nil {
+		return err
+	}
+
+	return p.putMultipart(ctx, key, io.MultiReader(bytes.NewReader(buffer), reader), contentType)
+}
+
+// 单次上传
+func (p *S3Storage) putObject(ctx context.Context, key string, content []byte, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(p.config.BucketName),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(content),
+		ContentLength: int64(len(content)),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	_, err := p.client.PutObject(ctx, input)
+
+	return err
+}
+
+// 分片上传，失败时取消上传
+func (p *S3Storage) putMultipart(ctx context.Context, key string, reader io.Reader, contentType string) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(p.config.BucketName),
+		Key:    aws.String(key),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	upload, err := p.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	parts := []types.CompletedPart{}
+	part := make([]byte, partSize)
+	for partNumber := int32(1); ; partNumber++ {
+		n, readErr := io.ReadFull(reader, part)
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil && readErr != io.ErrUnexpectedEOF {
+			p.abortMultipart(key, upload.UploadId)
+			return readErr
+		}
+
+		output, err := p.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(p.config.BucketName),
+			Key:           aws.String(key),
+			UploadId:      upload.UploadId,
+			PartNumber:    partNumber,
+			Body:          bytes.NewReader(part[:n]),
+			ContentLength: int64(n),
+		})
+		if err != nil {
+			p.abortMultipart(key, upload.UploadId)
+			return err
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:       output.ETag,
+			PartNumber: partNumber,
+		})
+
+		// 最后一个分片
+		if readErr == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+
+	_, err = p.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(p.config.BucketName),
+		Key:             aws.String(key),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		p.abortMultipart(key, upload.UploadId)
+	}
+
+	return err
+}
+
+// 取消分片上传，请求上下文可能已取消，因此使用新的上下文
+func (p *S3Storage) abortMultipart(key string, uploadId *string) {
+	p.client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(p.config.BucketName),
+		Key:      aws.String(key),
+		UploadId: uploadId,
+	})
+}
+
+// 读取文件
+func (p *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := p.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if p.isNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+// 删除文件
+func (p *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := p.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(p.config.BucketName),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+// 判断文件是否存在
+func (p *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := p.Stat(ctx, key)
+	if err == ErrNotExist {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// 获取文件信息
+func (p *S3Storage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	output, err := p.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(p.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if p.isNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ObjectInfo{
+		Key:         key,
+		Size:        output.ContentLength,
+		ContentType: aws.ToString(output.ContentType),
+	}
+	if output.LastModified != nil {
+		info.LastModified = *output.LastModified
+	}
+
+	return info, nil
+}
+
+// 获取文件访问地址
+func (p *S3Storage) URL(key string) string {
+	if p.config.Domain != "" {
+		return "//" + p.config.Domain + "/" + key
+	}
+
+	if p.config.Endpoint != "" {
+		host := p.config.Endpoint
+		if endpoint, err := url.Parse(p.config.Endpoint); err == nil && endpoint.Host != "" {
+			host = endpoint.Host + strings.TrimSuffix(endpoint.Path, "/")
+		}
+		if p.config.UsePathStyle {
+			return "//" + host + "/" + p.config.BucketName + "/" + key
+		}
+
+		return "//" + p.config.BucketName + "." + host + "/" + key
+	}
+
+	region := p.config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return "//" + p.config.BucketName + ".s3." + region + ".amazonaws.com/" + key
+}
+
+// 生成带签名的临时访问地址
+func (p *S3Storage) PresignURL(ctx context.Context, key string, expire time.Duration) (string, error) {
+	request, err := s3.NewPresignClient(p.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.config.BucketName),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expire))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+// 生成浏览器直传的签名，使用PUT方式上传，文件类型及大小包含在签名中
+func (p *S3Storage) PresignUpload(ctx context.Context, policy *UploadPolicy) (*PresignedUpload, error) {
+	if policy.Size <= 0 || (policy.MaxSize > 0 && policy.Size > policy.MaxSize) {
+		return nil, i18n.NewError("storage.size_exceeded")
+	}
+
+	expiresAt := time.Now().Add(policy.Expire)
+	request, err := s3.NewPresignClient(p.client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(p.config.BucketName),
+		Key:           aws.String(policy.Key),
+		ContentType:   aws.String(policy.ContentType),
+		ContentLength: policy.Size,
+	}, s3.WithPresignExpires(policy.Expire))
+	if err != nil {
+		return nil, err
+	}
+
+	// 浏览器会自动设置Host及Content-Length请求头
+	headers := map[string]string{}
+	for k := range request.SignedHeader {
+		if !strings.EqualFold(k, "Host") && !strings.EqualFold(k, "Content-Length") {
+			headers[k] = request.SignedHeader.Get(k)
+		}
+	}
+
+	return &PresignedUpload{
+		Method:    request.Method,
+		Url:       request.URL,
+		Headers:   headers,
+		Key:       policy.Key,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// 列出前缀下的所有文件
+func (p *S3Storage) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	objects := []*ObjectInfo{}
+	paginator := s3.NewListObjectsV2Paginator(p.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(p.config.BucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return objects, err
+		}
+		for _, v := range output.Contents {
+			info := &ObjectInfo{
+				Key:  aws.ToString(v.Key),
+				Size: v.Size,
+			}
+			if v.LastModified != nil {
+				info.LastModified = *v.LastModified
+			}
+			objects = append(objects, info)
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 本地的S3替身，使用路径形式访问Bucket，只实现驱动用到的接口
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]*fakeS3Object
+	uploads map[string]map[int][]byte
+	nextId  int
+}
+
+type fakeS3Object struct {
+	content     []byte
+	contentType string
+	modified    time.Time
+}
+
+func newFakeS3(t *testing.T, bucket string) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{
+		bucket:  bucket,
+		objects: map[string]*fakeS3Object{},
+		uploads: map[string]map[int][]byte{},
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, server
+}
+
+func (p *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != p.bucket {
+		p.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && query.Get("list-type") == "2":
+		p.list(w, query.Get("prefix"))
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		p.nextId++
+		uploadId := strconv.Itoa(p.nextId)
+		p.uploads[uploadId] = map[int][]byte{}
+		p.objects[key+"#"+uploadId] = &fakeS3Object{contentType: r.Header.Get("Content-Type")}
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, p.bucket, key, uploadId)
+	case r.Method == http.MethodPut && query.Get("uploadId") != "":
+		parts, ok := p.uploads[query.Get("uploadId")]
+		if !ok {
+			p.error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		content, _ := io.ReadAll(r.Body)
+		parts[partNumber] = content
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, partNumber))
+	case r.Method == http.MethodPost && query.Get("uploadId") != "":
+		uploadId := query.Get("uploadId")
+		parts, ok := p.uploads[uploadId]
+		if !ok {
+			p.error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		numbers := []int{}
+		for k := range parts {
+			numbers = append(numbers, k)
+		}
+		sort.Ints(numbers)
+		content := []byte{}
+		for _, v := range numbers {
+			content = append(content, parts[v]...)
+		}
+		object := p.objects[key+"#"+uploadId]
+		delete(p.objects, key+"#"+uploadId)
+		delete(p.uploads, uploadId)
+		p.objects[key] = &fakeS3Object{content: content, contentType: object.contentType, modified: time.Now()}
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key></CompleteMultipartUploadResult>`, p.bucket, key)
+	case r.Method == http.MethodDelete && query.Get("uploadId") != "":
+		delete(p.uploads, query.Get("uploadId"))
+		delete(p.objects, key+"#"+query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		p.objects[key] = &fakeS3Object{content: content, contentType: r.Header.Get("Content-Type"), modified: time.Now()}
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		object, ok := p.objects[key]
+		if !ok || strings.Contains(key, "#") {
+			p.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.content)))
+		w.Header().Set("Last-Modified", object.modified.UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(object.content)
+		}
+	case r.Method == http.MethodDelete:
+		delete(p.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		p.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// 列出文件
+func (p *fakeS3) list(w http.ResponseWriter, prefix string) {
+	type content struct {
+		Key          string
+		Size         int64
+		LastModified string
+	}
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		IsTruncated bool
+		Contents    []content
+	}{Name: p.bucket, Prefix: prefix}
+
+	keys := []string{}
+	for k := range p.objects {
+		if strings.HasPrefix(k, prefix) && !strings.Contains(k, "#") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		result.Contents = append(result.Contents, content{
+			Key:          k,
+			Size:         int64(len(p.objects[k].content)),
+			LastModified: p.objects[k].modified.UTC().Format(time.RFC3339),
+		})
+	}
+	result.KeyCount = len(result.Contents)
+
+	xml.NewEncoder(w).Encode(result)
+}
+
+// 返回错误，HEAD请求没有响应体
+func (p *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func newTestS3Storage(t *testing.T) (*fakeS3, *S3Storage) {
+	fake, server := newFakeS3(t, "bucket")
+	driver, err := NewS3Storage(&S3Config{
+		Endpoint:        server.URL,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		BucketName:      "bucket",
+		UsePathStyle:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+
+	return fake, driver
+}
+
+func TestS3Storage(t *testing.T) {
+	_, driver := newTestS3Storage(t)
+	testDriverContract(t, driver, "storage/")
+}
+
+func TestS3StorageMultipart(t *testing.T) {
+	fake, driver := newTestS3Storage(t)
+
+	// 超过一个分片大小时使用分片上传
+	content := bytes.Repeat([]byte("0123456789abcdef"), partSize/16+1024)
+	err := driver.Put(context.Background(), "storage/large.bin", bytes.NewReader(content), -1, "application/octet-stream")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	object, ok := fake.objects["storage/large.bin"]
+	if !ok {
+		t.Fatalf("Put: object was not stored")
+	}
+	if !bytes.Equal(object.content, content) {
+		t.Fatalf("Put: stored %d bytes, want %d", len(object.content), len(content))
+	}
+	if len(fake.uploads) != 0 {
+		t.Fatalf("Put: %d multipart uploads left open", len(fake.uploads))
+	}
+}
+
+func TestS3StorageURL(t *testing.T) {
+	tests := []struct {
+		config *S3Config
+		want   string
+	}{
+		{&S3Config{BucketName: "bucket", Domain: "cdn.example.com"}, "//cdn.example.com/a.png"},
+		{&S3Config{BucketName: "bucket", Endpoint: "http://127.0.0.1:9000", UsePathStyle: true}, "//127.0.0.1:9000/bucket/a.png"},
+		{&S3Config{BucketName: "bucket", Endpoint: "https://s3.example.com"}, "//bucket.s3.example.com/a.png"},
+		{&S3Config{BucketName: "bucket", Region: "eu-west-1"}, "//bucket.s3.eu-west-1.amazonaws.com/a.png"},
+	}
+	for _, v := range tests {
+		driver, err := NewS3Storage(v.config)
+		if err != nil {
+			t.Fatalf("NewS3Storage: %v", err)
+		}
+		if got := driver.URL("a.png"); got != v.want {
+			t.Errorf("URL: got %q, want %q", got, v.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+)
+
+// 扫描状态
+const (
+	ScanClean    = "clean"    // 未发现问题
+	ScanInfected = "infected" // 发现恶意或违规内容
+	ScanError    = "error"    // 扫描出错，仅在ScanFailOpen开启时保存
+)
+
+// 扫描结果
+type ScanResult struct {
+	Status  string `json:"status"`  // 扫描状态
+	Threat  string `json:"threat"`  // 威胁名称，例如：Eicar-Test-Signature
+	Scanner string `json:"scanner"` // 扫描器名称
+}
+
+// 文件内容扫描器，上传的文件在写入保存路径前扫描
+type Scanner interface {
+
+	// 扫描器名称
+	Name() string
+
+	// 扫描文件内容，发现威胁时返回状态为ScanInfected的结果，无法完成扫描时返回错误
+	Scan(ctx context.Context, name string, reader io.Reader) (*ScanResult, error)
+}
+
+// 设置扫描器，按顺序执行，任一扫描器发现威胁即终止
+func (p *FileSystem) Scanners(scanners ...Scanner) *FileSystem {
+	p.Config.Scanners = scanners
+
+	return p
+}
+
+// 设置隔离目录，未通过扫描的文件移动到该目录，为空时直接删除
+func (p *FileSystem) QuarantinePath(path string) *FileSystem {
+	p.Config.QuarantinePath = path
+
+	return p
+}
+
+// 使用配置的扫描器扫描文件内容，open用于读取文件内容，每个扫描器读取一次
+//
+// 发现威胁时返回错误并记录扫描结果；扫描器出错时未开启ScanFailOpen则返回错误，开启时继续执行其余扫描器，全部通过后扫描状态记为error
+func (p *FileSystem) scan(open func() (io.ReadCloser, error)) error {
+	if len(p.Config.Scanners) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	failures := []string{}
+	for _, scanner := range p.Config.Scanners {
+		reader, err := open()
+		if err != nil {
+			return err
+		}
+		result, err := scanner.Scan(p.context(), p.File.Name, reader)
+		reader.Close()
+
+		if err != nil {
+			if !p.Config.ScanFailOpen {
+				return i18n.NewError("storage.scan_failed", err.Error())
+			}
+
+			failures = append(failures, scanner.Name()+": "+err.Error())
+			continue
+		}
+
+		if result.Status == ScanInfected {
+			p.File.ScanStatus = ScanInfected
+			p.File.ScanResult = scanner.Name() + ": " + result.Threat
+
+			return i18n.NewError("storage.file_infected", result.Threat)
+		}
+
+		names = append(names, scanner.Name())
+	}
+
+	if len(failures) > 0 {
+		p.File.ScanStatus = ScanError
+		p.File.ScanResult = strings.Join(failures, ",")
+
+		return nil
+	}
+
+	p.File.ScanStatus = ScanClean
+	p.File.ScanResult = strings.Join(names, ",")
+
+	return nil
+}
+
+// 将未通过扫描的文件保存到隔离目录，未设置隔离目录时不保存
+func (p *FileSystem) quarantine(driver Driver, open func() (io.ReadCloser, error)) error {
+	if p.Config.QuarantinePath == "" {
+		return nil
+	}
+
+	reader, err := open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// 上传请求可能已取消，隔离操作不再跟随请求上下文
+	quarantineKey := p.Config.QuarantinePath + p.Config.SaveName
+	err = driver.Put(context.Background(), quarantineKey, reader, p.File.Size, p.File.ContentType)
+	if err != nil {
+		return err
+	}
+	p.File.QuarantineKey = quarantineKey
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ClamAV扫描器，通过clamd协议的INSTREAM命令以数据流方式发送文件内容
+//
+//	scanner := storage.NewClamAVScanner("tcp", "127.0.0.1:3310")
+//	scanner := storage.NewClamAVScanner("unix", "/var/run/clamav/clamd.ctl")
+type ClamAVScanner struct {
+	Network   string        // 网络类型：tcp、unix
+	Address   string        // clamd地址
+	Timeout   time.Duration // 单次扫描的超时时间，默认60秒
+	ChunkSize int           // 发送数据的分块大小，默认64KB
+}
+
+// 创建ClamAV扫描器
+func NewClamAVScanner(network string, address string) *ClamAVScanner {
+	return &ClamAVScanner{
+		Network:   network,
+		Address:   address,
+		Timeout:   60 * time.Second,
+		ChunkSize: 64 << 10,
+	}
+}
+
+// 扫描器名称
+func (p *ClamAVScanner) Name() string {
+	return "clamav"
+}
+
+// 连接clamd，连接的截止时间取超时时间与上下文截止时间中较早的一个
+func (p *ClamAVScanner) dial(ctx context.Context) (net.Conn, error) {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, p.Network, p.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	return conn, nil
+}
+
+// 执行命令并读取以\0结尾的响应
+func (p *ClamAVScanner) command(ctx context.Context, command string) (string, error) {
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("z" + command + "\x00"))
+	if err != nil {
+		return "", err
+	}
+
+	return p.readReply(conn)
+}
+
+// 读取响应
+func (p *ClamAVScanner) readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return "", err
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+// 检测clamd是否可用
+func (p *ClamAVScanner) Ping(ctx context.Context) error {
+	reply, err := p.command(ctx, "PING")
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return errors.New("clamav: unexpected reply: " + reply)
+	}
+
+	return nil
+}
+
+// 获取clamd及病毒库版本
+func (p *ClamAVScanner) Version(ctx context.Context) (string, error) {
+	return p.command(ctx, "VERSION")
+}
+
+// 扫描文件内容
+func (p *ClamAVScanner) Scan(ctx context.Context, name string, reader io.Reader) (*ScanResult, error) {
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// 上下文取消时关闭连接，终止扫描
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	readErr, writeErr := p.stream(conn, reader)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	// 超过clamd的StreamMaxLength时clamd会返回错误后关闭连接，此时仍然读取响应
+	reply, err := p.readReply(conn)
+	if err != nil {
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		return nil, err
+	}
+
+	return p.parseReply(reply)
+}
+
+// 按分块发送文件内容，每个分块以4字节大端长度开头，以长度为0的分块结束，分别返回读取文件及发送数据的错误
+func (p *ClamAVScanner) stream(conn net.Conn, reader io.Reader) (readErr error, writeErr error) {
+	chunkSize := p.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 64 << 10
+	}
+
+	_, err := conn.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := io.ReadFull(reader, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return nil, err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err, nil
+		}
+	}
+
+	_, err = conn.Write([]byte{0, 0, 0, 0})
+
+	return nil, err
+}
+
+// 解析扫描结果，例如：stream: OK、stream: Eicar-Test-Signature FOUND
+func (p *ClamAVScanner) parseReply(reply string) (*ScanResult, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return &ScanResult{Status: ScanClean, Scanner: p.Name()}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return &ScanResult{
+			Status:  ScanInfected,
+			Threat:  strings.TrimSuffix(reply, " FOUND"),
+			Scanner: p.Name(),
+		}, nil
+	}
+
+	return nil, errors.New("clamav: " + strings.TrimSuffix(reply, " ERROR"))
+}
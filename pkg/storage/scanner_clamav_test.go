@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 本地的clamd替身，支持PING及INSTREAM命令，reply根据收到的文件内容返回响应
+type fakeClamd struct {
+	listener net.Listener
+	reply    func(content []byte) string
+	delay    time.Duration
+}
+
+func newFakeClamd(t *testing.T, reply func(content []byte) string) *fakeClamd {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	fake := &fakeClamd{listener: listener, reply: reply}
+	t.Cleanup(func() { listener.Close() })
+	go fake.serve()
+
+	return fake
+}
+
+func (p *fakeClamd) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	command, err := reader.ReadString(0)
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimSuffix(command, "\x00") {
+	case "zPING":
+		conn.Write([]byte("PONG\x00"))
+	case "zINSTREAM":
+		content := []byte{}
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(reader, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			chunk := make([]byte, n)
+			if _, err := io.ReadFull(reader, chunk); err != nil {
+				return
+			}
+			content = append(content, chunk...)
+		}
+		time.Sleep(p.delay)
+		conn.Write([]byte("stream: " + p.reply(content) + "\x00"))
+	default:
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func (p *fakeClamd) scanner() *ClamAVScanner {
+	scanner := NewClamAVScanner("tcp", p.listener.Addr().String())
+	scanner.ChunkSize = 8
+
+	return scanner
+}
+
+// 内容包含EICAR时报告感染，包含ERROR时报告扫描出错
+func fakeClamdReply(content []byte) string {
+	switch {
+	case bytes.Contains(content, []byte("EICAR")):
+		return "Eicar-Test-Signature FOUND"
+	case bytes.Contains(content, []byte("ERROR")):
+		return "INSTREAM size limit exceeded. ERROR"
+	}
+
+	return "OK"
+}
+
+func TestClamAVScannerPing(t *testing.T) {
+	fake := newFakeClamd(t, fakeClamdReply)
+
+	err := fake.scanner().Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+}
+
+func TestClamAVScannerClean(t *testing.T) {
+	fake := newFakeClamd(t, func(content []byte) string {
+		if string(content) != "a clean file spanning several chunks" {
+			return "unexpected content " + string(content) + " ERROR"
+		}
+		return "OK"
+	})
+
+	result, err := fake.scanner().Scan(context.Background(), "demo.txt", strings.NewReader("a clean file spanning several chunks"))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if result.Status != ScanClean || result.Scanner != "clamav" {
+		t.Fatalf("Scan: got %+v, want clean", result)
+	}
+}
+
+func TestClamAVScannerInfected(t *testing.T) {
+	fake := newFakeClamd(t, fakeClamdReply)
+
+	result, err := fake.scanner().Scan(context.Background(), "eicar.txt", strings.NewReader("X5O!P%@AP EICAR test"))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if result.Status != ScanInfected || result.Threat != "Eicar-Test-Signature" {
+		t.Fatalf("Scan: got %+v, want infected", result)
+	}
+}
+
+func TestClamAVScannerError(t *testing.T) {
+	fake := newFakeClamd(t, fakeClamdReply)
+
+	_, err := fake.scanner().Scan(context.Background(), "large.bin", strings.NewReader("ERROR"))
+	if err == nil || !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Fatalf("Scan: got %v, want clamd error", err)
+	}
+}
+
+func TestClamAVScannerTimeout(t *testing.T) {
+	fake := newFakeClamd(t, fakeClamdReply)
+	fake.delay = time.Second
+
+	scanner := fake.scanner()
+	scanner.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := scanner.Scan(context.Background(), "slow.txt", strings.NewReader("slow"))
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("Scan: got %v, want timeout", err)
+	}
+	if time.Since(start) >= fake.delay {
+		t.Fatalf("Scan: did not stop at the timeout")
+	}
+}
+
+func TestClamAVScannerContextCanceled(t *testing.T) {
+	fake := newFakeClamd(t, fakeClamdReply)
+	fake.delay = time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := fake.scanner().Scan(ctx, "slow.txt", strings.NewReader("slow"))
+	if err == nil {
+		t.Fatalf("Scan: want error after the context is canceled")
+	}
+	if time.Since(start) >= fake.delay {
+		t.Fatalf("Scan: did not stop when the context was canceled")
+	}
+}
+
+func TestClamAVScannerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = NewClamAVScanner("tcp", address).Scan(context.Background(), "demo.txt", strings.NewReader("demo"))
+	if err == nil {
+		t.Fatalf("Scan: want error when clamd is unavailable")
+	}
+}
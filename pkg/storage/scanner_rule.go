@@ -0,0 +1,267 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// 基于规则的扫描器，检查压缩包炸弹及多格式混合的文件
+type RuleScanner struct {
+	MaxScanSize         int64   // 读入内存检查的最大文件大小，超过时只检查文件头，默认64MB
+	MaxEntries          int     // 压缩包最大文件数量，默认10000
+	MaxUncompressedSize int64   // 压缩包解压后的最大大小，默认1GB
+	MaxRatio            float64 // 解压后超过1MB时允许的最大压缩比，默认100
+	MaxDepth            int     // 压缩包最大嵌套层数，默认3
+}
+
+// 创建规则扫描器
+func NewRuleScanner() *RuleScanner {
+	return &RuleScanner{
+		MaxScanSize:         64 << 20,
+		MaxEntries:          10000,
+		MaxUncompressedSize: 1 << 30,
+		MaxRatio:            100,
+		MaxDepth:            3,
+	}
+}
+
+// 扫描器名称
+func (p *RuleScanner) Name() string {
+	return "rule"
+}
+
+// 扫描文件内容
+func (p *RuleScanner) Scan(ctx context.Context, name string, reader io.Reader) (*ScanResult, error) {
+	content, err := io.ReadAll(io.LimitReader(reader, p.MaxScanSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	// 超过检查大小时只检查文件头
+	complete := int64(len(content)) <= p.MaxScanSize
+	if !complete && len(content) > headSize {
+		content = content[:headSize]
+	}
+
+	threat := p.checkPolyglot(content, complete)
+	if threat == "" && complete {
+		threat = p.checkArchive(content, 0)
+	}
+	if threat != "" {
+		return &ScanResult{Status: ScanInfected, Threat: threat, Scanner: p.Name()}, nil
+	}
+
+	return &ScanResult{Status: ScanClean, Scanner: p.Name()}, nil
+}
+
+// 判断是否为zip格式，docx、xlsx、jar等格式均基于zip
+func (p *RuleScanner) isZip(mime *mimetype.MIME) bool {
+	for m := mime; m != nil; m = m.Parent() {
+		if m.Is("application/zip") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 检查多格式混合的文件，例如图片中嵌入脚本、文件末尾追加zip压缩包
+func (p *RuleScanner) checkPolyglot(content []byte, complete bool) string {
+	mime := mimetype.Detect(content)
+	isImage := strings.HasPrefix(mime.String(), "image/")
+
+	// 图片或PDF末尾包含zip目录结构，可以同时被当作压缩包解析
+	if complete && (isImage || mime.Is("application/pdf")) {
+		tail := content
+		if len(tail) > 65557 {
+			tail = tail[len(tail)-65557:]
+		}
+		if bytes.Contains(tail, []byte("PK\x05\x06")) && bytes.Contains(content, []byte("PK\x03\x04")) {
+			return "Polyglot.Zip"
+		}
+	}
+
+	// 图片的文件头中包含PDF标记
+	if isImage {
+		head := content
+		if len(head) > 1024 {
+			head = head[:1024]
+		}
+		if bytes.Contains(head, []byte("%PDF-")) {
+			return "Polyglot.Pdf"
+		}
+	}
+
+	if !isImage {
+		return ""
+	}
+
+	// SVG中的脚本会在浏览器直接打开时执行
+	lower := bytes.ToLower(content)
+	if mime.Is("image/svg+xml") {
+		if bytes.Contains(lower, []byte("<script")) {
+			return "Svg.Script"
+		}
+		return ""
+	}
+
+	for _, v := range []string{"<?php", "<script", "<html", "<%@"} {
+		if bytes.Contains(lower, []byte(v)) {
+			return "Polyglot.Script"
+		}
+	}
+
+	return ""
+}
+
+// 检查压缩包炸弹
+func (p *RuleScanner) checkArchive(content []byte, depth int) string {
+	mime := mimetype.Detect(content)
+	switch {
+	case p.isZip(mime):
+		return p.checkZip(content, depth)
+	case mime.Is("application/gzip"):
+		return p.checkGzip(content, depth)
+	}
+
+	return ""
+}
+
+// 检查zip压缩包，解压时统计实际大小，不信任压缩包中记录的大小
+func (p *RuleScanner) checkZip(content []byte, depth int) string {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return ""
+	}
+
+	if len(reader.File) > p.MaxEntries {
+		return "Archive.Bomb.Entries"
+	}
+
+	// 多个文件共用同一段压缩数据
+	offsets := []int64{}
+	for _, f := range reader.File {
+		offset, err := f.DataOffset()
+		if err != nil {
+			return ""
+		}
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] == offsets[i-1] {
+			return "Archive.Bomb.Overlap"
+		}
+	}
+
+	total := int64(0)
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+
+		// 嵌套的压缩包读入内存继续检查
+		nested := p.isArchiveName(f.Name)
+		n, buf := p.decompress(rc, nested, p.MaxUncompressedSize-total+1)
+		rc.Close()
+
+		total += n
+		if total > p.MaxUncompressedSize {
+			return "Archive.Bomb.Size"
+		}
+
+		if nested {
+			if depth+1 >= p.MaxDepth {
+				return "Archive.Bomb.Nested"
+			}
+			if threat := p.checkArchive(buf, depth+1); threat != "" {
+				return threat
+			}
+		}
+	}
+
+	if p.exceedsRatio(total, int64(len(content))) {
+		return "Archive.Bomb.Ratio"
+	}
+
+	return ""
+}
+
+// 检查gzip压缩包
+func (p *RuleScanner) checkGzip(content []byte, depth int) string {
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+
+	n, buf := p.decompress(reader, true, p.MaxUncompressedSize+1)
+	if n > p.MaxUncompressedSize {
+		return "Archive.Bomb.Size"
+	}
+	if p.exceedsRatio(n, int64(len(content))) {
+		return "Archive.Bomb.Ratio"
+	}
+
+	// tar.gz中的文件不再单独检查，只检查gzip中直接嵌套的压缩包
+	if depth+1 >= p.MaxDepth {
+		return ""
+	}
+
+	return p.checkArchive(buf, depth+1)
+}
+
+// 读取解压后的内容，最多读取limit字节，keep为true时保留不超过MaxScanSize的内容用于继续检查
+func (p *RuleScanner) decompress(reader io.Reader, keep bool, limit int64) (int64, []byte) {
+	if !keep {
+		n, _ := io.CopyN(io.Discard, reader, limit)
+		return n, nil
+	}
+
+	buf := &bytes.Buffer{}
+	kept := limit
+	if kept > p.MaxScanSize+1 {
+		kept = p.MaxScanSize + 1
+	}
+	n, _ := io.CopyN(buf, reader, kept)
+	rest, _ := io.CopyN(io.Discard, reader, limit-n)
+
+	// 超过检查大小的内容不再继续检查
+	if n > p.MaxScanSize {
+		return n + rest, nil
+	}
+
+	return n + rest, buf.Bytes()
+}
+
+// 判断压缩比是否超过限制，解压后小于1MB的不检查
+func (p *RuleScanner) exceedsRatio(uncompressed int64, compressed int64) bool {
+	if p.MaxRatio <= 0 || uncompressed < 1<<20 || compressed == 0 {
+		return false
+	}
+
+	return float64(uncompressed)/float64(compressed) > p.MaxRatio
+}
+
+// 根据文件名判断是否为压缩包
+func (p *RuleScanner) isArchiveName(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".zip", ".gz", ".tgz", ".jar":
+		return true
+	}
+
+	return false
+}
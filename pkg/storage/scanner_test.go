@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// 测试用的扫描器，内容包含threat时报告威胁，err不为空时返回错误
+type stubScanner struct {
+	name   string
+	threat string
+	err    error
+	check  func()
+	called bool
+}
+
+func (p *stubScanner) Name() string {
+	return p.name
+}
+
+func (p *stubScanner) Scan(ctx context.Context, name string, reader io.Reader) (*ScanResult, error) {
+	p.called = true
+	if p.check != nil {
+		p.check()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if p.threat != "" && bytes.Contains(content, []byte(p.threat)) {
+		return &ScanResult{Status: ScanInfected, Threat: p.threat, Scanner: p.name}, nil
+	}
+
+	return &ScanResult{Status: ScanClean, Scanner: p.name}, nil
+}
+
+func newScanFileSystem(path string, content string, scanners ...Scanner) *FileSystem {
+	return New(&Config{
+		Driver:         MemoryDriver,
+		Scanners:       scanners,
+		QuarantinePath: path + "quarantine/",
+	}).
+		Reader(&File{
+			Name:        "demo.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader(content),
+		}).
+		Path(path + "files/").
+		Name("demo.txt")
+}
+
+func TestScanBeforeSave(t *testing.T) {
+	driver := DefaultMemoryStorage()
+	scanner := &stubScanner{name: "stub", threat: "EICAR"}
+	scanner.check = func() {
+		exists, _ := driver.Exists(context.Background(), "scan-before/files/demo.txt")
+		if exists {
+			t.Errorf("Scan: file was saved before the scan finished")
+		}
+	}
+
+	fileInfo, err := newScanFileSystem("scan-before/", "hello EICAR", scanner).Save()
+	if err == nil {
+		t.Fatalf("Save: want error for infected file")
+	}
+	if fileInfo == nil || fileInfo.ScanStatus != ScanInfected || fileInfo.Path != "scan-before/quarantine/demo.txt" {
+		t.Fatalf("Save: got %+v, want infected file in quarantine", fileInfo)
+	}
+
+	exists, err := driver.Exists(context.Background(), "scan-before/files/demo.txt")
+	if err != nil || exists {
+		t.Fatalf("Save: infected file reached the save path: %v, %v", exists, err)
+	}
+	exists, err = driver.Exists(context.Background(), "scan-before/quarantine/demo.txt")
+	if err != nil || !exists {
+		t.Fatalf("Save: infected file was not quarantined: %v, %v", exists, err)
+	}
+}
+
+func TestScanClean(t *testing.T) {
+	driver := DefaultMemoryStorage()
+	fileInfo, err := newScanFileSystem("scan-clean/", "hello", &stubScanner{name: "a"}, &stubScanner{name: "b"}).Save()
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if fileInfo.ScanStatus != ScanClean || fileInfo.ScanResult != "a,b" {
+		t.Fatalf("Save: got %s %s, want clean a,b", fileInfo.ScanStatus, fileInfo.ScanResult)
+	}
+
+	exists, err := driver.Exists(context.Background(), "scan-clean/files/demo.txt")
+	if err != nil || !exists {
+		t.Fatalf("Save: clean file is missing: %v, %v", exists, err)
+	}
+}
+
+func TestScanFailOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		failOpen bool
+		status   string
+		saved    bool
+	}{
+		{"fail closed", "hello", false, "", false},
+		{"fail open clean", "hello", true, ScanError, true},
+		{"fail open infected", "hello EICAR", true, ScanInfected, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "scan-fail-open/" + string(rune('a'+i)) + "/"
+			broken := &stubScanner{name: "broken", err: errors.New("timeout")}
+			rule := &stubScanner{name: "rule", threat: "EICAR"}
+
+			fileSystem := newScanFileSystem(path, tt.content, broken, rule)
+			fileSystem.Config.ScanFailOpen = tt.failOpen
+			fileInfo, err := fileSystem.Save()
+			if tt.saved != (err == nil) {
+				t.Fatalf("Save: got error %v, want saved %v", err, tt.saved)
+			}
+			if fileSystem.File.ScanStatus != tt.status {
+				t.Fatalf("Save: got status %q, want %q", fileSystem.File.ScanStatus, tt.status)
+			}
+			if tt.failOpen && !rule.called {
+				t.Fatalf("Save: scanners after the failed one were skipped")
+			}
+			if tt.saved && fileInfo.ScanResult != "broken: timeout" {
+				t.Fatalf("Save: got result %q, want the scanner error", fileInfo.ScanResult)
+			}
+
+			exists, err := DefaultMemoryStorage().Exists(context.Background(), path+"files/demo.txt")
+			if err != nil || exists != tt.saved {
+				t.Fatalf("Save: got saved %v, %v, want %v", exists, err, tt.saved)
+			}
+		})
+	}
+}
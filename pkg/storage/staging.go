@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// 暂存的文件内容，检查及扫描通过后再写入存储驱动，未通过的文件不会出现在保存路径中
+type staging struct {
+	content []byte   // 二进制内容
+	file    *os.File // 数据流写入的临时文件
+}
+
+// 读取暂存的文件内容，每次调用都从头读取
+func (p *staging) Open() (io.ReadCloser, error) {
+	if p.file == nil {
+		return io.NopCloser(bytes.NewReader(p.content)), nil
+	}
+
+	_, err := p.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(p.file), nil
+}
+
+// 删除临时文件
+func (p *staging) Close() error {
+	if p.file == nil {
+		return nil
+	}
+
+	p.file.Close()
+
+	return os.Remove(p.file.Name())
+}
+
+// 暂存文件内容，同时统计大小并计算哈希值；二进制内容直接使用，数据流写入系统临时目录
+func (p *FileSystem) stage() (*staging, error) {
+	sizeReader := &sizeReader{reader: p.reader(), limit: p.limitSize()}
+	sha256New := sha256.New()
+	reader := io.TeeReader(sizeReader, sha256New)
+
+	result := &staging{content: p.File.Content}
+	writer := io.Discard
+	if p.File.Reader != nil {
+		file, err := os.CreateTemp("", "quark-upload-*")
+		if err != nil {
+			return nil, err
+		}
+		result = &staging{file: file}
+		writer = file
+	}
+
+	_, err := io.Copy(writer, reader)
+	if sizeReader.exceeded {
+		err = p.sizeError()
+	}
+	if err == nil {
+		err = p.context().Err()
+	}
+	if err != nil {
+		result.Close()
+		return nil, err
+	}
+
+	p.File.Hash = hex.EncodeToString(sha256New.Sum(nil))
+	p.File.Size = sizeReader.size
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDedupBeforeSave(t *testing.T) {
+	scanner := &stubScanner{name: "stub"}
+	hashes := []string{}
+	fileSystem := New(&Config{
+		Driver:   MemoryDriver,
+		Scanners: []Scanner{scanner},
+	}).
+		Reader(&File{
+			Name:        "demo.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader("hello dedup"),
+		}).
+		Path("dedup/files/").
+		Name("demo.txt").
+		Dedup(func(hash string) (*FileInfo, error) {
+			hashes = append(hashes, hash)
+			return &FileInfo{Path: "dedup/files/origin.txt", Url: "/origin.txt", ScanStatus: ScanClean}, nil
+		})
+
+	fileInfo, err := fileSystem.Save()
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// 数据流上传同样在写入存储前计算哈希值
+	if len(hashes) != 1 || hashes[0] != fileInfo.Hash || fileInfo.Hash == "" {
+		t.Fatalf("Save: dedup got hashes %v, want %s", hashes, fileInfo.Hash)
+	}
+	if fileInfo.Path != "dedup/files/origin.txt" || fileInfo.Url != "/origin.txt" || fileInfo.ScanStatus != ScanClean {
+		t.Fatalf("Save: got %+v, want the existing file", fileInfo)
+	}
+	if fileInfo.Name != "demo.txt" || fileInfo.Size != int64(len("hello dedup")) {
+		t.Fatalf("Save: got %s %d, want the uploaded name and size", fileInfo.Name, fileInfo.Size)
+	}
+	if scanner.called {
+		t.Fatalf("Save: duplicate file was scanned")
+	}
+
+	exists, err := DefaultMemoryStorage().Exists(context.Background(), "dedup/files/demo.txt")
+	if err != nil || exists {
+		t.Fatalf("Save: duplicate file was saved: %v, %v", exists, err)
+	}
+}
@@ -1,33 +1,31 @@
 package storage
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
-	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
-	"os"
-	"strconv"
 	"strings"
 
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/gabriel-vasile/mimetype"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
-	"github.com/quarkcloudio/quark-go/v2/pkg/utils/file"
+	"github.com/quarkcloudio/quark-go/v2/pkg/i18n"
+	"github.com/quarkcloudio/quark-go/v2/pkg/telemetry"
 	"github.com/quarkcloudio/quark-go/v2/pkg/utils/rand"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
-	OssDriver   = "oss"
-	LocalDriver = "local"
-	MinioDriver = "minio"
+	OssDriver    = "oss"
+	LocalDriver  = "local"
+	MinioDriver  = "minio"
+	S3Driver     = "s3"
+	MemoryDriver = "memory"
 )
 
 // OSS配置
@@ -50,6 +48,17 @@ type MinioConfig struct {
 	Domain          string // OSS自定义域名
 }
 
+// S3配置
+type S3Config struct {
+	Endpoint        string // Endpoint，为空时使用AWS S3，例如：http://127.0.0.1:9000
+	Region          string // Region，默认为us-east-1
+	AccessKeyID     string // AccessKeyID
+	SecretAccessKey string // SecretAccessKey
+	BucketName      string // BucketName
+	UsePathStyle    bool   // 使用路径形式访问Bucket，兼容S3协议的存储一般需要开启
+	Domain          string // 自定义域名
+}
+
 // 配置
 type Config struct {
 	LimitSize        int64        // 限制文件大小
@@ -63,19 +72,32 @@ type Config struct {
 	CheckFileExist   bool         // 检测文件是否已存在
 	OSSConfig        *OSSConfig   // OSS配置
 	MinioConfig      *MinioConfig // Minio配置
+	S3Config         *S3Config    // S3配置
+	Visibility       string       // 文件可见性：public、private，默认public
+	Scanners         []Scanner    // 文件内容扫描器
+	QuarantinePath   string       // 隔离目录，未通过扫描的文件移动到该目录，为空时直接删除
+	ScanFailOpen     bool         // 扫描器出错时继续执行其余扫描器，均未发现威胁时仍然保存文件，扫描状态记为error
+	Dedup            Deduplicator // 去重检查，已保存过相同内容的文件时不再扫描及写入存储
 }
 
+// 去重检查，根据文件内容的哈希值查找已保存的相同文件，未找到时返回nil
+type Deduplicator func(hash string) (*FileInfo, error)
+
 // 文件结构体
 type File struct {
-	Header      map[string][]string // map[Content-Disposition:[form-data; name="file"; filename="demo.jpg"] Content-Type:[image/jpeg]]
-	Name        string              // 文件名称
-	Size        int64               // 文件大小
-	Ext         string              // 文件扩展名
-	ContentType string              // 文件类型
-	Content     []byte              // 文件内容
-	Hash        string              // 文件哈希值
-	Width       int                 // 如果为图片，则返回宽度
-	Height      int                 // 如果为图片，则返回高度
+	Header        map[string][]string // map[Content-Disposition:[form-data; name="file"; filename="demo.jpg"] Content-Type:[image/jpeg]]
+	Name          string              // 文件名称
+	Size          int64               // 文件大小，使用数据流且大小未知时为0
+	Ext           string              // 文件扩展名
+	ContentType   string              // 文件类型
+	Content       []byte              // 文件内容，设置Reader时可为空
+	Reader        io.Reader           // 文件数据流，保存时边读取边写入存储驱动，不会整体读入内存
+	Hash          string              // 文件哈希值
+	Width         int                 // 如果为图片，则返回宽度
+	Height        int                 // 如果为图片，则返回高度
+	ScanStatus    string              // 扫描状态，未配置扫描器时为空
+	ScanResult    string              // 扫描结果，通过时为扫描器名称，未通过时为扫描器名称及威胁名称
+	QuarantineKey string              // 未通过扫描时隔离文件的保存路径
 }
 
 // 文件信息
@@ -89,12 +111,18 @@ type FileInfo struct {
 	Hash        string `json:"hash"`        // 文件哈希值
 	Width       int    `json:"width"`       // 如果为图片，则返回宽度
 	Height      int    `json:"height"`      // 如果为图片，则返回高度
+	ScanStatus  string `json:"scanStatus"`  // 扫描状态，未配置扫描器时为空
+	ScanResult  string `json:"scanResult"`  // 扫描结果
 }
 
 // 结构体
 type FileSystem struct {
-	Config *Config // 配置信息
-	File   *File   // 文件信息
+	Config   *Config         // 配置信息
+	File     *File           // 文件信息
+	ctx      context.Context // 上下文，取消后终止上传
+	stream   *bufio.Reader   // 带缓冲的文件数据流，用于预读文件头
+	existing *FileInfo       // 去重检查找到的已保存文件
+	quota    int64           // 剩余存储空间，为0时不限制
 }
 
 // 初始化对象
@@ -108,26 +136,70 @@ func New(config *Config) *FileSystem {
 	}
 }
 
-// 设置文件信息
+// 设置上下文，通常为当前请求的上下文，请求取消后终止上传
+func (p *FileSystem) WithContext(ctx context.Context) *FileSystem {
+	p.ctx = ctx
+
+	return p
+}
+
+// 获取上下文
+func (p *FileSystem) context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+
+	return p.ctx
+}
+
+// 设置文件信息，文件可以是二进制内容或数据流
 func (p *FileSystem) Reader(file *File) *FileSystem {
-	if file.Size == 0 {
+	if file.Size == 0 && file.Reader == nil {
 		file.Size = int64(len(file.Content))
 	}
-	if file.ContentType == "" {
-		if file.Header != nil {
-			if len(file.Header["Content-Type"]) > 0 {
-				file.ContentType = file.Header["Content-Type"][0]
-			}
-		} else {
-			file.ContentType = mimetype.Detect(file.Content).String()
+
+	p.File = file
+	p.stream = nil
+	p.existing = nil
+
+	if file.ContentType == "" && file.Header != nil {
+		if len(file.Header["Content-Type"]) > 0 {
+			file.ContentType = file.Header["Content-Type"][0]
 		}
 	}
 
-	p.File = file
+	// 未提供文件类型时根据文件头检测
+	if file.ContentType == "" || file.ContentType == "application/octet-stream" {
+		file.ContentType = mimetype.Detect(p.head()).String()
+	}
 
 	return p
 }
 
+// 获取文件数据流
+func (p *FileSystem) reader() *bufio.Reader {
+	if p.stream == nil {
+		reader := p.File.Reader
+		if reader == nil {
+			reader = bytes.NewReader(p.File.Content)
+		}
+		p.stream = bufio.NewReaderSize(reader, headSize)
+	}
+
+	return p.stream
+}
+
+// 获取文件头，预读的数据不会从数据流中消耗
+func (p *FileSystem) head() []byte {
+	if p.File.Reader == nil {
+		return p.File.Content
+	}
+
+	head, _ := p.reader().Peek(headSize)
+
+	return head
+}
+
 // 设置文件标头
 func (p *FileSystem) FileHeader(fileHeader map[string][]string) *FileSystem {
 	p.File.Header = fileHeader
@@ -145,6 +217,9 @@ func (p *FileSystem) FileName(fileName string) *FileSystem {
 // 设置二进制内容
 func (p *FileSystem) FileContent(fileContent []byte) *FileSystem {
 	p.File.Content = fileContent
+	p.File.Reader = nil
+	p.File.Size = int64(len(fileContent))
+	p.stream = nil
 
 	return p
 }
@@ -179,10 +254,10 @@ func (p *FileSystem) LimitImageHeight(limitImageHeight int) *FileSystem {
 
 // 读取图片宽高
 func (p *FileSystem) WithImageWH() *FileSystem {
-	byteReader := bytes.NewReader(p.File.Content)
+	byteReader := bytes.NewReader(p.head())
 	imageConfig, _, err := image.DecodeConfig(byteReader)
 	if err != nil {
-		fmt.Println(err)
+		telemetry.Logger().WarnContext(p.context(), "storage: decode image size failed", "name", p.File.Name, "error", err)
 		return p
 	}
 
@@ -227,17 +302,31 @@ func (p *FileSystem) Name(name string) *FileSystem {
 	return p
 }
 
-// 计算文件哈希值
+// 设置去重检查，文件内容的哈希值在暂存时计算，已保存过相同内容的文件时Save返回已有文件的路径
+func (p *FileSystem) Dedup(dedup Deduplicator) *FileSystem {
+	p.Config.Dedup = dedup
+
+	return p
+}
+
+// 计算文件内容的哈希值，相同内容的文件哈希值相同，数据流在保存时计算哈希值，保存前调用返回ErrHashUnavailable，需要去重时使用Dedup
 func (p *FileSystem) GetFileHash() (string, error) {
 	var (
 		hashValue string
 		err       error
 	)
 
+	if p.File.Hash != "" {
+		return p.File.Hash, nil
+	}
+
+	if p.File.Reader != nil {
+		return hashValue, ErrHashUnavailable
+	}
+
 	sha256New := sha256.New()
 
-	byteReader := bytes.NewReader(append(p.File.Content, []byte(p.File.Name)...))
-	_, err = io.Copy(sha256New, byteReader)
+	_, err = sha256New.Write(p.File.Content)
 	if err != nil {
 		return hashValue, err
 	}
@@ -247,15 +336,16 @@ func (p *FileSystem) GetFileHash() (string, error) {
 	return hashValue, nil
 }
 
-// 检查文件大小
+// 检查文件大小，大小未知的数据流在保存时检查
 func (p *FileSystem) checkFileSize() error {
 	var err error
-	if p.Config.LimitSize == 0 {
+	limitSize := p.limitSize()
+	if limitSize == 0 {
 		return err
 	}
 
-	if p.File.Size > p.Config.LimitSize {
-		err = errors.New("上传文件大小超出限制！")
+	if p.File.Size > limitSize {
+		err = p.sizeError()
 	}
 
 	return err
@@ -287,7 +377,7 @@ func (p *FileSystem) checkFileType() error {
 
 	limitText = strings.Trim(limitText, ",")
 	if !checkReuslt {
-		return errors.New("文件类型 " + p.File.ContentType + " 不合法，" + "请上传 " + limitText + " 格式的文件")
+		return i18n.NewError("storage.type_invalid", p.File.ContentType, limitText)
 	}
 
 	return err
@@ -300,17 +390,14 @@ func (p *FileSystem) checkImageWH() error {
 		return err
 	}
 
-	byteReader := bytes.NewReader(p.File.Content)
+	byteReader := bytes.NewReader(p.head())
 	imageConfig, _, err := image.DecodeConfig(byteReader)
 	if err != nil {
 		return err
 	}
 
 	if imageConfig.Width != p.Config.LimitImageWidth || imageConfig.Height != p.Config.LimitImageHeight {
-		limitW := strconv.Itoa(p.Config.LimitImageWidth)
-		limitH := strconv.Itoa(p.Config.LimitImageHeight)
-
-		err = errors.New("请上传 " + limitW + "*" + limitH + " 尺寸的图片")
+		err = i18n.NewError("storage.image_size_invalid", p.Config.LimitImageWidth, p.Config.LimitImageHeight)
 	}
 
 	return err
@@ -337,11 +424,32 @@ func (p *FileSystem) CheckFile() error {
 	return err
 }
 
-// 保存文件到本地
-func (p *FileSystem) SaveToLocal() error {
+// 开始存储操作的span，操作期间驱动使用span的上下文，返回的方法用于结束span
+func (p *FileSystem) startSpan(name string, driverName string) func(err error) {
+	parent := p.ctx
+	ctx, span := telemetry.StartSpan(p.context(), name, attribute.String("storage.driver", driverName))
+	p.ctx = ctx
+
+	return func(err error) {
+		span.SetAttributes(attribute.String("storage.key", p.Config.SavePath+p.Config.SaveName))
+		if p.File != nil {
+			span.SetAttributes(attribute.Int64("storage.size", p.File.Size))
+		}
+		telemetry.EndSpan(span, err)
+		p.ctx = parent
+	}
+}
+
+// 保存文件到指定驱动，处理扩展名、合法性检查、重命名及哈希值
+func (p *FileSystem) saveTo(driverName string, driver Driver) (err error) {
+	endSpan := p.startSpan("storage.save", driverName)
+	defer func() {
+		endSpan(err)
+	}()
+
 	savePath := p.Config.SavePath
 	if savePath == "" {
-		return errors.New("请设置保存路径")
+		return i18n.NewError("storage.path_required")
 	}
 
 	if p.Config.SaveName == "" {
@@ -351,12 +459,12 @@ func (p *FileSystem) SaveToLocal() error {
 	// 获取文件扩展名
 	fileExt := ContentTypeList[p.File.ContentType]
 	if fileExt == "" {
-		return errors.New("无法获取文件扩展名！")
+		return i18n.NewError("storage.ext_unknown")
 	}
 	p.File.Ext = fileExt
 
 	// 检查文件合法性
-	err := p.CheckFile()
+	err = p.CheckFile()
 	if err != nil {
 		return err
 	}
@@ -365,211 +473,147 @@ func (p *FileSystem) SaveToLocal() error {
 		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
 	}
 
-	if !file.IsExist(savePath) {
-		err := os.MkdirAll(savePath, os.ModeDir)
+	key := savePath + p.Config.SaveName
+	if p.Config.CheckFileExist {
+		exists, err := driver.Exists(p.context(), key)
 		if err != nil {
 			return err
 		}
-	}
-
-	saveName := p.Config.SaveName
-	if p.Config.CheckFileExist {
-		if file.IsExist(savePath + saveName) {
-			return errors.New("文件已存在：" + savePath + saveName)
+		if exists {
+			return i18n.NewError("storage.file_exists", key)
 		}
 	}
 
-	// 计算文件哈希值
-	fileHash, err := p.GetFileHash()
-	if err != nil {
-		return err
-	}
-	p.File.Hash = fileHash
-
-	f, err := os.OpenFile(savePath+saveName, os.O_WRONLY|os.O_CREATE, 0666) //根据文件名创建文件路径
+	// 先暂存文件，统计大小并计算哈希值，扫描通过后再写入保存路径
+	staged, err := p.stage()
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	byteReader := bytes.NewReader(p.File.Content)
-	io.Copy(f, byteReader)
-
-	return nil
-}
-
-// 保存文件到OSS
-func (p *FileSystem) SaveToOSS() error {
-	if p.Config.OSSConfig == nil {
-		return errors.New("请配置OSS信息")
-	}
-
-	savePath := p.Config.SavePath
-	if savePath == "" {
-		return errors.New("请设置保存路径")
-	}
-
-	if p.Config.SaveName == "" {
-		p.Config.SaveName = p.File.Name
-	}
-
-	// 获取文件扩展名
-	fileExt := ContentTypeList[p.File.ContentType]
-	if fileExt == "" {
-		return errors.New("无法获取文件扩展名！")
-	}
-	p.File.Ext = fileExt
+	defer staged.Close()
 
-	// 检查文件合法性
-	err := p.CheckFile()
-	if err != nil {
+	found, err := p.dedup()
+	if err != nil || found {
 		return err
 	}
 
-	if p.Config.SaveRandName {
-		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
-	}
-
-	saveName := p.Config.SaveName
-
-	// 计算文件哈希值
-	fileHash, err := p.GetFileHash()
-	if err != nil {
-		return err
+	err = p.scan(staged.Open)
+	if p.File.ScanStatus == ScanInfected {
+		if quarantineErr := p.quarantine(driver, staged.Open); quarantineErr != nil {
+			return quarantineErr
+		}
 	}
-	p.File.Hash = fileHash
-
-	client, err := oss.New(p.Config.OSSConfig.Endpoint, p.Config.OSSConfig.AccessKeyID, p.Config.OSSConfig.AccessKeySecret)
 	if err != nil {
 		return err
 	}
 
-	bucket, err := client.Bucket(p.Config.OSSConfig.BucketName)
+	reader, err := staged.Open()
 	if err != nil {
 		return err
 	}
+	defer reader.Close()
 
-	// 指定Object访问权限
-	objectAcl := oss.ObjectACL(oss.ACLPublicRead)
-	byteReader := bytes.NewReader(p.File.Content)
-	err = bucket.PutObject(savePath+saveName, byteReader, objectAcl)
+	err = driver.Put(p.context(), key, reader, p.File.Size, p.File.ContentType)
 	if err != nil {
 		return err
 	}
+	telemetry.AddUploadBytes(driverName, p.File.Size)
 
 	return nil
 }
 
-// 保存文件到Minio
-func (p *FileSystem) SaveToMinio() error {
-	if p.Config.MinioConfig == nil {
-		return errors.New("请配置Minio信息")
-	}
-
-	savePath := p.Config.SavePath
-	if savePath == "" {
-		return errors.New("请设置保存路径")
+// 根据哈希值查找已保存的相同文件，找到时使用已有文件的扫描结果
+func (p *FileSystem) dedup() (bool, error) {
+	if p.Config.Dedup == nil {
+		return false, nil
 	}
 
-	if p.Config.SaveName == "" {
-		p.Config.SaveName = p.File.Name
-	}
-
-	// 获取文件扩展名
-	fileExt := ContentTypeList[p.File.ContentType]
-	if fileExt == "" {
-		return errors.New("无法获取文件扩展名！")
+	existing, err := p.Config.Dedup(p.File.Hash)
+	if err != nil || existing == nil {
+		return false, err
 	}
-	p.File.Ext = fileExt
 
-	// 检查文件合法性
-	err := p.CheckFile()
-	if err != nil {
-		return err
-	}
+	p.existing = existing
+	p.File.ScanStatus = existing.ScanStatus
+	p.File.ScanResult = existing.ScanResult
 
-	if p.Config.SaveRandName {
-		p.Config.SaveName = rand.MakeAlphanumeric(40) + "." + p.File.Ext
-	}
-
-	saveName := p.Config.SaveName
-
-	// 计算文件哈希值
-	fileHash, err := p.GetFileHash()
-	if err != nil {
-		return err
-	}
-	p.File.Hash = fileHash
+	return true, nil
+}
 
-	ctx := context.Background()
+// 保存文件到本地
+func (p *FileSystem) SaveToLocal() error {
+	return p.saveTo(LocalDriver, NewLocalStorage())
+}
 
-	// Initialize minio client object.
-	minioClient, err := minio.New(p.Config.MinioConfig.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(p.Config.MinioConfig.AccessKeyID, p.Config.MinioConfig.SecretAccessKey, ""),
-		Secure: p.Config.MinioConfig.UseSSL,
-	})
+// 保存文件到OSS
+func (p *FileSystem) SaveToOSS() error {
+	driver, err := NewOSSStorage(p.Config.OSSConfig)
 	if err != nil {
 		return err
 	}
 
-	byteReader := bytes.NewReader(p.File.Content)
+	return p.saveTo(OssDriver, driver)
+}
 
-	// Upload the zip file with FPutObject
-	info, err := minioClient.PutObject(ctx, p.Config.MinioConfig.BucketName, saveName, byteReader, -1, minio.PutObjectOptions{ContentType: p.File.ContentType})
+// 保存文件到Minio
+func (p *FileSystem) SaveToMinio() error {
+	driver, err := NewMinioStorage(p.Config.MinioConfig)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Successfully uploaded %s of size %d\n", saveName, info.Size)
+	return p.saveTo(MinioDriver, driver)
+}
 
-	return nil
+// 获取当前配置的驱动
+func (p *FileSystem) GetDriver() (Driver, error) {
+	return GetDriver(p.Config.Driver, p.Config)
 }
 
 // 保存文件
 func (p *FileSystem) Save() (fileInfo *FileInfo, err error) {
-	var fileUrl = ""
 
-	switch p.Config.Driver {
-	case LocalDriver:
-		err = p.SaveToLocal()
-		if err != nil {
-			return fileInfo, err
-		}
+	// 上下文已取消时不再上传
+	err = p.context().Err()
+	if err != nil {
+		return fileInfo, err
+	}
 
-		fileUrl = p.Config.SavePath + p.Config.SaveName
-	case OssDriver:
-		err = p.SaveToOSS()
-		if err != nil {
-			return fileInfo, err
-		}
+	driver, err := p.GetDriver()
+	if err != nil {
+		return fileInfo, err
+	}
 
-		if p.Config.OSSConfig.Domain != "" {
-			fileUrl = "//" + p.Config.OSSConfig.Domain + "/" + p.Config.SavePath + p.Config.SaveName
-		} else {
-			fileUrl = "//" + p.Config.OSSConfig.BucketName + "." + p.Config.OSSConfig.Endpoint + "/" + p.Config.SavePath + p.Config.SaveName
-		}
-	case MinioDriver:
-		err = p.SaveToMinio()
-		if err != nil {
-			return fileInfo, err
-		}
+	err = p.saveTo(p.Config.Driver, driver)
+
+	// 未通过扫描时返回隔离文件的信息，用于记录扫描结果
+	if p.File.ScanStatus == ScanInfected {
+		return p.fileInfo(p.File.QuarantineKey, ""), err
+	}
+	if err != nil {
+		return fileInfo, err
+	}
 
-		fileUrl = "//" + p.Config.MinioConfig.Domain + "/" + p.Config.SavePath + p.Config.SaveName
-	default:
-		return fileInfo, errors.New("上传驱动未知")
+	// 已保存过相同内容的文件
+	if p.existing != nil {
+		return p.fileInfo(p.existing.Path, p.existing.Url), err
 	}
 
-	fileInfo = &FileInfo{
+	return p.fileInfo(p.Config.SavePath+p.Config.SaveName, driver.URL(p.Config.SavePath+p.Config.SaveName)), err
+}
+
+// 获取保存后的文件信息
+func (p *FileSystem) fileInfo(path string, url string) *FileInfo {
+	return &FileInfo{
 		p.File.Name,
 		p.File.Size,
 		p.File.Ext,
 		p.File.ContentType,
-		p.Config.SavePath + p.Config.SaveName,
-		fileUrl,
+		path,
+		url,
 		p.File.Hash,
 		p.File.Width,
 		p.File.Height,
+		p.File.ScanStatus,
+		p.File.ScanResult,
 	}
-
-	return fileInfo, err
 }
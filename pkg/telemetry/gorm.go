@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// 记录查询开始时间、span及查询原上下文的key
+const (
+	gormStartKey   = "telemetry:start"
+	gormSpanKey    = "telemetry:span"
+	gormContextKey = "telemetry:context"
+)
+
+// GORM插件，记录数据库查询耗时，开启链路追踪时为每次查询创建span，使用方法：db.Use(&telemetry.GormPlugin{})
+//
+// 查询需要通过db.WithContext(ctx)传入请求的上下文，span才会关联到请求的链路
+type GormPlugin struct{}
+
+// 插件名称
+func (p *GormPlugin) Name() string {
+	return "telemetry"
+}
+
+// 注册回调
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	registers := []error{
+		callback.Create().Before("gorm:create").Register("telemetry:before_create", p.before("create")),
+		callback.Create().After("gorm:create").Register("telemetry:after_create", p.after("create")),
+		callback.Query().Before("gorm:query").Register("telemetry:before_query", p.before("query")),
+		callback.Query().After("gorm:query").Register("telemetry:after_query", p.after("query")),
+		callback.Update().Before("gorm:update").Register("telemetry:before_update", p.before("update")),
+		callback.Update().After("gorm:update").Register("telemetry:after_update", p.after("update")),
+		callback.Delete().Before("gorm:delete").Register("telemetry:before_delete", p.before("delete")),
+		callback.Delete().After("gorm:delete").Register("telemetry:after_delete", p.after("delete")),
+		callback.Row().Before("gorm:row").Register("telemetry:before_row", p.before("row")),
+		callback.Row().After("gorm:row").Register("telemetry:after_row", p.after("row")),
+		callback.Raw().Before("gorm:raw").Register("telemetry:before_raw", p.before("raw")),
+		callback.Raw().After("gorm:raw").Register("telemetry:after_raw", p.after("raw")),
+	}
+	for _, err := range registers {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 查询开始
+func (p *GormPlugin) before(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		db.InstanceSet(gormStartKey, time.Now())
+
+		ctx, span := StartSpan(db.Statement.Context, "gorm."+operation, attribute.String("db.operation", operation))
+		db.InstanceSet(gormContextKey, db.Statement.Context)
+		db.InstanceSet(gormSpanKey, span)
+		db.Statement.Context = ctx
+	}
+}
+
+// 查询结束
+func (p *GormPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		ObserveDBQuery(operation, db.Statement.Table, time.Since(start))
+
+		value, ok = db.InstanceGet(gormSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+
+		// 恢复查询原来的上下文，同一查询对象再次执行时span不会嵌套在本次查询下
+		if value, ok := db.InstanceGet(gormContextKey); ok {
+			if ctx, ok := value.(context.Context); ok {
+				db.Statement.Context = ctx
+			}
+		}
+		span.SetAttributes(
+			attribute.String("db.sql.table", db.Statement.Table),
+			attribute.String("db.statement", db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+
+		// 未查询到记录属于正常的业务结果，不记录为错误
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		EndSpan(span, err)
+	}
+}
@@ -0,0 +1,32 @@
+package telemetry
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// 日志字段名称
+const (
+	RequestIdKey = "request_id" // 请求ID
+	TraceIdKey   = "trace_id"   // 链路ID，开启链路追踪时记录
+	AdminIdKey   = "admin_id"   // 当前登录的管理员ID
+	ResourceKey  = "resource"   // 资源名称
+	UriKeyKey    = "uri_key"    // 行为的唯一标识
+)
+
+// 框架使用的日志记录器
+var logger atomic.Value
+
+// 设置框架使用的日志记录器，为nil时使用slog.Default()
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// 获取框架使用的日志记录器，未设置时使用slog.Default()
+func Logger() *slog.Logger {
+	if l, ok := logger.Load().(*slog.Logger); ok && l != nil {
+		return l
+	}
+
+	return slog.Default()
+}
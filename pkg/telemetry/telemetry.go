@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// 指标名称前缀
+const Namespace = "quark"
+
+// 登录结果
+const (
+	LoginSuccess = "success" // 登录成功
+	LoginFailure = "failure" // 登录失败
+)
+
+// 请求耗时的区间上限，单位秒
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// 指标注册表，可以注册自定义的指标，例如：telemetry.Registry.MustRegister(collector)
+var Registry = prometheus.NewRegistry()
+
+var (
+	// 请求数，标签：method为请求方法，route为模板路由，status为状态码
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+
+	// 请求耗时
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and status.",
+		Buckets:   DurationBuckets,
+	}, []string{"method", "route", "status"})
+
+	// 资源操作次数，标签：operation为操作类型，uri_key为行为的唯一标识
+	resourceOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "resource_operations_total",
+		Help:      "Total number of resource operations.",
+	}, []string{"resource", "operation", "uri_key"})
+
+	// 上传的字节数
+	uploadBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "upload_bytes_total",
+		Help:      "Total bytes uploaded by storage driver.",
+	}, []string{"driver"})
+
+	// 数据库查询耗时，标签：operation为create、query、update、delete、row、raw
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by operation and table.",
+		Buckets:   DurationBuckets,
+	}, []string{"operation", "table"})
+
+	// 登录次数，标签：result为success、failure
+	loginsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "logins_total",
+		Help:      "Total number of login attempts by result.",
+	}, []string{"guard", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		resourceOperationsTotal,
+		uploadBytesTotal,
+		dbQueryDuration,
+		loginsTotal,
+	)
+}
+
+// Prometheus文本格式的指标接口
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// 记录请求，route为模板路由，例如：/api/admin/:resource/index
+func ObserveRequest(method string, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	requestsTotal.With(labels).Inc()
+	requestDuration.With(labels).Observe(duration.Seconds())
+}
+
+// 记录资源操作，operation为index、store、save、delete、import、export、action，执行行为时uriKey为行为的唯一标识
+func IncResourceOperation(resource string, operation string, uriKey string) {
+	resourceOperationsTotal.WithLabelValues(resource, operation, uriKey).Inc()
+}
+
+// 记录上传的字节数
+func AddUploadBytes(driver string, size int64) {
+	if size > 0 {
+		uploadBytesTotal.WithLabelValues(driver).Add(float64(size))
+	}
+}
+
+// 记录数据库查询耗时
+func ObserveDBQuery(operation string, table string, duration time.Duration) {
+	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+// 记录登录结果，result为LoginSuccess或LoginFailure
+func IncLogin(guard string, result string) {
+	loginsTotal.WithLabelValues(guard, result).Inc()
+}
@@ -0,0 +1,153 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 链路追踪的名称
+const TracerName = "github.com/quarkcloudio/quark-go/v2"
+
+// 链路导出方式
+const (
+	TraceStdout   = "stdout"   // 输出到标准输出或指定的Writer，用于本地调试
+	TraceOTLP     = "otlp"     // 通过OTLP HTTP协议导出到采集器，默认地址localhost:4318
+	TraceOTLPGRPC = "otlpgrpc" // 通过OTLP gRPC协议导出到采集器，默认地址localhost:4317
+)
+
+// 链路追踪配置
+type TracingConfig struct {
+	Exporter    string            // 导出方式，stdout、otlp、otlpgrpc
+	Endpoint    string            // 采集器地址，例如：localhost:4318，为空时使用OTEL_EXPORTER_OTLP_ENDPOINT环境变量或默认地址
+	Headers     map[string]string // 导出到采集器时附加的请求头，例如认证信息
+	Insecure    bool              // 不使用TLS连接采集器
+	ServiceName string            // 服务名称，默认为quark
+	SampleRatio float64           // 采样比例，小于等于0或大于等于1时全部采样
+	Writer      io.Writer         // stdout方式的输出，默认为os.Stdout
+}
+
+// 开启链路追踪，设置全局的TracerProvider及W3C Trace Context传播方式，返回的shutdown用于关闭时导出剩余的链路
+//
+// 未开启时使用OpenTelemetry默认的空实现，创建的span不会被记录
+func InitTracing(config *TracingConfig) (shutdown func(ctx context.Context) error, err error) {
+	exporter, err := newExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = Namespace
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 && config.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(config.SampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// 创建链路导出器
+func newExporter(config *TracingConfig) (sdktrace.SpanExporter, error) {
+	ctx := context.Background()
+
+	switch config.Exporter {
+	case TraceStdout:
+		writer := config.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(writer))
+	case TraceOTLP:
+		options := []otlptracehttp.Option{}
+		if config.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(config.Headers))
+		}
+		return otlptracehttp.New(ctx, options...)
+	case TraceOTLPGRPC:
+		options := []otlptracegrpc.Option{}
+		if config.Endpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracegrpc.WithHeaders(config.Headers))
+		}
+		return otlptracegrpc.New(ctx, options...)
+	}
+
+	return nil, errors.New("telemetry: unknown trace exporter " + config.Exporter)
+}
+
+// 获取框架的Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// 开始一个span，返回的ctx用于创建子span
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// 从请求头中恢复上游的链路，并开始一个服务端span，用于适配的框架透传traceparent
+func StartServerSpan(ctx context.Context, header http.Header, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// 将当前链路写入请求头，用于调用下游服务
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// 结束span，err不为空时记录错误
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// 获取当前链路ID，未开启链路追踪时返回空字符串
+func TraceId(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
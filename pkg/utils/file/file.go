@@ -1,6 +1,10 @@
 package file
 
-import "os"
+import (
+	"math"
+	"os"
+	"strconv"
+)
 
 // 判断文件路径是否存在
 func IsExist(path string) bool {
@@ -13,3 +17,16 @@ func IsExist(path string) bool {
 
 	return true
 }
+
+// 格式化文件大小，例如：1.5MB
+func FormatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	index := 0
+	for value >= 1024 && index < len(units)-1 {
+		value = value / 1024
+		index++
+	}
+
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64) + units[index]
+}
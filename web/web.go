@@ -0,0 +1,8 @@
+package web
+
+import "embed"
+
+// 内置的WEB资源，包含后台及小程序前端文件；不包含运行时上传到app/storage目录的文件
+//
+//go:embed app/admin app/miniapp app/favicon.ico app/robots.txt
+var FS embed.FS